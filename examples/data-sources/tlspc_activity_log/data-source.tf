@@ -0,0 +1,9 @@
+data "tlspc_activity_log" "recent_logins" {
+  type  = "Authentication"
+  from  = timeadd(plantimestamp(), "-24h")
+  limit = 50
+}
+
+output "recent_logins" {
+  value = [for e in data.tlspc_activity_log.recent_logins.entries : "${e.date}: ${e.message}"]
+}
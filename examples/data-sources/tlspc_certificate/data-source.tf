@@ -0,0 +1,14 @@
+data "tlspc_certificate" "web" {
+  id = var.certificate_id
+}
+
+# Import the certificate into ACM, with the key it was requested with
+resource "aws_acm_certificate" "web" {
+  certificate_body  = data.tlspc_certificate.web.certificate_pem
+  certificate_chain = data.tlspc_certificate.web.chain_pem
+  private_key       = var.private_key_pem
+}
+
+output "dns_names" {
+  value = data.tlspc_certificate.web.parsed.dns_names
+}
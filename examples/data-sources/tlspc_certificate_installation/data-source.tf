@@ -0,0 +1,11 @@
+data "tlspc_certificate_installation" "lb" {
+  certificate_id = var.certificate_id
+  machine_id     = data.tlspc_machine.lb.id
+
+  lifecycle {
+    postcondition {
+      condition     = self.status == "SUCCESS"
+      error_message = "Certificate installation is ${self.status}: ${self.failure_reason}"
+    }
+  }
+}
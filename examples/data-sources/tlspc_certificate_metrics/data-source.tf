@@ -0,0 +1,14 @@
+data "tlspc_certificate_metrics" "active" {
+  status = "ACTIVE"
+}
+
+output "expiring_soon" {
+  value = data.tlspc_certificate_metrics.active.by_expiry["30_days"]
+}
+
+check "no_expired_certificates" {
+  assert {
+    condition     = data.tlspc_certificate_metrics.active.by_expiry["expired"] == 0
+    error_message = "There are expired certificates which haven't been retired."
+  }
+}
@@ -0,0 +1,17 @@
+data "tlspc_firefly_bootstrap" "firefly" {
+  id = resource.tlspc_firefly_config.firefly.id
+}
+
+resource "helm_release" "firefly" {
+  name       = "firefly"
+  namespace  = "venafi"
+  repository = "oci://registry.venafi.cloud/charts"
+  chart      = "firefly"
+
+  values = [data.tlspc_firefly_bootstrap.firefly.values]
+
+  set {
+    name  = "acceptTerms"
+    value = "true"
+  }
+}
@@ -0,0 +1,3 @@
+data "tlspc_firefly_policy" "shared" {
+  name = "Shared Firefly Policy"
+}
@@ -0,0 +1,3 @@
+data "tlspc_machine" "lb" {
+  name = "lb-1.example.com"
+}
@@ -0,0 +1,8 @@
+data "tlspc_plugin" "digicert" {
+  type = "CA"
+  name = "DigiCert"
+}
+
+output "digicert_plugin_version" {
+  value = jsondecode(data.tlspc_plugin.digicert.manifest).version
+}
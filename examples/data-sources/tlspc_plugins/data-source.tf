@@ -0,0 +1,7 @@
+data "tlspc_plugins" "ca" {
+  type = "CA"
+}
+
+output "ca_connectors" {
+  value = [for p in data.tlspc_plugins.ca.plugins : p.name]
+}
@@ -0,0 +1,8 @@
+data "tlspc_policy_violations" "last_week" {
+  application_id = tlspc_application.app.id
+  from           = timeadd(plantimestamp(), "-168h")
+}
+
+output "policy_violations" {
+  value = [for v in data.tlspc_policy_violations.last_week.violations : "${v.date}: ${v.message}"]
+}
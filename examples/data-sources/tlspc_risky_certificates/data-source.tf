@@ -0,0 +1,12 @@
+data "tlspc_risky_certificates" "app" {
+  application_id    = tlspc_application.app.id
+  status            = "ACTIVE"
+  max_validity_days = 200
+}
+
+check "no_risky_certificates" {
+  assert {
+    condition     = length(data.tlspc_risky_certificates.app.ids) == 0
+    error_message = "Risky certificates: ${join(", ", [for c in data.tlspc_risky_certificates.app.certificates : "${c.name} (${join(", ", c.risks)})"])}"
+  }
+}
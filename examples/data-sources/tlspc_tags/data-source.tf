@@ -0,0 +1,12 @@
+data "tlspc_tags" "all" {}
+
+# Referencing other objects in variable validation requires Terraform 1.9 or
+# later.
+variable "environment" {
+  type = string
+
+  validation {
+    condition     = contains(data.tlspc_tags.all.values["environment"], var.environment)
+    error_message = "environment must be one of the values allowed by the environment tag."
+  }
+}
@@ -0,0 +1,10 @@
+data "tlspc_team_members" "platform" {
+  name = "Platform Engineering"
+}
+
+# Make every owner of the team an owner of an application
+resource "tlspc_application" "app" {
+  name                = "TF Managed App"
+  owners              = [for o in data.tlspc_team_members.platform.owners : { type = "USER", owner = o.id }]
+  ca_template_aliases = { "${resource.tlspc_certificate_template.built_in.name}" = resource.tlspc_certificate_template.built_in.id }
+}
@@ -1 +1,6 @@
 data "tlspc_tenant" "default" {}
+
+locals {
+  # Skip Firefly resources where the tenant isn't entitled to Firefly
+  firefly_enabled = contains(data.tlspc_tenant.default.products, "Firefly")
+}
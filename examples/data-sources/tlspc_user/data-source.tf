@@ -1,3 +1,9 @@
 data "tlspc_user" "example" {
   email = "username@venafi.com"
 }
+
+# Choose between users sharing an email address
+data "tlspc_user" "active" {
+  email  = "username@venafi.com"
+  status = "ACTIVE"
+}
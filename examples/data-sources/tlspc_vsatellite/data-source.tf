@@ -0,0 +1,3 @@
+data "tlspc_vsatellite" "edge" {
+  name = "datacenter-1"
+}
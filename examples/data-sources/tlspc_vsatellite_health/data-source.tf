@@ -0,0 +1,23 @@
+data "tlspc_vsatellite_health" "edge" {
+  name              = "datacenter-1"
+  max_heartbeat_age = "5m"
+}
+
+resource "tlspc_machine" "lb" {
+  name          = "lb-1.example.com"
+  plugin_id     = data.tlspc_plugin.f5.id
+  vsatellite_id = data.tlspc_vsatellite_health.edge.id
+  owner         = tlspc_team.team.id
+  connection_details = jsonencode({
+    hostnameOrAddress = "lb-1.example.com"
+    username          = "admin"
+    password          = var.f5_password
+  })
+
+  lifecycle {
+    precondition {
+      condition     = data.tlspc_vsatellite_health.edge.healthy
+      error_message = "VSatellite datacenter-1 isn't healthy (status ${data.tlspc_vsatellite_health.edge.status}, last seen ${data.tlspc_vsatellite_health.edge.last_seen})."
+    }
+  }
+}
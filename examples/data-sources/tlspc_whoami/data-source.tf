@@ -0,0 +1,14 @@
+variable "tenant_id" {
+  type = string
+}
+
+data "tlspc_whoami" "current" {
+  expiry_warning_days = 14
+}
+
+check "tenant" {
+  assert {
+    condition     = data.tlspc_whoami.current.company_id == var.tenant_id
+    error_message = "The API key belongs to an unexpected tenant"
+  }
+}
@@ -0,0 +1,16 @@
+resource "tlspc_vsatellite" "edge" {
+  name = "datacenter-1"
+}
+
+# A fresh pairing code, which is never saved to plans or state, passed to
+# the VSatellite's VM through a write-only attribute.
+ephemeral "tlspc_vsatellite_pairing_code" "edge" {
+  vsatellite_id = tlspc_vsatellite.edge.id
+}
+
+resource "aws_ssm_parameter" "pairing_code" {
+  name             = "/vsatellite/edge/pairing-code"
+  type             = "SecureString"
+  value_wo         = ephemeral.tlspc_vsatellite_pairing_code.edge.pairing_code
+  value_wo_version = 1
+}
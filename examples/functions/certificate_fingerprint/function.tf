@@ -0,0 +1,3 @@
+output "fingerprint" {
+  value = provider::tlspc::certificate_fingerprint(file("${path.module}/cert.pem"))
+}
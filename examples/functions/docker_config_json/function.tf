@@ -0,0 +1,15 @@
+resource "kubernetes_secret" "venafi_registry" {
+  metadata {
+    name      = "venafi-image-pull-secret"
+    namespace = "venafi"
+  }
+
+  type = "kubernetes.io/dockerconfigjson"
+
+  data = {
+    ".dockerconfigjson" = provider::tlspc::docker_config_json(
+      resource.tlspc_registry_account.registry.oci_account_name,
+      resource.tlspc_registry_account.registry.oci_registry_token,
+    )
+  }
+}
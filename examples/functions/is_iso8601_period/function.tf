@@ -0,0 +1,9 @@
+variable "validity_period" {
+  type    = string
+  default = "P30D"
+
+  validation {
+    condition     = provider::tlspc::is_iso8601_period(var.validity_period)
+    error_message = "validity_period must be an ISO8601 period, e.g. P30D"
+  }
+}
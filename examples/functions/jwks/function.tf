@@ -0,0 +1,21 @@
+# Serve both the current and the next signing key while workloads move over
+# to the next one, then remove the current key.
+resource "aws_s3_object" "jwks" {
+  bucket       = aws_s3_bucket.oidc.id
+  key          = "jwks.json"
+  content_type = "application/json"
+  content = provider::tlspc::jwks([
+    tls_private_key.current.public_key_pem,
+    tls_private_key.next.public_key_pem,
+  ])
+}
+
+resource "tlspc_service_account_wif" "workload" {
+  name       = "workload"
+  owner      = tlspc_team.team.id
+  scopes     = ["certificate-issuance"]
+  jwks_uri   = "https://${aws_s3_bucket.oidc.bucket_regional_domain_name}/jwks.json"
+  issuer_url = "https://issuer.example.com"
+  audience   = "tlspc"
+  subject    = "workload"
+}
@@ -0,0 +1,11 @@
+locals {
+  cert = provider::tlspc::parse_certificate(file("${path.module}/cert.pem"))
+}
+
+output "expires" {
+  value = local.cert.not_after
+}
+
+output "is_rsa" {
+  value = startswith(local.cert.key_algorithm, "RSA_")
+}
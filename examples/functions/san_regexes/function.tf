@@ -0,0 +1,39 @@
+resource "tlspc_firefly_policy" "internal" {
+  name                = "internal"
+  validity_period     = "P7D"
+  key_usages          = ["digitalSignature"]
+  extended_key_usages = ["SERVER_AUTH"]
+
+  key_algorithm = {
+    allowed_values = ["EC_P256"]
+    default_value  = "EC_P256"
+  }
+
+  sans = {
+    dns_names = {
+      # ["^example\\.com$", "^.*\\.internal$"]
+      allowed_values  = provider::tlspc::san_regexes(["example.com", "*.internal"])
+      max_occurrences = 10
+      min_occurrences = 1
+      type            = "REQUIRED"
+    }
+    ip_addresses = {
+      allowed_values  = []
+      max_occurrences = 0
+      min_occurrences = 0
+      type            = "FORBIDDEN"
+    }
+    rfc822_names = {
+      allowed_values  = []
+      max_occurrences = 0
+      min_occurrences = 0
+      type            = "FORBIDDEN"
+    }
+    uris = {
+      allowed_values  = []
+      max_occurrences = 0
+      min_occurrences = 0
+      type            = "FORBIDDEN"
+    }
+  }
+}
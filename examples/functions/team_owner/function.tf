@@ -0,0 +1,7 @@
+resource "tlspc_application" "app" {
+  name = "TF Managed App"
+  owners = [
+    provider::tlspc::team_owner(resource.tlspc_team.team.id),
+  ]
+  ca_template_aliases = { "${resource.tlspc_certificate_template.built_in.name}" = resource.tlspc_certificate_template.built_in.id }
+}
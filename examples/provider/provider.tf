@@ -1,4 +1,45 @@
 provider "tlspc" {
-  apikey   = ""
-  endpoint = "https://api.venafi.eu"
+  apikey            = ""
+  endpoint          = "https://api.venafi.eu"
+  user_agent_suffix = "my-platform/2.3"
+}
+
+# The API key can be an ephemeral value, so that it's never saved to plans or
+# state (requires Terraform 1.10 or later).
+ephemeral "vault_kv_secret_v2" "tlspc" {
+  mount = "secret"
+  name  = "tlspc"
+}
+
+provider "tlspc" {
+  alias  = "vault"
+  apikey = ephemeral.vault_kv_secret_v2.tlspc.data.apikey
+}
+
+# In CI, authenticate as a service account limited to the scopes the pipeline
+# needs, rather than with a user's API key.
+provider "tlspc" {
+  alias = "ci"
+  service_account = {
+    client_id   = var.tlspc_client_id
+    private_key = ephemeral.vault_kv_secret_v2.tlspc.data.private_key
+  }
+}
+
+# Limit the requests in flight at once, so that large applies stay within the
+# tenant's rate limits.
+provider "tlspc" {
+  alias                   = "throttled"
+  apikey                  = var.tlspc_apikey
+  max_concurrent_requests = 4
+}
+
+# Pin the API's certificate, with a backup pin for when it's rotated.
+provider "tlspc" {
+  alias  = "pinned"
+  apikey = var.tlspc_apikey
+  pinned_spki_sha256 = [
+    "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+    "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg=",
+  ]
 }
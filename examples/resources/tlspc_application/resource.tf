@@ -1,5 +1,20 @@
 resource "tlspc_application" "app" {
   name                = "TF Managed App"
-  owners              = [{ type = "USER", owner = data.tlspc_user.owner.id }, { type = "TEAM", owner = resource.tlspc_team.team.id }]
+  owners              = [{ type = "USER", id = data.tlspc_user.owner.id }, { type = "TEAM", id = resource.tlspc_team.team.id }]
   ca_template_aliases = { "${resource.tlspc_certificate_template.built_in.name}" = resource.tlspc_certificate_template.built_in.id }
 }
+
+# Owners can also be given by user email and team name
+resource "tlspc_application" "by_name" {
+  name                = "TF Managed App by name"
+  owners              = [{ type = "USER", email = "owner@example.com" }, { type = "TEAM", name = "Platform" }]
+  ca_template_aliases = { "${resource.tlspc_certificate_template.built_in.name}" = resource.tlspc_certificate_template.built_in.id }
+}
+
+# Only manage the aliases declared here, leaving others attached elsewhere
+resource "tlspc_application" "shared" {
+  name                = "TF Managed Shared App"
+  owners              = [{ type = "TEAM", id = resource.tlspc_team.team.id }]
+  ca_template_aliases = { "${resource.tlspc_certificate_template.built_in.name}" = resource.tlspc_certificate_template.built_in.id }
+  manage_all_aliases  = false
+}
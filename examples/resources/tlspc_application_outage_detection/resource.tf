@@ -0,0 +1,7 @@
+resource "tlspc_application_outage_detection" "app" {
+  application_id = resource.tlspc_application.app.id
+  fqdns          = ["www.example.com", "api.example.com"]
+  ip_ranges      = ["10.0.0.0/24"]
+  ports          = ["443", "8443"]
+  internal_ports = ["443", "8000-8080"]
+}
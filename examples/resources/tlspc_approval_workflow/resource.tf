@@ -0,0 +1,10 @@
+resource "tlspc_approval_workflow" "production" {
+  name                     = "Production certificates"
+  application_ids          = [resource.tlspc_application.app.id]
+  certificate_template_ids = [resource.tlspc_certificate_template.built_in.id]
+  approvers = [
+    { type = "TEAM", id = resource.tlspc_team.team.id },
+    { type = "USER", id = data.tlspc_user.owner.id },
+  ]
+  required_approvals = 1
+}
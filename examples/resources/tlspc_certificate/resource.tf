@@ -0,0 +1,29 @@
+resource "tls_private_key" "web" {
+  algorithm   = "ECDSA"
+  ecdsa_curve = "P256"
+}
+
+resource "tls_cert_request" "web" {
+  private_key_pem = tls_private_key.web.private_key_pem
+  dns_names       = ["www.example.com"]
+
+  subject {
+    common_name = "www.example.com"
+  }
+}
+
+resource "tlspc_certificate" "web" {
+  application_id          = resource.tlspc_application.app.id
+  certificate_template_id = resource.tlspc_certificate_template.built_in.id
+  csr                     = tls_cert_request.web.cert_request_pem
+  validity_period         = "P90D"
+
+  # Custom fields required by the tenant's policies
+  custom_fields = {
+    "Cost Centre" = "1234"
+  }
+
+  tags = {
+    environment = "production"
+  }
+}
@@ -0,0 +1,4 @@
+resource "tlspc_certificate_application" "discovered" {
+  certificate_id = "4f1c2a9e-5c3b-4d2e-9a8f-1b2c3d4e5f60"
+  application_id = resource.tlspc_application.app.id
+}
@@ -4,3 +4,21 @@ resource "tlspc_certificate_template" "built_in" {
   ca_product_id = data.tlspc_ca_product.built_in.id
   key_reuse     = false
 }
+
+# The product option can also be looked up by name, without a data source
+resource "tlspc_certificate_template" "digicert" {
+  name           = "DigiCert Cert Template"
+  ca_type        = "DIGICERT"
+  ca_name        = "DigiCert"
+  product_option = "DV SSL Certificate"
+}
+
+# Only allow certificates for example.com and its subdomains
+resource "tlspc_certificate_template" "example_com" {
+  name               = "example.com Cert Template"
+  ca_type            = data.tlspc_ca_product.built_in.type
+  ca_product_id      = data.tlspc_ca_product.built_in.id
+  san_regexes        = provider::tlspc::san_regexes(["example.com", "*.example.com"])
+  subject_cn_regexes = provider::tlspc::san_regexes(["example.com", "*.example.com"])
+  subject_o_regexes  = ["^Example Ltd$"]
+}
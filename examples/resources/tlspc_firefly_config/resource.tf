@@ -3,4 +3,44 @@ resource "tlspc_firefly_config" "ff_config" {
   subca_provider   = resource.tlspc_firefly_subca.subca.id
   service_accounts = [resource.tlspc_service_account.sa.id]
   policies         = [resource.tlspc_firefly_policy.ff_policy.id]
+
+  client_authentication = {
+    type = "JWT_JWKS"
+    urls = ["https://kubernetes.default.svc.cluster.local/openid/v1/jwks"]
+  }
+
+  cloud_providers = {
+    aws = {
+      account_ids = ["123456789012"]
+      regions     = ["us-east-1", "eu-west-1"]
+    }
+  }
+}
+
+resource "tlspc_firefly_config" "hardened" {
+  name             = "Hardened Firefly Config"
+  subca_provider   = resource.tlspc_firefly_subca.subca.id
+  service_accounts = [resource.tlspc_service_account.sa.id]
+  policies         = [resource.tlspc_firefly_policy.ff_policy.id]
+  min_tls_version  = "TLS13"
+
+  advanced_settings = {
+    key_storage                    = "IN_MEMORY"
+    min_heartbeat_interval_seconds = 60
+    enable_issuance_audit_log      = true
+    require_fips_compliant_build   = true
+  }
+}
+
+# Clients authenticate with certificates issued by a trusted CA
+resource "tlspc_firefly_config" "mtls" {
+  name             = "mTLS Firefly Config"
+  subca_provider   = resource.tlspc_firefly_subca.subca.id
+  service_accounts = [resource.tlspc_service_account.sa.id]
+  policies         = [resource.tlspc_firefly_policy.ff_policy.id]
+
+  client_authentication = {
+    type          = "MTLS"
+    trust_anchors = [file("${path.module}/client-ca.pem")]
+  }
 }
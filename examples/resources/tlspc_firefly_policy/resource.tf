@@ -82,3 +82,10 @@ resource "tlspc_firefly_policy" "ff_policy" {
     }
   }
 }
+
+# A policy authored in the UI, captured verbatim from the policy_json of the
+# tlspc_firefly_policy data source
+resource "tlspc_firefly_policy" "from_ui" {
+  name        = "Firefly Policy From UI"
+  policy_json = file("${path.module}/firefly-policy.json")
+}
@@ -7,3 +7,17 @@ resource "tlspc_firefly_subca" "subca" {
   key_algorithm        = "RSA_2048"
   validity_period      = "P30D"
 }
+
+# A self-signed Sub CA, e.g. for air-gapped deployments, with its keys in an HSM
+resource "tlspc_firefly_subca" "airgapped" {
+  name            = "Air-gapped Firefly Sub CA"
+  ca_type         = "SELF_SIGNED"
+  common_name     = "firefly-airgapped.example.com"
+  key_algorithm   = "EC_P256"
+  validity_period = "P365D"
+  pkcs11 = {
+    allowed_client_libraries = ["/usr/safenet/lunaclient/lib/libCryptoki2_64.so"]
+    partition_label          = "firefly"
+    pin                      = var.hsm_pin
+  }
+}
@@ -0,0 +1,36 @@
+resource "tls_private_key" "agent" {
+  algorithm = "RSA"
+  rsa_bits  = 4096
+}
+
+resource "tlspc_kubernetes_agent" "prod" {
+  cluster_name        = "prod-eu-1"
+  cluster_description = "Production cluster in eu-west-1"
+  owner               = tlspc_team.team.id
+  public_key          = tls_private_key.agent.public_key_pem
+}
+
+resource "kubernetes_secret" "agent" {
+  metadata {
+    name      = tlspc_kubernetes_agent.prod.secret_name
+    namespace = "venafi"
+  }
+  data = {
+    "privatekey.pem" = tls_private_key.agent.private_key_pem
+  }
+}
+
+resource "helm_release" "agent" {
+  name       = "venafi-kubernetes-agent"
+  namespace  = "venafi"
+  repository = "oci://registry.venafi.cloud/charts"
+  chart      = "venafi-kubernetes-agent"
+  values     = [tlspc_kubernetes_agent.prod.helm_values]
+
+  set {
+    name  = "podAnnotations.checksum/config"
+    value = tlspc_kubernetes_agent.prod.config_hash
+  }
+
+  depends_on = [kubernetes_secret.agent]
+}
@@ -0,0 +1,16 @@
+data "tlspc_plugin" "f5" {
+  type = "MACHINE"
+  name = "F5 BIG-IP LTM"
+}
+
+resource "tlspc_machine" "lb" {
+  name          = "lb-1.example.com"
+  plugin_id     = data.tlspc_plugin.f5.id
+  vsatellite_id = tlspc_vsatellite.edge.id
+  owner         = tlspc_team.team.id
+  connection_details = jsonencode({
+    hostnameOrAddress = "lb-1.example.com"
+    username          = "admin"
+    password          = var.f5_password
+  })
+}
@@ -2,3 +2,9 @@ resource "tlspc_plugin" "digicert" {
   type     = "CA"
   manifest = file("${path.root}/plugins/digicert.json")
 }
+
+# Large manifests can be kept as files, only their hash is saved to state
+resource "tlspc_plugin" "machine" {
+  type          = "MACHINE"
+  manifest_file = "${path.module}/plugins/machine.json"
+}
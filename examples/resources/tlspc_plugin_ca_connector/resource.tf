@@ -0,0 +1,12 @@
+resource "tlspc_plugin_ca_connector" "digicert" {
+  name       = "DigiCert"
+  version    = "1.0.0"
+  work_types = ["ISSUANCE", "REVOCATION"]
+  image      = "org/digicert-ca-connector:v1.0.0"
+
+  domain_schema = jsonencode(jsondecode(file("${path.root}/plugins/digicert.json")).domainSchema)
+
+  extra_manifest = jsonencode({
+    description = "DigiCert CA connector"
+  })
+}
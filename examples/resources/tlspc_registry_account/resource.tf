@@ -3,9 +3,20 @@ resource "tlspc_registry_account" "oci" {
   owner               = resource.tlspc_team.team.id
   scopes              = ["oci-registry-cm"]
   credential_lifetime = 365
+
+  # Generate a new token for this account once the current one expires within 30 days
+  rotate_when_expiring_within = 30
 }
 
-output "dockerconfig" {
-  value     = jsonencode({ "auths" = { "private-registry.venafi.eu" = { "auth" = base64encode("${resource.tlspc_registry_account.oci.oci_account_name}:${resource.tlspc_registry_account.oci.oci_registry_token}") } } })
-  sensitive = true
+resource "kubernetes_secret" "venafi_registry" {
+  metadata {
+    name      = "venafi-image-pull-secret"
+    namespace = "venafi"
+  }
+
+  type = "kubernetes.io/dockerconfigjson"
+
+  data = {
+    ".dockerconfigjson" = resource.tlspc_registry_account.oci.dockerconfigjson
+  }
 }
@@ -11,6 +11,17 @@ resource "tlspc_service_account" "agent-credentials" {
   public_key          = trimspace(resource.tls_private_key.rsa-key.public_key_pem)
 }
 
+# With Terraform 1.11+, the public key can be supplied as a write-only argument
+# so that it is not persisted in the tlspc_service_account state.
+resource "tlspc_service_account" "agent-credentials-wo" {
+  name                  = "k8s-cluster-wo"
+  owner                 = resource.tlspc_team.team.id
+  scopes                = ["kubernetes-discovery"]
+  credential_lifetime   = 365
+  public_key_wo         = trimspace(resource.tls_private_key.rsa-key.public_key_pem)
+  public_key_wo_version = 1
+}
+
 resource "kubernetes_secret" "credentials" {
   metadata {
     name      = "agent-credentials"
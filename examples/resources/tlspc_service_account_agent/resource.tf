@@ -0,0 +1,30 @@
+resource "tls_private_key" "rsa-key" {
+  algorithm = "RSA"
+  rsa_bits  = 4096
+}
+
+resource "tlspc_service_account_agent" "agent-credentials" {
+  name                = "k8s-cluster"
+  owner               = resource.tlspc_team.team.id
+  scopes              = ["kubernetes-discovery"]
+  credential_lifetime = 365
+  public_key          = trimspace(resource.tls_private_key.rsa-key.public_key_pem)
+}
+
+# With Terraform 1.11+, the public key can be supplied as a write-only argument
+# so that it is not persisted in the state.
+resource "tlspc_service_account_agent" "agent-credentials-wo" {
+  name                  = "k8s-cluster-wo"
+  owner                 = resource.tlspc_team.team.id
+  scopes                = ["kubernetes-discovery"]
+  credential_lifetime   = 365
+  public_key_wo         = trimspace(resource.tls_private_key.rsa-key.public_key_pem)
+  public_key_wo_version = 1
+}
+
+# Service accounts managed with the deprecated tlspc_service_account can be
+# moved without being recreated.
+moved {
+  from = tlspc_service_account.agent-credentials
+  to   = tlspc_service_account_agent.agent-credentials
+}
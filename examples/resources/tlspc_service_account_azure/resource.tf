@@ -0,0 +1,16 @@
+# Allow workloads using a user assigned managed identity, e.g. through AKS
+# workload identity, to request certificates
+resource "azurerm_user_assigned_identity" "issuer" {
+  name                = "tlspc-issuer"
+  resource_group_name = "example"
+  location            = "westeurope"
+}
+
+resource "tlspc_service_account_azure" "issuer" {
+  name         = "aks-issuer"
+  owner        = resource.tlspc_team.team.id
+  scopes       = ["certificate-issuance"]
+  applications = [resource.tlspc_application.app.id]
+  tenant_id    = azurerm_user_assigned_identity.issuer.tenant_id
+  principal_id = azurerm_user_assigned_identity.issuer.principal_id
+}
@@ -0,0 +1,22 @@
+# Allow workflows on the main branch of example-org/example-repo to request
+# certificates
+resource "tlspc_service_account_github_actions" "deploy" {
+  name         = "example-repo-deploy"
+  owner        = resource.tlspc_team.team.id
+  scopes       = ["certificate-issuance"]
+  applications = [resource.tlspc_application.app.id]
+  organization = "example-org"
+  repository   = "example-repo"
+  branch       = "main"
+}
+
+# Or jobs which deploy to the production environment
+resource "tlspc_service_account_github_actions" "production" {
+  name         = "example-repo-production"
+  owner        = resource.tlspc_team.team.id
+  scopes       = ["certificate-issuance"]
+  applications = [resource.tlspc_application.app.id]
+  organization = "example-org"
+  repository   = "example-repo"
+  environment  = "production"
+}
@@ -0,0 +1,17 @@
+resource "tlspc_service_account_wif" "wif-issuer" {
+  name         = "test-issuer1"
+  owner        = resource.tlspc_team.team.id
+  scopes       = ["certificate-issuance"]
+  applications = [resource.tlspc_application.app.id]
+  jwks_uri     = "https://kubernetes/.well-known/jwks.json"
+  issuer_url   = "https://kubernetes.default.svc.cluster.local"
+  subject      = "system:serviceaccount:venafi:application-team-1"
+  audience     = "api.venafi.eu"
+}
+
+# Service accounts managed with the deprecated tlspc_service_account can be
+# moved without being recreated.
+moved {
+  from = tlspc_service_account.wif-issuer
+  to   = tlspc_service_account_wif.wif-issuer
+}
@@ -0,0 +1,4 @@
+resource "tlspc_tag" "environment" {
+  name   = "environment"
+  values = ["production", "staging", "development"]
+}
@@ -0,0 +1,9 @@
+resource "tlspc_tag_assignment" "app" {
+  entity_type = "APPLICATION"
+  entity_id   = resource.tlspc_application.app.id
+
+  tags = {
+    (tlspc_tag.environment.name) = "production"
+    "cost-center"                = "1234"
+  }
+}
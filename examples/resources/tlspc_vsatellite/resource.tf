@@ -0,0 +1,15 @@
+resource "tlspc_vsatellite" "edge" {
+  name = "datacenter-1"
+}
+
+resource "helm_release" "vsatellite" {
+  name       = "vsatellite"
+  namespace  = "venafi"
+  repository = "oci://registry.venafi.cloud/charts"
+  chart      = "vsatellite"
+
+  set_sensitive {
+    name  = "pairingCode"
+    value = tlspc_vsatellite.edge.pairing_code
+  }
+}
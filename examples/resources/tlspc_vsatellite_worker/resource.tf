@@ -0,0 +1,14 @@
+resource "tlspc_vsatellite" "edge" {
+  name = "datacenter-1"
+}
+
+resource "tlspc_vsatellite_worker" "workers" {
+  count         = 3
+  name          = "datacenter-1-worker-${count.index}"
+  vsatellite_id = tlspc_vsatellite.edge.id
+}
+
+output "worker_pairing_codes" {
+  value     = tlspc_vsatellite_worker.workers[*].pairing_code
+  sensitive = true
+}
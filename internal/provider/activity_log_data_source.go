@@ -0,0 +1,218 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &activityLogDataSource{}
+	_ datasource.DataSourceWithConfigure = &activityLogDataSource{}
+)
+
+// NewActivityLogDataSource is a helper function to simplify the provider implementation.
+func NewActivityLogDataSource() datasource.DataSource {
+	return &activityLogDataSource{}
+}
+
+// activityLogDataSource is the data source implementation.
+type activityLogDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *activityLogDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *activityLogDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_activity_log"
+}
+
+// defaultActivityLogLimit is the number of entries returned if no limit is set.
+const defaultActivityLogLimit = 100
+
+// Schema defines the schema for the data source.
+func (d *activityLogDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Search the activity log, newest entries first.",
+		Attributes: map[string]schema.Attribute{
+			"actor": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return activity by the user with this ID",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return activity of this type, e.g. `Authentication`",
+			},
+			"from": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return activity at or after this time, in RFC3339 format",
+			},
+			"to": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return activity at or before this time, in RFC3339 format",
+			},
+			"limit": schema.Int32Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The maximum number of entries to return, defaults to %d", defaultActivityLogLimit),
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"entries": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching activity log entries",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the entry",
+						},
+						"date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the activity happened",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of activity",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the activity",
+						},
+						"criticality": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The criticality of the activity",
+						},
+						"message": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "A description of the activity",
+						},
+						"actor": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the user responsible for the activity",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type activityLogDataSourceModel struct {
+	Actor   types.String       `tfsdk:"actor"`
+	Type    types.String       `tfsdk:"type"`
+	From    types.String       `tfsdk:"from"`
+	To      types.String       `tfsdk:"to"`
+	Limit   types.Int32        `tfsdk:"limit"`
+	Entries []activityLogEntry `tfsdk:"entries"`
+}
+
+type activityLogEntry struct {
+	ID          types.String `tfsdk:"id"`
+	Date        types.String `tfsdk:"date"`
+	Type        types.String `tfsdk:"type"`
+	Name        types.String `tfsdk:"name"`
+	Criticality types.Int64  `tfsdk:"criticality"`
+	Message     types.String `tfsdk:"message"`
+	Actor       types.String `tfsdk:"actor"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *activityLogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model activityLogDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for attr, v := range map[string]types.String{"from": model.From, "to": model.To} {
+		if v.IsNull() {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, v.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr),
+				"Invalid Time",
+				fmt.Sprintf("Expected an RFC3339 timestamp, e.g. 2025-01-02T15:04:05Z: %s", err.Error()),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultActivityLogLimit
+	if !model.Limit.IsNull() {
+		limit = int(model.Limit.ValueInt32())
+	}
+
+	entries, err := d.client.SearchActivityLog(tlspc.ActivityLogFilter{
+		Actor: model.Actor.ValueString(),
+		Type:  model.Type.ValueString(),
+		From:  model.From.ValueString(),
+		To:    model.To.ValueString(),
+		Limit: limit,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Activity Log",
+			fmt.Sprintf("Error retrieving Activity Log: %s", err.Error()),
+		)
+		return
+	}
+
+	model.Entries = []activityLogEntry{}
+	for _, e := range entries {
+		model.Entries = append(model.Entries, activityLogEntry{
+			ID:          types.StringValue(e.ID),
+			Date:        types.StringValue(e.Date),
+			Type:        types.StringValue(e.Type),
+			Name:        types.StringValue(e.Name),
+			Criticality: types.Int64Value(e.Criticality),
+			Message:     types.StringValue(e.Message),
+			Actor:       types.StringValue(e.Actor),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 var (
@@ -22,7 +21,7 @@ var (
 )
 
 type applicationDataSource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewApplicationDataSource() datasource.DataSource {
@@ -44,12 +43,26 @@ func (r *applicationDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 				Required:            true,
 				MarkdownDescription: "The name of the application",
 			},
-			"owners": schema.SetAttribute{
+			"owners": schema.SetNestedAttribute{
 				Computed: true,
-				ElementType: basetypes.MapType{
-					ElemType: types.StringType,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of owner, one of `USER` or `TEAM`",
+						},
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the user or team",
+						},
+						"owner": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the user or team",
+							DeprecationMessage:  "Use id instead; owner will be removed in a future release.",
+						},
+					},
 				},
-				MarkdownDescription: "A map of owner ids and their type, one of 'USER' or 'TEAM'",
+				MarkdownDescription: "The owners of the application",
 			},
 			"ca_template_aliases": schema.MapAttribute{
 				Computed:            true,
@@ -65,11 +78,11 @@ func (r *applicationDataSource) Configure(_ context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -77,10 +90,10 @@ func (r *applicationDataSource) Configure(_ context.Context, req datasource.Conf
 }
 
 type applicationDataSourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Owners            []types.Map  `tfsdk:"owners"`
-	CATemplateAliases types.Map    `tfsdk:"ca_template_aliases"`
+	ID                types.String            `tfsdk:"id"`
+	Name              types.String            `tfsdk:"name"`
+	Owners            []applicationOwnerModel `tfsdk:"owners"`
+	CATemplateAliases types.Map               `tfsdk:"ca_template_aliases"`
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -112,18 +125,13 @@ func (d *applicationDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	owners := []types.Map{}
+	owners := []applicationOwnerModel{}
 	for _, v := range app.Owners {
-		owner := map[string]attr.Value{
-			"type":  types.StringValue(v.Type),
-			"owner": types.StringValue(v.ID),
-		}
-		ownermap, diags := types.MapValue(types.StringType, owner)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		owners = append(owners, ownermap)
+		owners = append(owners, applicationOwnerModel{
+			Type:  types.StringValue(v.Type),
+			ID:    types.StringValue(v.ID),
+			Owner: types.StringValue(v.ID),
+		})
 	}
 
 	model.ID = types.StringValue(app.ID)
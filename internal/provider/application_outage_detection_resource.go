@@ -0,0 +1,256 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &applicationOutageDetectionResource{}
+	_ resource.ResourceWithConfigure   = &applicationOutageDetectionResource{}
+	_ resource.ResourceWithImportState = &applicationOutageDetectionResource{}
+	_ resource.ResourceWithIdentity    = &applicationOutageDetectionResource{}
+)
+
+// portRegex matches a port or a range of ports, e.g. 443 or 8000-8080.
+var portRegex = regexp.MustCompile(`^[0-9]{1,5}(-[0-9]{1,5})?$`)
+
+type applicationOutageDetectionResource struct {
+	client tlspc.API
+}
+
+func NewApplicationOutageDetectionResource() resource.Resource {
+	return &applicationOutageDetectionResource{}
+}
+
+func (r *applicationOutageDetectionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_outage_detection"
+}
+
+func (r *applicationOutageDetectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	portsValidators := []validator.Set{
+		setvalidator.ValueStringsAre(stringvalidator.RegexMatches(portRegex, "must be a port or range of ports, e.g. 443 or 8000-8080")),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage the endpoints scanned for an application's certificates by outage detection. Destroying this resource clears them, leaving the rest of the application untouched. Existing settings can be imported using the application ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource, which is the ID of the application",
+			},
+			"application_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the application",
+			},
+			"fqdns": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The fully qualified domain names of the application's endpoints",
+			},
+			"ip_ranges": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IP ranges of the application's endpoints in CIDR notation, e.g. `10.0.0.0/24`",
+			},
+			"ports": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The ports to scan on the `fqdns` and `ip_ranges`, e.g. `443` or `8000-8080`",
+				Validators:          portsValidators,
+			},
+			"internal_ports": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The ports to scan on internal networks by VSatellites",
+				Validators:          portsValidators,
+			},
+		},
+	}
+}
+
+func (r *applicationOutageDetectionResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
+func (r *applicationOutageDetectionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type applicationOutageDetectionResourceModel struct {
+	ID            types.String   `tfsdk:"id"`
+	ApplicationID types.String   `tfsdk:"application_id"`
+	FQDNs         []types.String `tfsdk:"fqdns"`
+	IPRanges      []types.String `tfsdk:"ip_ranges"`
+	Ports         []types.String `tfsdk:"ports"`
+	InternalPorts []types.String `tfsdk:"internal_ports"`
+}
+
+// apply sets the outage detection settings of app to those of the model.
+func (m applicationOutageDetectionResourceModel) apply(app *tlspc.Application) {
+	app.FQDNs = stringsFromValues(m.FQDNs)
+	app.IPRanges = stringsFromValues(m.IPRanges)
+	app.Ports = stringsFromValues(m.Ports)
+	app.InternalPorts = stringsFromValues(m.InternalPorts)
+}
+
+// outageDetectionValues returns the values of one of the settings, leaving it
+// unset if it's empty and unset in the model.
+func outageDetectionValues(values []string, prior []types.String) []types.String {
+	if len(values) == 0 && prior == nil {
+		return nil
+	}
+	return valuesFromStrings(values)
+}
+
+func (r *applicationOutageDetectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan applicationOutageDetectionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ModifyApplication(plan.ApplicationID.ValueString(), plan.apply)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Application Outage Detection",
+			"Could not update application, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = plan.ApplicationID
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *applicationOutageDetectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state applicationOutageDetectionResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := r.client.GetApplication(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Application Outage Detection",
+			"Could not read application ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ApplicationID = types.StringValue(app.ID)
+	state.FQDNs = outageDetectionValues(app.FQDNs, state.FQDNs)
+	state.IPRanges = outageDetectionValues(app.IPRanges, state.IPRanges)
+	state.Ports = outageDetectionValues(app.Ports, state.Ports)
+	state.InternalPorts = outageDetectionValues(app.InternalPorts, state.InternalPorts)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+}
+
+func (r *applicationOutageDetectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state applicationOutageDetectionResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ModifyApplication(state.ID.ValueString(), plan.apply)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Application Outage Detection",
+			"Could not update application, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = state.ID
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *applicationOutageDetectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state applicationOutageDetectionResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.ModifyApplication(state.ID.ValueString(), applicationOutageDetectionResourceModel{}.apply)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Application Outage Detection",
+			"Could not clear outage detection settings of Application ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *applicationOutageDetectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
@@ -5,29 +5,41 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 var (
-	_ resource.Resource                = &applicationResource{}
-	_ resource.ResourceWithConfigure   = &applicationResource{}
-	_ resource.ResourceWithImportState = &applicationResource{}
+	_ resource.Resource                 = &applicationResource{}
+	_ resource.ResourceWithConfigure    = &applicationResource{}
+	_ resource.ResourceWithImportState  = &applicationResource{}
+	_ resource.ResourceWithIdentity     = &applicationResource{}
+	_ resource.ResourceWithUpgradeState = &applicationResource{}
+	_ resource.ResourceWithModifyPlan   = &applicationResource{}
 )
 
+// maxTemplateAliasLength is the longest CA template alias the API accepts.
+const maxTemplateAliasLength = 256
+
 type applicationResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewApplicationResource() resource.Resource {
@@ -40,6 +52,7 @@ func (r *applicationResource) Metadata(_ context.Context, req resource.MetadataR
 
 func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -52,33 +65,89 @@ func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Required:            true,
 				MarkdownDescription: "The name of the application",
 			},
-			"owners": schema.SetAttribute{
+			"owners": schema.SetNestedAttribute{
 				Required: true,
-				ElementType: basetypes.MapType{
-					ElemType: types.StringType,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The type of owner, one of `USER` or `TEAM`",
+							Validators: []validator.String{
+								stringvalidator.OneOf("USER", "TEAM"),
+							},
+						},
+						"id": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The ID of the user or team. Exactly one of `id`, `email` or `name` must be given",
+							Validators: []validator.String{
+								validators.Uuid(),
+								stringvalidator.ExactlyOneOf(
+									path.MatchRelative().AtParent().AtName("owner"),
+									path.MatchRelative().AtParent().AtName("email"),
+									path.MatchRelative().AtParent().AtName("name"),
+								),
+							},
+						},
+						"email": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The email address of the user, for owners of type `USER`, which is looked up when planning and applying",
+							Validators: []validator.String{
+								validators.Email(),
+							},
+						},
+						"name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The name of the team, for owners of type `TEAM`, which is looked up when planning and applying",
+						},
+						"owner": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The ID of the user or team. Deprecated: use `id` instead; this will be removed in a future release.",
+							DeprecationMessage:  `The owner attribute of owners is deprecated and will be removed in a future release; rename it to id, e.g. rewrite { type = "TEAM", owner = tlspc_team.example.id } as { type = "TEAM", id = tlspc_team.example.id }.`,
+							Validators: []validator.String{
+								validators.Uuid(),
+							},
+						},
+					},
 				},
-				MarkdownDescription: "A map of owner ids, see example for format",
+				MarkdownDescription: "The owners of the application, see example for format",
 			},
 			"ca_template_aliases": schema.MapAttribute{
 				Required:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "CA Template alias-to-id mapping for templates available to this application, see example for format",
+				MarkdownDescription: "CA Template alias-to-id mapping for templates available to this application, see example for format. When `manage_all_aliases` is false, only these aliases are managed",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.LengthBetween(1, maxTemplateAliasLength),
+						stringvalidator.RegexMatches(trimmedRegex, "must not start or end with whitespace"),
+					),
+					mapvalidator.ValueStringsAre(validators.Uuid()),
+				},
+			},
+			"manage_all_aliases": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether `ca_template_aliases` is the full set of aliases for the application. When false, aliases attached outside Terraform are left in place and not reported as drift. Defaults to true",
 			},
 		},
 	}
 }
 
+func (r *applicationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
 func (r *applicationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -88,45 +157,204 @@ func (r *applicationResource) Configure(_ context.Context, req resource.Configur
 }
 
 type applicationResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Owners            []types.Map  `tfsdk:"owners"`
-	CATemplateAliases types.Map    `tfsdk:"ca_template_aliases"`
+	ID                types.String            `tfsdk:"id"`
+	Name              types.String            `tfsdk:"name"`
+	Owners            []applicationOwnerModel `tfsdk:"owners"`
+	CATemplateAliases types.Map               `tfsdk:"ca_template_aliases"`
+	ManageAllAliases  types.Bool              `tfsdk:"manage_all_aliases"`
 }
 
-func (r *applicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan applicationResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+// managesAllAliases reports whether the application's alias map is owned
+// entirely by Terraform. It is unset in state after an import.
+func (m applicationResourceModel) managesAllAliases() bool {
+	return m.ManageAllAliases.IsNull() || m.ManageAllAliases.ValueBool()
+}
+
+// applicationOwnerModel is an entry of owners. The ID was originally given as
+// owner, which is still accepted until it is removed in a future release.
+// Users may be given by email and teams by name instead.
+type applicationOwnerModel struct {
+	Type  types.String `tfsdk:"type"`
+	ID    types.String `tfsdk:"id"`
+	Owner types.String `tfsdk:"owner"`
+	Email types.String `tfsdk:"email"`
+	Name  types.String `tfsdk:"name"`
+}
+
+// ownerID returns the ID of the owner, whichever attribute it was given in.
+func (o applicationOwnerModel) ownerID() string {
+	if !o.ID.IsNull() {
+		return o.ID.ValueString()
 	}
+	return o.Owner.ValueString()
+}
+
+// resolve returns the ID of the owner, looking up the user or team if it was
+// given by email or name.
+func (o applicationOwnerModel) resolve(client tlspc.API) (string, error) {
+	kind := o.Type.ValueString()
+	switch {
+	case !o.Email.IsNull():
+		if kind != "USER" {
+			return "", fmt.Errorf("email can only be given for owners of type USER, not %s", kind)
+		}
+		user, err := client.GetUser(o.Email.ValueString(), "")
+		if err != nil {
+			return "", err
+		}
+		return user.ID, nil
+	case !o.Name.IsNull():
+		if kind != "TEAM" {
+			return "", fmt.Errorf("name can only be given for owners of type TEAM, not %s", kind)
+		}
+		team, err := client.GetTeamByName(o.Name.ValueString())
+		if err != nil {
+			return "", err
+		}
+		return team.ID, nil
+	}
+	return o.ownerID(), nil
+}
 
+// resolveOwners returns the owners to send to the API.
+func resolveOwners(client tlspc.API, models []applicationOwnerModel) ([]tlspc.OwnerAndType, error) {
 	owners := []tlspc.OwnerAndType{}
-	for _, v := range plan.Owners {
-		m := v.Elements()
-		// TODO: Work out how you're supposed to get an unquoted string out
-		kind := strings.Trim(m["type"].String(), `"`)
-		ownerId := strings.Trim(m["owner"].String(), `"`)
+	for _, v := range models {
+		kind := v.Type.ValueString()
 		if kind != "USER" && kind != "TEAM" {
-			resp.Diagnostics.AddError(
-				"Error creating application",
-				"Could not create application, unsupported owner type: "+kind,
-			)
-			return
+			return nil, fmt.Errorf("unsupported owner type: %s", kind)
+		}
+		ownerId, err := v.resolve(client)
+		if err != nil {
+			return nil, err
 		}
 		if ownerId == "" {
-			resp.Diagnostics.AddError(
-				"Error creating application",
-				"Could not create application, undefined owner",
-			)
-			return
+			return nil, errors.New("undefined owner")
 		}
-		owner := tlspc.OwnerAndType{
+		owners = append(owners, tlspc.OwnerAndType{
 			ID:   ownerId,
 			Type: kind,
+		})
+	}
+	return owners, nil
+}
+
+// applicationOwners returns the owners of an application, in the same shape
+// as prior, so that owners given by email, name or the deprecated owner
+// attribute don't cause a diff. Prior owners which can no longer be looked up
+// are returned by ID.
+func applicationOwners(client tlspc.API, owners []tlspc.OwnerAndType, prior []applicationOwnerModel) []applicationOwnerModel {
+	given := map[string]applicationOwnerModel{}
+	for _, o := range prior {
+		if !o.ID.IsNull() {
+			continue
+		}
+		if id, err := o.resolve(client); err == nil {
+			given[o.Type.ValueString()+"/"+id] = o
+		}
+	}
+
+	models := []applicationOwnerModel{}
+	for _, v := range owners {
+		owner := applicationOwnerModel{
+			Type:  types.StringValue(v.Type),
+			ID:    types.StringValue(v.ID),
+			Owner: types.StringNull(),
+			Email: types.StringNull(),
+			Name:  types.StringNull(),
+		}
+		if g, ok := given[v.Type+"/"+v.ID]; ok {
+			owner.ID = types.StringNull()
+			owner.Owner = g.Owner
+			owner.Email = g.Email
+			owner.Name = g.Name
+		}
+		models = append(models, owner)
+	}
+	return models
+}
+
+// ModifyPlan checks that the certificate templates referenced by
+// ca_template_aliases, and the owners given by email or name, exist, so that
+// a bad reference fails the plan rather than the apply. Templates which are
+// unknown, or unchanged since the last apply, aren't checked.
+func (r *applicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var owners types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("owners"), &owners)...)
+	for _, v := range owners.Elements() {
+		obj, ok := v.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		var o applicationOwnerModel
+		resp.Diagnostics.Append(obj.As(ctx, &o, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if o.Type.IsUnknown() || o.Email.IsUnknown() || o.Name.IsUnknown() || (o.Email.IsNull() && o.Name.IsNull()) {
+			continue
+		}
+		if _, err := o.resolve(r.client); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("owners"),
+				"Owner not found",
+				fmt.Sprintf("Could not find %s owner %s: %s", o.Type.ValueString(), o.Email.ValueString()+o.Name.ValueString(), err.Error()),
+			)
+		}
+	}
+
+	var aliases, prior types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ca_template_aliases"), &aliases)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("ca_template_aliases"), &prior)...)
+	}
+	if resp.Diagnostics.HasError() || aliases.IsNull() || aliases.IsUnknown() {
+		return
+	}
+
+	priorIDs := map[string]bool{}
+	for _, v := range prior.Elements() {
+		if id, ok := v.(types.String); ok {
+			priorIDs[id.ValueString()] = true
+		}
+	}
+
+	for alias, v := range aliases.Elements() {
+		id, ok := v.(types.String)
+		if !ok || id.IsUnknown() || id.IsNull() || priorIDs[id.ValueString()] {
+			continue
+		}
+		if _, err := r.client.GetCertificateTemplate(id.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_template_aliases").AtMapKey(alias),
+				"Certificate Template not found",
+				fmt.Sprintf("Could not find certificate template %s for alias %s: %s", id.ValueString(), alias, err.Error()),
+			)
 		}
-		owners = append(owners, owner)
+	}
+}
+
+func (r *applicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan applicationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owners, err := resolveOwners(r.client, plan.Owners)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating application",
+			"Could not create application, "+err.Error(),
+		)
+		return
 	}
 
 	aliases := map[string]string{}
@@ -150,9 +378,12 @@ func (r *applicationResource) Create(ctx context.Context, req resource.CreateReq
 	plan.ID = types.StringValue(created.ID)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state applicationResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -173,23 +404,17 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 	state.ID = types.StringValue(app.ID)
 	state.Name = types.StringValue(app.Name)
 
-	owners := []types.Map{}
-	for _, v := range app.Owners {
-		owner := map[string]attr.Value{
-			"type":  types.StringValue(v.Type),
-			"owner": types.StringValue(v.ID),
-		}
-		ownermap, diags := types.MapValue(types.StringType, owner)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		owners = append(owners, ownermap)
-	}
-	state.Owners = owners
+	state.Owners = applicationOwners(r.client, app.Owners, state.Owners)
 
+	// Only the aliases already in state are tracked when the rest of the map
+	// is managed elsewhere.
 	aliases := map[string]attr.Value{}
 	for k, v := range app.CertificateTemplates {
+		if !state.managesAllAliases() {
+			if _, ok := state.CATemplateAliases.Elements()[k]; !ok {
+				continue
+			}
+		}
 		aliases[k] = types.StringValue(v)
 	}
 
@@ -200,12 +425,16 @@ func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	state.CATemplateAliases = aliasmap
+	state.ManageAllAliases = types.BoolValue(state.managesAllAliases())
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 }
 
 func (r *applicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan, state applicationResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -218,31 +447,13 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	owners := []tlspc.OwnerAndType{}
-	for _, v := range plan.Owners {
-		m := v.Elements()
-		// TODO: Work out how you're supposed to get an unquoted string out
-		kind := strings.Trim(m["type"].String(), `"`)
-		ownerId := strings.Trim(m["owner"].String(), `"`)
-		if kind != "USER" && kind != "TEAM" {
-			resp.Diagnostics.AddError(
-				"Error creating application",
-				"Could not create application, unsupported owner type: "+kind,
-			)
-			return
-		}
-		if ownerId == "" {
-			resp.Diagnostics.AddError(
-				"Error creating application",
-				"Could not create application, undefined owner",
-			)
-			return
-		}
-		owner := tlspc.OwnerAndType{
-			ID:   ownerId,
-			Type: kind,
-		}
-		owners = append(owners, owner)
+	owners, err := resolveOwners(r.client, plan.Owners)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating application",
+			"Could not update application, "+err.Error(),
+		)
+		return
 	}
 
 	aliases := map[string]string{}
@@ -250,14 +461,28 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 		aliases[k] = strings.Trim(v.String(), `"`)
 	}
 
-	application := tlspc.Application{
-		ID:                   state.ID.ValueString(),
-		Name:                 plan.Name.ValueString(),
-		Owners:               owners,
-		CertificateTemplates: aliases,
-	}
-
-	updated, err := r.client.UpdateApplication(application)
+	// The outage detection settings are managed by
+	// tlspc_application_outage_detection, so are left as they are.
+	updated, err := r.client.ModifyApplication(state.ID.ValueString(), func(app *tlspc.Application) {
+		app.Name = plan.Name.ValueString()
+		app.Owners = owners
+		if plan.managesAllAliases() {
+			app.CertificateTemplates = aliases
+			return
+		}
+		// Keep aliases attached outside Terraform, dropping only those
+		// removed from the configuration.
+		merged := map[string]string{}
+		for k, v := range app.CertificateTemplates {
+			if _, managed := state.CATemplateAliases.Elements()[k]; !managed {
+				merged[k] = v
+			}
+		}
+		for k, v := range aliases {
+			merged[k] = v
+		}
+		app.CertificateTemplates = merged
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating application",
@@ -268,9 +493,12 @@ func (r *applicationResource) Update(ctx context.Context, req resource.UpdateReq
 	plan.ID = types.StringValue(updated.ID)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state applicationResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -290,6 +518,6 @@ func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -0,0 +1,82 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+type applicationResourceModelV0 struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Owners            []types.Map  `tfsdk:"owners"`
+	CATemplateAliases types.Map    `tfsdk:"ca_template_aliases"`
+}
+
+func (r *applicationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored owners as a set of maps with type and owner keys
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"name": schema.StringAttribute{
+						Required: true,
+					},
+					"owners": schema.SetAttribute{
+						Required: true,
+						ElementType: basetypes.MapType{
+							ElemType: types.StringType,
+						},
+					},
+					"ca_template_aliases": schema.MapAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior applicationResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// Keep the ID in owner, matching existing configuration; it
+				// moves to id once the configuration is rewritten.
+				owners := []applicationOwnerModel{}
+				for _, v := range prior.Owners {
+					m := map[string]string{}
+					resp.Diagnostics.Append(v.ElementsAs(ctx, &m, false)...)
+					if resp.Diagnostics.HasError() {
+						return
+					}
+					owners = append(owners, applicationOwnerModel{
+						Type:  types.StringValue(m["type"]),
+						ID:    types.StringNull(),
+						Owner: types.StringValue(m["owner"]),
+						Email: types.StringNull(),
+						Name:  types.StringNull(),
+					})
+				}
+
+				upgraded := applicationResourceModel{
+					ID:                prior.ID,
+					Name:              prior.Name,
+					Owners:            owners,
+					CATemplateAliases: prior.CATemplateAliases,
+					ManageAllAliases:  types.BoolValue(true),
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &approvalWorkflowResource{}
+	_ resource.ResourceWithConfigure      = &approvalWorkflowResource{}
+	_ resource.ResourceWithImportState    = &approvalWorkflowResource{}
+	_ resource.ResourceWithValidateConfig = &approvalWorkflowResource{}
+)
+
+type approvalWorkflowResource struct {
+	client tlspc.API
+}
+
+func NewApprovalWorkflowResource() resource.Resource {
+	return &approvalWorkflowResource{}
+}
+
+func (r *approvalWorkflowResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_approval_workflow"
+}
+
+func (r *approvalWorkflowResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Require certificate requests for applications or certificate issuing templates to be approved before they are issued.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the approval workflow",
+			},
+			"application_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the applications whose certificate requests require approval",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.Uuid()),
+					setvalidator.AtLeastOneOf(path.MatchRoot("certificate_template_ids")),
+				},
+			},
+			"certificate_template_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the certificate issuing templates whose certificate requests require approval",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.Uuid()),
+				},
+			},
+			"approvers": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The users and teams who can approve requests",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The type of approver, one of `USER` or `TEAM`",
+							Validators: []validator.String{
+								stringvalidator.OneOf("USER", "TEAM"),
+							},
+						},
+						"id": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The ID of the user or team",
+							Validators: []validator.String{
+								validators.Uuid(),
+							},
+						},
+					},
+				},
+			},
+			"required_approvals": schema.Int32Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int32default.StaticInt32(1),
+				MarkdownDescription: "The number of approvals a request needs, defaults to 1",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *approvalWorkflowResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type approvalWorkflowResourceModel struct {
+	ID                     types.String    `tfsdk:"id"`
+	Name                   types.String    `tfsdk:"name"`
+	ApplicationIDs         []types.String  `tfsdk:"application_ids"`
+	CertificateTemplateIDs []types.String  `tfsdk:"certificate_template_ids"`
+	Approvers              []approverModel `tfsdk:"approvers"`
+	RequiredApprovals      types.Int32     `tfsdk:"required_approvals"`
+}
+
+type approverModel struct {
+	Type types.String `tfsdk:"type"`
+	ID   types.String `tfsdk:"id"`
+}
+
+func (m approvalWorkflowResourceModel) toWorkflow() tlspc.ApprovalWorkflow {
+	approvers := []tlspc.OwnerAndType{}
+	for _, a := range m.Approvers {
+		approvers = append(approvers, tlspc.OwnerAndType{
+			ID:   a.ID.ValueString(),
+			Type: a.Type.ValueString(),
+		})
+	}
+
+	return tlspc.ApprovalWorkflow{
+		Name:                   m.Name.ValueString(),
+		ApplicationIDs:         stringsFromValues(m.ApplicationIDs),
+		CertificateTemplateIDs: stringsFromValues(m.CertificateTemplateIDs),
+		Approvers:              approvers,
+		RequiredApprovals:      m.RequiredApprovals.ValueInt32(),
+	}
+}
+
+// optionalValuesFromStrings is valuesFromStrings, except that no strings
+// gives nil so that an omitted optional set stays null.
+func optionalValuesFromStrings(in []string) []types.String {
+	if len(in) == 0 {
+		return nil
+	}
+	return valuesFromStrings(in)
+}
+
+// ValidateConfig checks that the number of approvals required can be met by
+// the approvers.
+func (r *approvalWorkflowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var approvers types.Set
+	var required types.Int32
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("approvers"), &approvers)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("required_approvals"), &required)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if approvers.IsUnknown() || approvers.IsNull() || required.IsUnknown() || required.IsNull() {
+		return
+	}
+
+	// Unknown approvers may turn out to be duplicates, so can't be counted
+	for _, v := range approvers.Elements() {
+		if v.IsUnknown() {
+			return
+		}
+	}
+
+	if n := len(approvers.Elements()); int(required.ValueInt32()) > n {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("required_approvals"),
+			"Invalid Approval Workflow",
+			fmt.Sprintf("required_approvals is %d, but there are only %d approvers", required.ValueInt32(), n),
+		)
+	}
+}
+
+func (r *approvalWorkflowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan approvalWorkflowResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateApprovalWorkflow(plan.toWorkflow())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Approval Workflow",
+			"Could not create Approval Workflow, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *approvalWorkflowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state approvalWorkflowResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	w, err := r.client.GetApprovalWorkflow(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Approval Workflow",
+			"Could not read Approval Workflow ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(w.ID)
+	state.Name = types.StringValue(w.Name)
+	state.ApplicationIDs = optionalValuesFromStrings(w.ApplicationIDs)
+	state.CertificateTemplateIDs = optionalValuesFromStrings(w.CertificateTemplateIDs)
+	state.RequiredApprovals = types.Int32Value(w.RequiredApprovals)
+
+	approvers := []approverModel{}
+	for _, a := range w.Approvers {
+		approvers = append(approvers, approverModel{
+			Type: types.StringValue(a.Type),
+			ID:   types.StringValue(a.ID),
+		})
+	}
+	state.Approvers = approvers
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *approvalWorkflowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state approvalWorkflowResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	w := plan.toWorkflow()
+	w.ID = state.ID.ValueString()
+	_, err := r.client.UpdateApprovalWorkflow(w)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Approval Workflow",
+			"Could not update Approval Workflow, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *approvalWorkflowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state approvalWorkflowResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteApprovalWorkflow(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Approval Workflow",
+			"Could not delete Approval Workflow ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *approvalWorkflowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
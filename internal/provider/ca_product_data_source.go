@@ -27,7 +27,7 @@ func NewCAProductDataSource() datasource.DataSource {
 
 // caProductDataSource is the data source implementation.
 type caProductDataSource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 // Configure adds the provider configured client to the data source.
@@ -38,11 +38,11 @@ func (d *caProductDataSource) Configure(_ context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -0,0 +1,199 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &certificateApplicationResource{}
+	_ resource.ResourceWithConfigure   = &certificateApplicationResource{}
+	_ resource.ResourceWithImportState = &certificateApplicationResource{}
+)
+
+type certificateApplicationResource struct {
+	client tlspc.API
+}
+
+func NewCertificateApplicationResource() resource.Resource {
+	return &certificateApplicationResource{}
+}
+
+func (r *certificateApplicationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_application"
+}
+
+func (r *certificateApplicationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assign an existing certificate, such as a discovered one, to an application. Other applications the certificate is assigned to are left untouched. Existing assignments can be imported using an ID of the form `certificate_id/application_id`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"certificate_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the certificate",
+			},
+			"application_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the application",
+			},
+		},
+	}
+}
+
+func (r *certificateApplicationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type certificateApplicationResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	CertificateID types.String `tfsdk:"certificate_id"`
+	ApplicationID types.String `tfsdk:"application_id"`
+}
+
+func (r *certificateApplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan certificateApplicationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := plan.ApplicationID.ValueString()
+	err := r.client.UpdateCertificateApplications(plan.CertificateID.ValueString(), func(apps []string) []string {
+		if slices.Contains(apps, appID) {
+			return apps
+		}
+		return append(apps, appID)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Certificate Application",
+			"Could not create Certificate Application, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(plan.CertificateID.ValueString() + "/" + appID)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *certificateApplicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state certificateApplicationResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cert, err := r.client.GetCertificate(state.CertificateID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Certificate Application",
+			"Could not read Certificate Application ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The assignment was removed outside of Terraform
+	if !slices.Contains(cert.ApplicationIDs, state.ApplicationID.ValueString()) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *certificateApplicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Both IDs require replacement, so there is nothing to update.
+	var plan certificateApplicationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *certificateApplicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state certificateApplicationResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID := state.ApplicationID.ValueString()
+	err := r.client.UpdateCertificateApplications(state.CertificateID.ValueString(), func(apps []string) []string {
+		return slices.DeleteFunc(apps, func(id string) bool { return id == appID })
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Certificate Application",
+			"Could not delete Certificate Application ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *certificateApplicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID is certificate_id/application_id
+	importStateComposite(ctx, req, resp, "certificate_id", "application_id")
+}
@@ -0,0 +1,203 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &certificateDataSource{}
+	_ datasource.DataSourceWithConfigure = &certificateDataSource{}
+)
+
+// NewCertificateDataSource is a helper function to simplify the provider implementation.
+func NewCertificateDataSource() datasource.DataSource {
+	return &certificateDataSource{}
+}
+
+// certificateDataSource is the data source implementation.
+type certificateDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *certificateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *certificateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate"
+}
+
+// Schema defines the schema for the data source.
+func (d *certificateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up a certificate by ID, returning its PEM encoded contents and chain along with its parsed attributes",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the certificate",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the certificate",
+			},
+			"applications": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the applications the certificate belongs to",
+			},
+			"certificate_pem": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The PEM encoded certificate",
+			},
+			"chain_pem": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The PEM encoded certificates of the issuing CAs, in order from the issuer to the root",
+			},
+			"full_chain_pem": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The PEM encoded certificate followed by `chain_pem`",
+			},
+			"parsed": schema.ObjectAttribute{
+				Computed:       true,
+				AttributeTypes: parsedCertificateAttrTypes,
+				MarkdownDescription: `The attributes of the certificate, as returned by the ` + "`parse_certificate`" + ` function:
+	* common_name
+	* dns_names
+	* ip_addresses
+	* uris
+	* email_addresses
+	* issuer
+	* serial_number
+	* not_before (RFC3339)
+	* not_after (RFC3339)
+	* key_algorithm (e.g. RSA_2048, EC_P256)`,
+			},
+		},
+	}
+}
+
+type certificateDataSourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	Applications []types.String `tfsdk:"applications"`
+	CertPEM      types.String   `tfsdk:"certificate_pem"`
+	ChainPEM     types.String   `tfsdk:"chain_pem"`
+	FullChainPEM types.String   `tfsdk:"full_chain_pem"`
+	Parsed       types.Object   `tfsdk:"parsed"`
+}
+
+// splitCertificateChain splits a PEM encoded certificate chain into the first
+// certificate and the rest of the chain, returning the first certificate
+// parsed.
+func splitCertificateChain(s string) (string, string, *x509.Certificate, error) {
+	var blocks []*pem.Block
+	for rest := []byte(s); ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			blocks = append(blocks, block)
+		}
+	}
+	if len(blocks) == 0 {
+		return "", "", nil, errors.New("no PEM encoded certificate found")
+	}
+
+	cert, err := x509.ParseCertificate(blocks[0].Bytes)
+	if err != nil {
+		return "", "", nil, err
+	}
+	chain := ""
+	for _, b := range blocks[1:] {
+		chain += string(pem.EncodeToMemory(b))
+	}
+	return string(pem.EncodeToMemory(blocks[0])), chain, cert, nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *certificateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model certificateDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cert, err := d.client.GetCertificate(model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Certificate",
+			fmt.Sprintf("Error retrieving Certificate: %s", err.Error()),
+		)
+		return
+	}
+	contents, err := d.client.GetCertificateContents(model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Certificate",
+			fmt.Sprintf("Error retrieving Certificate contents: %s", err.Error()),
+		)
+		return
+	}
+	leaf, chain, parsed, err := splitCertificateChain(contents)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing Certificate",
+			fmt.Sprintf("Error parsing Certificate: %s", err.Error()),
+		)
+		return
+	}
+
+	model.Name = types.StringValue(cert.CertificateName)
+	model.Applications = valuesFromStrings(cert.ApplicationIDs)
+	model.CertPEM = types.StringValue(leaf)
+	model.ChainPEM = types.StringValue(chain)
+	model.FullChainPEM = types.StringValue(leaf + chain)
+	model.Parsed, diags = types.ObjectValueFrom(ctx, parsedCertificateAttrTypes, newParsedCertificate(parsed))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
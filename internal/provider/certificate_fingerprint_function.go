@@ -0,0 +1,71 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &certificateFingerprintFunction{}
+
+// parseCertificatePEM returns the first certificate in a PEM encoded string.
+func parseCertificatePEM(s string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+type certificateFingerprintFunction struct{}
+
+func NewCertificateFingerprintFunction() function.Function {
+	return &certificateFingerprintFunction{}
+}
+
+func (f *certificateFingerprintFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "certificate_fingerprint"
+}
+
+func (f *certificateFingerprintFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Compute the SHA-256 fingerprint of a certificate",
+		MarkdownDescription: "Returns the SHA-256 fingerprint of a PEM encoded certificate as an upper case hex string, as displayed in the TLS Protect Cloud inventory. If the PEM contains a chain, only the first certificate is used.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "pem",
+				MarkdownDescription: "The PEM encoded certificate",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *certificateFingerprintFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var certPEM string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &certPEM))
+	if resp.Error != nil {
+		return
+	}
+
+	cert, err := parseCertificatePEM(certPEM)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Could not parse certificate: "+err.Error())
+		return
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, fingerprint))
+}
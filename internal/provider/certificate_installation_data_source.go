@@ -0,0 +1,192 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &certificateInstallationDataSource{}
+	_ datasource.DataSourceWithConfigure = &certificateInstallationDataSource{}
+)
+
+// NewCertificateInstallationDataSource is a helper function to simplify the provider implementation.
+func NewCertificateInstallationDataSource() datasource.DataSource {
+	return &certificateInstallationDataSource{}
+}
+
+// certificateInstallationDataSource is the data source implementation.
+type certificateInstallationDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *certificateInstallationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *certificateInstallationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_installation"
+}
+
+// Schema defines the schema for the data source.
+func (d *certificateInstallationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Look up the installation status of a certificate on a machine.
+
+The overall ` + "`status`" + ` is one of:
+    * SUCCESS - the certificate is installed everywhere it should be on the machine
+    * PENDING - an installation is in progress
+    * FAILED - an installation failed, see ` + "`failure_reason`" + `
+    * NOT_FOUND - the certificate isn't due to be installed on the machine`,
+		Attributes: map[string]schema.Attribute{
+			"certificate_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the certificate",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"machine_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the machine",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The overall status of the installation",
+			},
+			"failure_reason": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Why the installation failed, if it did",
+			},
+			"installations": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Each installation of the certificate on the machine",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the machine identity",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The status reported by TLS Protect Cloud, e.g. INSTALLED or FAILED",
+						},
+						"status_details": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Details of the status",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type certificateInstallationDataSourceModel struct {
+	CertificateID types.String              `tfsdk:"certificate_id"`
+	MachineID     types.String              `tfsdk:"machine_id"`
+	Status        types.String              `tfsdk:"status"`
+	FailureReason types.String              `tfsdk:"failure_reason"`
+	Installations []certificateInstallation `tfsdk:"installations"`
+}
+
+type certificateInstallation struct {
+	ID            types.String `tfsdk:"id"`
+	Status        types.String `tfsdk:"status"`
+	StatusDetails types.String `tfsdk:"status_details"`
+}
+
+// installationStatus summarises the status of a machine identity.
+func installationStatus(status string) string {
+	switch status {
+	case "INSTALLED", "VALIDATED", "DISCOVERED":
+		return "SUCCESS"
+	case "FAILED", "MISSING":
+		return "FAILED"
+	}
+	return "PENDING"
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *certificateInstallationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model certificateInstallationDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	identities, err := d.client.GetMachineIdentities(model.CertificateID.ValueString(), model.MachineID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Certificate Installation",
+			fmt.Sprintf("Error retrieving Certificate Installation: %s", err.Error()),
+		)
+		return
+	}
+
+	status := "NOT_FOUND"
+	if len(identities) > 0 {
+		status = "SUCCESS"
+	}
+	reasons := []string{}
+	model.Installations = []certificateInstallation{}
+	for _, mi := range identities {
+		model.Installations = append(model.Installations, certificateInstallation{
+			ID:            types.StringValue(mi.ID),
+			Status:        types.StringValue(mi.Status),
+			StatusDetails: types.StringValue(mi.StatusDetails),
+		})
+
+		switch installationStatus(mi.Status) {
+		case "FAILED":
+			status = "FAILED"
+			if mi.StatusDetails != "" {
+				reasons = append(reasons, mi.StatusDetails)
+			}
+		case "PENDING":
+			if status != "FAILED" {
+				status = "PENDING"
+			}
+		}
+	}
+	model.Status = types.StringValue(status)
+	model.FailureReason = types.StringValue(strings.Join(reasons, "; "))
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
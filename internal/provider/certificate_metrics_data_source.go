@@ -0,0 +1,200 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &certificateMetricsDataSource{}
+	_ datasource.DataSourceWithConfigure = &certificateMetricsDataSource{}
+)
+
+// expiryBuckets are the buckets certificates are counted in by how many days
+// there are until they expire, in order; each holds certificates expiring
+// after the previous one and within its number of days.
+var expiryBuckets = []struct {
+	name string
+	days int
+}{
+	{"30_days", 30},
+	{"60_days", 60},
+	{"90_days", 90},
+}
+
+// NewCertificateMetricsDataSource is a helper function to simplify the provider implementation.
+func NewCertificateMetricsDataSource() datasource.DataSource {
+	return &certificateMetricsDataSource{}
+}
+
+// certificateMetricsDataSource is the data source implementation.
+type certificateMetricsDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *certificateMetricsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *certificateMetricsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_metrics"
+}
+
+// Schema defines the schema for the data source.
+func (d *certificateMetricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Count the certificates in the tenant, or in an application, by status, issuer, application and time until expiry, e.g. for dashboards or policy checks.",
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only count certificates belonging to this application",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only count certificates with this status, e.g. `ACTIVE`",
+			},
+			"total": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of certificates",
+			},
+			"by_status": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "The number of certificates with each status",
+			},
+			"by_issuer": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "The number of certificates issued by each issuer, keyed by the issuer's common name",
+			},
+			"by_application": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "The number of certificates belonging to each application, keyed by application ID. Certificates can belong to more than one application",
+			},
+			"by_expiry": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.Int64Type,
+				MarkdownDescription: "The number of certificates by time until they expire, keyed by:\n" +
+					"	* expired\n" +
+					"	* 30_days (within 30 days)\n" +
+					"	* 60_days (in 31 to 60 days)\n" +
+					"	* 90_days (in 61 to 90 days)\n" +
+					"	* later",
+			},
+		},
+	}
+}
+
+type certificateMetricsDataSourceModel struct {
+	ApplicationID types.String     `tfsdk:"application_id"`
+	Status        types.String     `tfsdk:"status"`
+	Total         types.Int64      `tfsdk:"total"`
+	ByStatus      map[string]int64 `tfsdk:"by_status"`
+	ByIssuer      map[string]int64 `tfsdk:"by_issuer"`
+	ByApplication map[string]int64 `tfsdk:"by_application"`
+	ByExpiry      map[string]int64 `tfsdk:"by_expiry"`
+}
+
+// expiryBucket returns the name of the bucket of by_expiry a certificate
+// expiring at validityEnd is counted in, or an empty string if validityEnd
+// isn't a valid time.
+func expiryBucket(validityEnd string, now time.Time) string {
+	end, err := time.Parse(time.RFC3339, validityEnd)
+	if err != nil {
+		return ""
+	}
+	if !end.After(now) {
+		return "expired"
+	}
+	for _, b := range expiryBuckets {
+		if !end.After(now.AddDate(0, 0, b.days)) {
+			return b.name
+		}
+	}
+	return "later"
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *certificateMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state certificateMetricsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certs, err := d.client.SearchCertificates(tlspc.CertificateFilter{
+		ApplicationID: state.ApplicationID.ValueString(),
+		Status:        state.Status.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving certificates",
+			fmt.Sprintf("Error searching certificates: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Total = types.Int64Value(int64(len(certs)))
+	state.ByStatus = map[string]int64{}
+	state.ByIssuer = map[string]int64{}
+	state.ByApplication = map[string]int64{}
+	state.ByExpiry = map[string]int64{"expired": 0, "later": 0}
+	for _, b := range expiryBuckets {
+		state.ByExpiry[b.name] = 0
+	}
+
+	now := time.Now()
+	for _, cert := range certs {
+		if cert.Status != "" {
+			state.ByStatus[cert.Status]++
+		}
+		if len(cert.IssuerCN) > 0 {
+			state.ByIssuer[cert.IssuerCN[0]]++
+		}
+		for _, app := range cert.ApplicationIDs {
+			state.ByApplication[app]++
+		}
+		if bucket := expiryBucket(cert.ValidityEnd, now); bucket != "" {
+			state.ByExpiry[bucket]++
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
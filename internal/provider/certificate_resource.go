@@ -0,0 +1,569 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &certificateResource{}
+	_ resource.ResourceWithConfigure   = &certificateResource{}
+	_ resource.ResourceWithImportState = &certificateResource{}
+	_ resource.ResourceWithIdentity    = &certificateResource{}
+)
+
+type certificateResource struct {
+	client tlspc.API
+}
+
+func NewCertificateResource() resource.Resource {
+	return &certificateResource{}
+}
+
+func (r *certificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate"
+}
+
+func (r *certificateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Request a certificate for a CSR, waiting for it to be issued. The certificate is retired when the resource is destroyed",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of the certificate request",
+			},
+			"application_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the application to request the certificate for",
+			},
+			"certificate_template_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the issuing template to request the certificate with, which must be assigned to the application",
+			},
+			"csr": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "The PEM encoded certificate signing request",
+			},
+			"validity_period": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "How long the certificate should be valid for, as an ISO8601 period, e.g. `P90D`. Defaults to the validity of the issuing template",
+				Validators: []validator.String{
+					validators.ISO8601Period(),
+				},
+			},
+			"custom_fields": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "A map of custom field name to the value to submit with the request, for policies which require them",
+			},
+			"tags": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "A map of tag name to the value to assign to the certificate once it's issued. Tags assigned outside of this resource are left untouched",
+			},
+			"wait_for_issuance": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether to wait for the certificate to be issued, which may take several minutes for external CAs or requests which need approval. Defaults to `true`",
+			},
+			"issuance_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultIssuanceTimeout),
+				MarkdownDescription: "How long to wait for the certificate to be issued, e.g. `30m`, after which the resource is tainted. Defaults to `10m`",
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"revocation_reason": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: `If set, the certificate is revoked for this reason before it's retired when the resource is destroyed or replaced, e.g. to handle a compromised key. The CA which issued it must support revocation, and the reason must already have been applied when the certificate is destroyed. One of:
+	* UNSPECIFIED
+	* KEY_COMPROMISE
+	* AFFILIATION_CHANGED
+	* SUPERSEDED
+	* CESSATION_OF_OPERATION
+`,
+				Validators: []validator.String{
+					stringvalidator.OneOf(tlspc.RevocationReasons...),
+				},
+			},
+			"revocation_comments": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comments recorded with the revocation",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("revocation_reason")),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The status of the certificate request, e.g. ISSUED",
+			},
+			"certificate_id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of the issued certificate",
+			},
+			"certificate_pem": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The PEM encoded certificate",
+			},
+			"chain_pem": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The PEM encoded certificates of the issuing CAs, in order from the issuer to the root",
+			},
+		},
+	}
+}
+
+func (r *certificateResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
+func (r *certificateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type certificateResourceModel struct {
+	ID             types.String            `tfsdk:"id"`
+	ApplicationID  types.String            `tfsdk:"application_id"`
+	TemplateID     types.String            `tfsdk:"certificate_template_id"`
+	CSR            types.String            `tfsdk:"csr"`
+	ValidityPeriod types.String            `tfsdk:"validity_period"`
+	CustomFields   map[string]types.String `tfsdk:"custom_fields"`
+	Tags           map[string]types.String `tfsdk:"tags"`
+	Wait           types.Bool              `tfsdk:"wait_for_issuance"`
+	Timeout        types.String            `tfsdk:"issuance_timeout"`
+	RevokeReason   types.String            `tfsdk:"revocation_reason"`
+	RevokeComments types.String            `tfsdk:"revocation_comments"`
+	Status         types.String            `tfsdk:"status"`
+	CertificateID  types.String            `tfsdk:"certificate_id"`
+	CertPEM        types.String            `tfsdk:"certificate_pem"`
+	ChainPEM       types.String            `tfsdk:"chain_pem"`
+}
+
+// defaultIssuanceTimeout is how long to wait for certificates to be issued by
+// default.
+const defaultIssuanceTimeout = "10m"
+
+// certificateRequestFailure returns why a certificate request won't be
+// issued, or "" if it's been issued or still may be.
+func certificateRequestFailure(cr *tlspc.CertificateRequest) string {
+	var reason string
+	switch cr.Status {
+	case "FAILED":
+		reason = "the CA failed to issue the certificate"
+	case "REJECTED":
+		reason = "the request was rejected"
+	case "REJECTED_APPROVAL":
+		reason = "the request was rejected by an approver"
+	case "CANCELLED":
+		reason = "the request was cancelled"
+	default:
+		return ""
+	}
+	if cr.ErrorInformation != nil && cr.ErrorInformation.Message != "" {
+		reason += ": " + cr.ErrorInformation.Message
+	}
+	return reason
+}
+
+// certificateRequestPending describes why a certificate request hasn't been
+// issued yet.
+func certificateRequestPending(cr *tlspc.CertificateRequest) string {
+	switch cr.Status {
+	case "PENDING_APPROVAL", "PENDING_FINAL_APPROVAL":
+		return "the request is awaiting approval"
+	}
+	return "the request is " + cr.Status
+}
+
+// waitForIssuance polls the certificate request cr until the certificate is
+// issued, returning the request as last seen along with any error.
+func (r *certificateResource) waitForIssuance(ctx context.Context, cr *tlspc.CertificateRequest, timeout time.Duration) (*tlspc.CertificateRequest, error) {
+	first := true
+	opts := tlspc.PollOptions{
+		Interval:    2 * time.Second,
+		MaxInterval: 30 * time.Second,
+		Timeout:     timeout,
+	}
+	err := r.client.Poll(ctx, opts, func(context.Context) (bool, error) {
+		// The request as created is checked first, as the built in CA
+		// issues certificates straight away.
+		if !first {
+			got, err := r.client.GetCertificateRequest(cr.ID)
+			if err != nil {
+				return false, err
+			}
+			cr = got
+		}
+		first = false
+
+		if reason := certificateRequestFailure(cr); reason != "" {
+			return false, errors.New(reason)
+		}
+		return cr.Status == "ISSUED" && len(cr.CertificateIDs) > 0, nil
+	})
+	if errors.Is(err, tlspc.ErrPollTimeout) {
+		err = fmt.Errorf("Timed out after %s waiting for the certificate to be issued; %s", timeout, certificateRequestPending(cr))
+	}
+	return cr, err
+}
+
+// customFields converts a map of custom field names to values into the form
+// used by the API, sorted by name.
+func customFields(fields map[string]types.String) []tlspc.CustomField {
+	out := []tlspc.CustomField{}
+	for k, v := range fields {
+		out = append(out, tlspc.CustomField{Name: k, Value: v.ValueString()})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// refresh updates the model from the certificate request, fetching the
+// certificate once it's been issued.
+func (m *certificateResourceModel) refresh(client tlspc.API, cr *tlspc.CertificateRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.StringValue(cr.ID)
+	m.Status = types.StringValue(cr.Status)
+	if len(cr.CertificateIDs) == 0 {
+		m.CertificateID = types.StringValue("")
+		m.CertPEM = types.StringValue("")
+		m.ChainPEM = types.StringValue("")
+		return diags
+	}
+	// The certificate never changes once issued, so is only fetched once.
+	if m.CertificateID.ValueString() == cr.CertificateIDs[0] && m.CertPEM.ValueString() != "" {
+		return diags
+	}
+
+	m.CertificateID = types.StringValue(cr.CertificateIDs[0])
+	contents, err := client.GetCertificateContents(cr.CertificateIDs[0])
+	if err != nil {
+		diags.AddError(
+			"Error Reading Certificate",
+			"Could not read certificate ID "+cr.CertificateIDs[0]+": "+err.Error(),
+		)
+		return diags
+	}
+	leaf, chain, _, err := splitCertificateChain(contents)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Certificate",
+			"Could not parse certificate ID "+cr.CertificateIDs[0]+": "+err.Error(),
+		)
+		return diags
+	}
+	m.CertPEM = types.StringValue(leaf)
+	m.ChainPEM = types.StringValue(chain)
+	return diags
+}
+
+// assignTags assigns tags to the issued certificate.
+func (r *certificateResource) assignTags(action string, m certificateResourceModel, tags map[string]types.String) error {
+	if len(tags) == 0 || m.CertificateID.ValueString() == "" {
+		return nil
+	}
+	return r.client.AssignTags(tlspc.TagAssignment{
+		Action:       action,
+		EntityIDs:    []string{m.CertificateID.ValueString()},
+		EntityType:   "CERTIFICATE",
+		TargetedTags: tagAssignments(tags),
+	})
+}
+
+func (r *certificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan certificateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cr := tlspc.CertificateRequest{
+		CSR:            plan.CSR.ValueString(),
+		ApplicationID:  plan.ApplicationID.ValueString(),
+		TemplateID:     plan.TemplateID.ValueString(),
+		ValidityPeriod: plan.ValidityPeriod.ValueString(),
+	}
+	if len(plan.CustomFields) > 0 {
+		cr.CustomFields = customFields(plan.CustomFields)
+	}
+	created, err := r.client.CreateCertificateRequest(cr)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Certificate",
+			"Could not request Certificate, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if plan.Wait.ValueBool() {
+		timeout, _ := time.ParseDuration(plan.Timeout.ValueString())
+		created, err = r.waitForIssuance(ctx, created, timeout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating Certificate",
+				"Certificate request ID "+created.ID+" wasn't issued: "+err.Error(),
+			)
+		}
+	}
+	resp.Diagnostics.Append(plan.refresh(r.client, created)...)
+	// The request is saved even if it failed, so that it's replaced by the
+	// next apply.
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+		return
+	}
+	if err := r.assignTags("ADD", plan, plan.Tags); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Certificate",
+			"Could not assign Tags to Certificate, unexpected error: "+err.Error(),
+		)
+	}
+	if plan.CertificateID.ValueString() == "" && len(plan.Tags) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Certificate not yet issued",
+			"The certificate request is "+plan.Status.ValueString()+", so tags will be assigned to the certificate by a later apply once it's issued",
+		)
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *certificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state certificateResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cr, err := r.client.GetCertificateRequest(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Certificate",
+			"Could not read certificate request ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	state.ApplicationID = types.StringValue(cr.ApplicationID)
+	state.TemplateID = types.StringValue(cr.TemplateID)
+	// The CSR returned may be formatted differently, so it's only used when
+	// importing.
+	if state.CSR.IsNull() && cr.CSR != "" {
+		state.CSR = types.StringValue(cr.CSR)
+	}
+	resp.Diagnostics.Append(state.refresh(r.client, cr)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.Wait.IsNull() {
+		state.Wait = types.BoolValue(true)
+	}
+	if state.Timeout.IsNull() {
+		state.Timeout = types.StringValue(defaultIssuanceTimeout)
+	}
+
+	// Only track the tags managed by this resource, so that any which
+	// couldn't be assigned before the certificate was issued show as drift
+	if len(state.Tags) > 0 {
+		assigned := []string{}
+		if state.CertificateID.ValueString() != "" {
+			assigned, err = r.client.GetAssignedTags("CERTIFICATE", state.CertificateID.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading Certificate",
+					"Could not read Tags of certificate ID "+state.CertificateID.ValueString()+": "+err.Error(),
+				)
+				return
+			}
+		}
+		tags := map[string]types.String{}
+		for _, v := range assigned {
+			name, value, ok := strings.Cut(v, ":")
+			if !ok {
+				continue
+			}
+			if _, managed := state.Tags[name]; managed {
+				tags[name] = types.StringValue(value)
+			}
+		}
+		state.Tags = tags
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+}
+
+// Update only changes the tags and the settings used when creating or
+// destroying the certificate, as changes to anything else request a new
+// certificate.
+func (r *certificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state certificateResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removed := map[string]types.String{}
+	for k, v := range state.Tags {
+		if pv, ok := plan.Tags[k]; !ok || !pv.Equal(v) {
+			removed[k] = v
+		}
+	}
+	if err := r.assignTags("REMOVE", state, removed); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Certificate",
+			"Could not remove Tags, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if err := r.assignTags("ADD", state, plan.Tags); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Certificate",
+			"Could not add Tags, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	state.Tags = plan.Tags
+	state.Wait = plan.Wait
+	state.Timeout = plan.Timeout
+	state.RevokeReason = plan.RevokeReason
+	state.RevokeComments = plan.RevokeComments
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+}
+
+func (r *certificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state certificateResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.CertificateID.ValueString() == "" {
+		return
+	}
+	if !state.RevokeReason.IsNull() {
+		err := r.client.RevokeCertificate(state.CertificateID.ValueString(), state.RevokeReason.ValueString(), state.RevokeComments.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Certificate",
+				"Could not revoke Certificate ID "+state.CertificateID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	err := r.client.RetireCertificates([]string{state.CertificateID.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Certificate",
+			"Could not retire Certificate ID "+state.CertificateID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *certificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
@@ -5,31 +5,56 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"terraform-provider-tlspc/internal/tlspc"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &certificateTemplateResource{}
-	_ resource.ResourceWithConfigure   = &certificateTemplateResource{}
-	_ resource.ResourceWithImportState = &certificateTemplateResource{}
+	_ resource.Resource                 = &certificateTemplateResource{}
+	_ resource.ResourceWithConfigure    = &certificateTemplateResource{}
+	_ resource.ResourceWithImportState  = &certificateTemplateResource{}
+	_ resource.ResourceWithUpgradeState = &certificateTemplateResource{}
+	_ resource.ResourceWithModifyPlan   = &certificateTemplateResource{}
 )
 
-var defaultKeyAlgorithms = types.ListValueMust(
+// defaultTemplateRegexes allows any value.
+var defaultTemplateRegexes = types.SetValueMust(
+	types.StringType,
+	[]attr.Value{types.StringValue(".*")},
+)
+
+// templateRegexAttribute returns the schema of a set of regular expressions
+// constraining a field of requested certificates.
+func templateRegexAttribute(what string) schema.SetAttribute {
+	return schema.SetAttribute{
+		Optional:            true,
+		Computed:            true,
+		ElementType:         types.StringType,
+		Default:             setdefault.StaticValue(defaultTemplateRegexes),
+		MarkdownDescription: "Regular expressions " + what + " must match, defaults to `[\".*\"]` (allow all)",
+		Validators: []validator.Set{
+			setvalidator.SizeAtLeast(1),
+		},
+	}
+}
+
+var defaultKeyAlgorithms = types.SetValueMust(
 	types.StringType,
 	[]attr.Value{
 		types.StringValue("RSA_2048"),
@@ -39,7 +64,7 @@ var defaultKeyAlgorithms = types.ListValueMust(
 )
 
 type certificateTemplateResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewCertificateTemplateResource() resource.Resource {
@@ -52,9 +77,11 @@ func (r *certificateTemplateResource) Metadata(_ context.Context, req resource.M
 
 func (r *certificateTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1: key_algorithms changed from a list to a set
+		Version: 1,
 		MarkdownDescription: `Manage Certificate Issuing Template
 
--> Currently only a limited subset of attributes are supported. All Common Name/SAN/CSR validation fields are set to ` + "`.*` (allow all)." + ` Permitted Key Algorithms are set to RSA 2048/3072/4096.`,
+-> Currently only a limited subset of attributes are supported. The Common Name, SAN and Subject fields of requests are allowed to take any value unless constrained by the regex attributes. Permitted Key Algorithms default to RSA 2048/3072/4096.`,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -71,8 +98,26 @@ func (r *certificateTemplateResource) Schema(_ context.Context, _ resource.Schem
 				MarkdownDescription: "Type of Certificate Authority (see Certificate Authority Product Option data source)",
 			},
 			"ca_product_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The ID of a Certificate Authority Product Option",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of a Certificate Authority Product Option. Either this, or `ca_name` and `product_option`, must be specified",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("ca_name")),
+				},
+			},
+			"ca_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the Certificate Authority account, used with `product_option` to look up `ca_product_id`",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("product_option")),
+				},
+			},
+			"product_option": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the Certificate Authority Product Option, used with `ca_name` to look up `ca_product_id`",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("ca_name")),
+				},
 			},
 			"key_reuse": schema.BoolAttribute{
 				Optional:            true,
@@ -80,13 +125,13 @@ func (r *certificateTemplateResource) Schema(_ context.Context, _ resource.Schem
 				Default:             booldefault.StaticBool(false),
 				MarkdownDescription: "Allow Private Key Reuse, defaults to false",
 			},
-			"key_algorithms": schema.ListAttribute{
+			"key_algorithms": schema.SetAttribute{
 				Optional:    true,
 				Computed:    true,
 				ElementType: types.StringType,
-				Default:     listdefault.StaticValue(defaultKeyAlgorithms),
-				Validators: []validator.List{
-					listvalidator.ValueStringsAre(
+				Default:     setdefault.StaticValue(defaultKeyAlgorithms),
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
 						stringvalidator.OneOf(
 							"RSA_1024", "RSA_2048", "RSA_3072", "RSA_4096", "EC_P256", "EC_P384", "EC_P521", "EC_ED25519",
 						),
@@ -104,21 +149,47 @@ func (r *certificateTemplateResource) Schema(_ context.Context, _ resource.Schem
 	If unspecified, defaults to: [RSA_2048, RSA_3072, RSA_4096],
 `,
 			},
+			"san_regexes":        templateRegexAttribute("DNS name SANs"),
+			"subject_cn_regexes": templateRegexAttribute("the Common Name"),
+			"subject_o_regexes":  templateRegexAttribute("the Organization"),
+			"subject_ou_regexes": templateRegexAttribute("Organizational Units"),
+			"subject_l_regexes":  templateRegexAttribute("the Locality"),
+			"subject_st_regexes": templateRegexAttribute("the State or Province"),
+			"subject_c_values":   templateRegexAttribute("the Country"),
 		},
 	}
 }
 
+// productTemplateKey is the private state key the CA product template is kept
+// under, so that updates which don't change the CA needn't look it up.
+const productTemplateKey = "product_template"
+
+// privateKeySetter is the private state of a resource response.
+type privateKeySetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func setProductTemplate(ctx context.Context, private privateKeySetter, product tlspc.CAProductTemplate) diag.Diagnostics {
+	b, err := json.Marshal(product)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Error encoding CA product template", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, productTemplateKey, b)
+}
+
 func (r *certificateTemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -132,11 +203,87 @@ type certificateTemplateResourceModel struct {
 	Name          types.String   `tfsdk:"name"`
 	CAType        types.String   `tfsdk:"ca_type"`
 	CAProductID   types.String   `tfsdk:"ca_product_id"`
+	CAName        types.String   `tfsdk:"ca_name"`
+	ProductOption types.String   `tfsdk:"product_option"`
 	KeyReuse      types.Bool     `tfsdk:"key_reuse"`
 	KeyAlgorithms []types.String `tfsdk:"key_algorithms"`
+
+	SANRegexes       []types.String `tfsdk:"san_regexes"`
+	SubjectCNRegexes []types.String `tfsdk:"subject_cn_regexes"`
+	SubjectORegexes  []types.String `tfsdk:"subject_o_regexes"`
+	SubjectOURegexes []types.String `tfsdk:"subject_ou_regexes"`
+	SubjectLRegexes  []types.String `tfsdk:"subject_l_regexes"`
+	SubjectSTRegexes []types.String `tfsdk:"subject_st_regexes"`
+	SubjectCValues   []types.String `tfsdk:"subject_c_values"`
+}
+
+// setRegexes sets the template's regular expressions from the model.
+func (m certificateTemplateResourceModel) setRegexes(ct *tlspc.CertificateTemplate) {
+	ct.SANRegexes = stringsFromValues(m.SANRegexes)
+	ct.SubjectCNRegexes = stringsFromValues(m.SubjectCNRegexes)
+	ct.SubjectORegexes = stringsFromValues(m.SubjectORegexes)
+	ct.SubjectOURegexes = stringsFromValues(m.SubjectOURegexes)
+	ct.SubjectLRegexes = stringsFromValues(m.SubjectLRegexes)
+	ct.SubjectSTRegexes = stringsFromValues(m.SubjectSTRegexes)
+	ct.SubjectCValues = stringsFromValues(m.SubjectCValues)
+}
+
+// refreshRegexes sets the model's regular expressions from the template, so
+// that changes made outside of Terraform show as drift.
+func (m *certificateTemplateResourceModel) refreshRegexes(ct tlspc.CertificateTemplate) {
+	m.SANRegexes = valuesFromStrings(ct.SANRegexes)
+	m.SubjectCNRegexes = valuesFromStrings(ct.SubjectCNRegexes)
+	m.SubjectORegexes = valuesFromStrings(ct.SubjectORegexes)
+	m.SubjectOURegexes = valuesFromStrings(ct.SubjectOURegexes)
+	m.SubjectLRegexes = valuesFromStrings(ct.SubjectLRegexes)
+	m.SubjectSTRegexes = valuesFromStrings(ct.SubjectSTRegexes)
+	m.SubjectCValues = valuesFromStrings(ct.SubjectCValues)
+}
+
+// productOption returns the CA product option for the template, looking it up
+// by name if ca_name and product_option are set, or else by ca_product_id.
+func (r *certificateTemplateResource) productOption(m certificateTemplateResourceModel) (*tlspc.CAProductOption, error) {
+	if !m.CAName.IsNull() {
+		pt, _, err := r.client.GetCAProductOption(m.CAType.ValueString(), m.CAName.ValueString(), m.ProductOption.ValueString())
+		return pt, err
+	}
+	return r.client.GetCAProductOptionByID(m.CAType.ValueString(), m.CAProductID.ValueString())
+}
+
+// ModifyPlan looks up ca_product_id from ca_name and product_option, so that
+// the plan shows if it would change, and a bad name fails the plan rather
+// than the apply.
+func (r *certificateTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var caType, caName, productOption types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ca_type"), &caType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ca_name"), &caName)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("product_option"), &productOption)...)
+	if resp.Diagnostics.HasError() || caName.IsNull() {
+		return
+	}
+	if caType.IsUnknown() || caName.IsUnknown() || productOption.IsUnknown() {
+		return
+	}
+
+	pt, _, err := r.client.GetCAProductOption(caType.ValueString(), caName.ValueString(), productOption.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("product_option"),
+			"CA Product Option not found",
+			fmt.Sprintf("Could not find product option %q of CA %q: %s", productOption.ValueString(), caName.ValueString(), err.Error()),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ca_product_id"), pt.ID)...)
 }
 
 func (r *certificateTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan certificateTemplateResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -144,7 +291,7 @@ func (r *certificateTemplateResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
-	pt, err := r.client.GetCAProductOptionByID(plan.CAType.ValueString(), plan.CAProductID.ValueString())
+	pt, err := r.productOption(plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating certificate template",
@@ -152,6 +299,7 @@ func (r *certificateTemplateResource) Create(ctx context.Context, req resource.C
 		)
 		return
 	}
+	plan.CAProductID = types.StringValue(pt.ID)
 
 	ct := tlspc.CertificateTemplate{
 		Name:                                plan.Name.ValueString(),
@@ -160,14 +308,8 @@ func (r *certificateTemplateResource) Create(ctx context.Context, req resource.C
 		Product:                             pt.Details.Template,
 		KeyReuse:                            plan.KeyReuse.ValueBool(),
 		KeyTypes:                            keyTypesFromAlgorithms(plan.KeyAlgorithms),
-		SANRegexes:                          []string{".*"},
-		SubjectCNRegexes:                    []string{".*"},
-		SubjectCValues:                      []string{".*"},
-		SubjectLRegexes:                     []string{".*"},
-		SubjectORegexes:                     []string{".*"},
-		SubjectOURegexes:                    []string{".*"},
-		SubjectSTRegexes:                    []string{".*"},
 	}
+	plan.setRegexes(&ct)
 
 	created, err := r.client.CreateCertificateTemplate(ct)
 	if err != nil {
@@ -180,9 +322,12 @@ func (r *certificateTemplateResource) Create(ctx context.Context, req resource.C
 	plan.ID = types.StringValue(created.ID)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductTemplate(ctx, resp.Private, pt.Details.Template)...)
 }
 
 func (r *certificateTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state certificateTemplateResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -201,16 +346,21 @@ func (r *certificateTemplateResource) Read(ctx context.Context, req resource.Rea
 	}
 
 	state.ID = types.StringValue(ct.ID)
+	state.Name = types.StringValue(ct.Name)
 	state.CAType = types.StringValue(ct.CertificateAuthorityType)
 	state.CAProductID = types.StringValue(ct.CertificateAuthorityProductOptionID)
 	state.KeyReuse = types.BoolValue(ct.KeyReuse)
 	state.KeyAlgorithms = keyAlgorithmsFromKeyTypes(ct.KeyTypes)
+	state.refreshRegexes(*ct)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductTemplate(ctx, resp.Private, ct.Product)...)
 }
 
 func (r *certificateTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan, state certificateTemplateResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -224,13 +374,21 @@ func (r *certificateTemplateResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
-	pt, err := r.client.GetCAProductOptionByID(plan.CAType.ValueString(), plan.CAProductID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating certificate template",
-			"CA Product ID not found: "+err.Error(),
-		)
-		return
+	// The CA product template only needs looking up if the CA has changed.
+	var product tlspc.CAProductTemplate
+	stored, diags := req.Private.GetKey(ctx, productTemplateKey)
+	resp.Diagnostics.Append(diags...)
+	if !plan.CAType.Equal(state.CAType) || !plan.CAProductID.Equal(state.CAProductID) || stored == nil || json.Unmarshal(stored, &product) != nil {
+		pt, err := r.productOption(plan)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating certificate template",
+				"CA Product ID not found: "+err.Error(),
+			)
+			return
+		}
+		plan.CAProductID = types.StringValue(pt.ID)
+		product = pt.Details.Template
 	}
 
 	ct := tlspc.CertificateTemplate{
@@ -238,17 +396,11 @@ func (r *certificateTemplateResource) Update(ctx context.Context, req resource.U
 		Name:                                plan.Name.ValueString(),
 		CertificateAuthorityType:            plan.CAType.ValueString(),
 		CertificateAuthorityProductOptionID: plan.CAProductID.ValueString(),
-		Product:                             pt.Details.Template,
+		Product:                             product,
 		KeyReuse:                            plan.KeyReuse.ValueBool(),
 		KeyTypes:                            keyTypesFromAlgorithms(plan.KeyAlgorithms),
-		SANRegexes:                          []string{".*"},
-		SubjectCNRegexes:                    []string{".*"},
-		SubjectCValues:                      []string{".*"},
-		SubjectLRegexes:                     []string{".*"},
-		SubjectORegexes:                     []string{".*"},
-		SubjectOURegexes:                    []string{".*"},
-		SubjectSTRegexes:                    []string{".*"},
 	}
+	plan.setRegexes(&ct)
 
 	updated, err := r.client.UpdateCertificateTemplate(ct)
 	if err != nil {
@@ -261,9 +413,12 @@ func (r *certificateTemplateResource) Update(ctx context.Context, req resource.U
 	plan.ID = types.StringValue(updated.ID)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductTemplate(ctx, resp.Private, product)...)
 }
 
 func (r *certificateTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state certificateTemplateResourceModel
 
 	diags := req.State.Get(ctx, &state)
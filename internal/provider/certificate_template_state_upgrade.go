@@ -0,0 +1,75 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// certificateTemplateResourceModelV0 is shape compatible with version 0 of the
+// schema.
+type certificateTemplateResourceModelV0 struct {
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	CAType        types.String   `tfsdk:"ca_type"`
+	CAProductID   types.String   `tfsdk:"ca_product_id"`
+	KeyReuse      types.Bool     `tfsdk:"key_reuse"`
+	KeyAlgorithms []types.String `tfsdk:"key_algorithms"`
+}
+
+func (r *certificateTemplateResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored key_algorithms as a list
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed: true,
+					},
+					"name": schema.StringAttribute{
+						Required: true,
+					},
+					"ca_type": schema.StringAttribute{
+						Required: true,
+					},
+					"ca_product_id": schema.StringAttribute{
+						Required: true,
+					},
+					"key_reuse": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+					},
+					"key_algorithms": schema.ListAttribute{
+						Optional:    true,
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior certificateTemplateResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := certificateTemplateResourceModel{
+					ID:            prior.ID,
+					Name:          prior.Name,
+					CAType:        prior.CAType,
+					CAProductID:   prior.CAProductID,
+					CAName:        types.StringNull(),
+					ProductOption: types.StringNull(),
+					KeyReuse:      prior.KeyReuse,
+					KeyAlgorithms: prior.KeyAlgorithms,
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
@@ -26,7 +26,7 @@ var (
 )
 
 type cloudProviderGCPResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewCloudProviderGCPResource() resource.Resource {
@@ -69,6 +69,9 @@ func (r *cloudProviderGCPResource) Schema(_ context.Context, _ resource.SchemaRe
 			"service_account_email": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "GCP Service Account Email",
+				Validators: []validator.String{
+					validators.Email(),
+				},
 			},
 			"project_number": schema.Int64Attribute{
 				Required:            true,
@@ -82,6 +85,14 @@ func (r *cloudProviderGCPResource) Schema(_ context.Context, _ resource.SchemaRe
 				Required:            true,
 				MarkdownDescription: "GCP Workload Identity Pool Provider ID",
 			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The validation status of this integration, either `VALIDATED` or `NOT_VALIDATED`. Use `tlspc_cloudprovider_gcp_validate` to validate it",
+			},
+			"status_details": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Why the integration isn't validated, if known",
+			},
 		},
 	}
 }
@@ -91,12 +102,12 @@ func (r *cloudProviderGCPResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -114,9 +125,22 @@ type cloudProviderGCPResourceModel struct {
 	ProjectNumber                  types.Int64  `tfsdk:"project_number"`
 	WorkloadIdentityPoolId         types.String `tfsdk:"workload_identity_pool_id"`
 	WorkloadIdentityPoolProviderId types.String `tfsdk:"workload_identity_pool_provider_id"`
+	Status                         types.String `tfsdk:"status"`
+	StatusDetails                  types.String `tfsdk:"status_details"`
+}
+
+// setStatus sets the validation status of the integration.
+func (m *cloudProviderGCPResourceModel) setStatus(cp *tlspc.CloudProviderGCP) {
+	m.Status = types.StringValue(cp.Status)
+	m.StatusDetails = types.StringNull()
+	if cp.StatusDetails != "" {
+		m.StatusDetails = types.StringValue(cp.StatusDetails)
+	}
 }
 
 func (r *cloudProviderGCPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan cloudProviderGCPResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -145,12 +169,15 @@ func (r *cloudProviderGCPResource) Create(ctx context.Context, req resource.Crea
 
 	plan.ID = types.StringValue(created.ID)
 	plan.IssuerUrl = types.StringValue(created.IssuerUrl)
+	plan.setStatus(created)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *cloudProviderGCPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state cloudProviderGCPResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -175,12 +202,15 @@ func (r *cloudProviderGCPResource) Read(ctx context.Context, req resource.ReadRe
 	state.ProjectNumber = types.Int64Value(cp.ProjectNumber)
 	state.WorkloadIdentityPoolId = types.StringValue(cp.WorkloadIdentityPoolId)
 	state.WorkloadIdentityPoolProviderId = types.StringValue(cp.WorkloadIdentityPoolProviderId)
+	state.setStatus(cp)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *cloudProviderGCPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state, plan cloudProviderGCPResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -214,12 +244,15 @@ func (r *cloudProviderGCPResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 	plan.IssuerUrl = types.StringValue(updated.IssuerUrl)
+	plan.setStatus(updated)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *cloudProviderGCPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state cloudProviderGCPResourceModel
 
 	diags := req.State.Get(ctx, &state)
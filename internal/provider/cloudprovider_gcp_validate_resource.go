@@ -22,7 +22,7 @@ var (
 )
 
 type cloudProviderGCPValidateResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewCloudProviderGCPValidateResource() resource.Resource {
@@ -54,12 +54,12 @@ func (r *cloudProviderGCPValidateResource) Configure(_ context.Context, req reso
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -74,6 +74,8 @@ type cloudProviderGCPValidateResourceModel struct {
 }
 
 func (r *cloudProviderGCPValidateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan cloudProviderGCPValidateResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -114,6 +116,8 @@ func (r *cloudProviderGCPValidateResource) Create(ctx context.Context, req resou
 }
 
 func (r *cloudProviderGCPValidateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state cloudProviderGCPValidateResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -145,6 +149,8 @@ func (r *cloudProviderGCPValidateResource) Read(ctx context.Context, req resourc
 }
 
 func (r *cloudProviderGCPValidateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state, plan cloudProviderGCPValidateResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -190,6 +196,8 @@ func (r *cloudProviderGCPValidateResource) Update(ctx context.Context, req resou
 }
 
 func (r *cloudProviderGCPValidateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state cloudProviderGCPValidateResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -0,0 +1,85 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &dockerConfigJSONFunction{}
+
+type dockerConfigJSONFunction struct{}
+
+func NewDockerConfigJSONFunction() function.Function {
+	return &dockerConfigJSONFunction{}
+}
+
+func (f *dockerConfigJSONFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "docker_config_json"
+}
+
+func (f *dockerConfigJSONFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Render a .dockerconfigjson for the Venafi OCI private registry",
+		MarkdownDescription: "Returns a `.dockerconfigjson` string, suitable for a Kubernetes `kubernetes.io/dockerconfigjson` image pull secret, which authenticates to the Venafi OCI private registry with the `oci_account_name` and `oci_registry_token` of a `tlspc_registry_account`. The registry hostname defaults to `" + defaultRegistryHostname + "`, and may be given as an optional third argument (e.g. `private-registry.venafi.eu` for EU tenants).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "oci_account_name",
+				MarkdownDescription: "The OCI registry account name",
+			},
+			function.StringParameter{
+				Name:                "oci_registry_token",
+				MarkdownDescription: "The OCI registry token",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "hostname",
+			MarkdownDescription: "The registry hostname",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *dockerConfigJSONFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var username, password string
+	var hostnames []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &username, &password, &hostnames))
+	if resp.Error != nil {
+		return
+	}
+
+	if username == "" {
+		resp.Error = function.NewArgumentFuncError(0, "oci_account_name must not be empty")
+		return
+	}
+	if password == "" {
+		resp.Error = function.NewArgumentFuncError(1, "oci_registry_token must not be empty")
+		return
+	}
+
+	hostname := defaultRegistryHostname
+	switch len(hostnames) {
+	case 0:
+	case 1:
+		hostname = hostnames[0]
+		if hostname == "" {
+			resp.Error = function.NewArgumentFuncError(2, "hostname must not be empty")
+			return
+		}
+	default:
+		resp.Error = function.NewArgumentFuncError(3, "At most one hostname may be given")
+		return
+	}
+
+	out, err := dockerConfigJSON(hostname, username, password)
+	if err != nil {
+		resp.Error = function.NewFuncError("Could not render dockerconfigjson: " + err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, out))
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// fakeAPI is a tlspc.API for testing resources without an HTTP server. Only
+// the methods used by the tests are implemented, any others panic on the nil
+// embedded API.
+type fakeAPI struct {
+	tlspc.API
+
+	scopes          []string
+	serviceAccounts map[string]*tlspc.ServiceAccount
+	dependents      map[string]*tlspc.TeamDependents
+	deletedTeams    []string
+}
+
+func (f *fakeAPI) Stats() tlspc.Stats {
+	return tlspc.Stats{}
+}
+
+func (f *fakeAPI) LowRateLimitQuota() (tlspc.RateLimitQuota, bool) {
+	return tlspc.RateLimitQuota{}, false
+}
+
+func (f *fakeAPI) GetServiceAccountScopes() ([]string, error) {
+	return f.scopes, nil
+}
+
+func (f *fakeAPI) GetServiceAccount(id string) (*tlspc.ServiceAccount, error) {
+	if sa, ok := f.serviceAccounts[id]; ok {
+		return sa, nil
+	}
+	return nil, fmt.Errorf("service account %s not found", id)
+}
+
+func (f *fakeAPI) GetTeamDependents(id string) (*tlspc.TeamDependents, error) {
+	if deps, ok := f.dependents[id]; ok {
+		return deps, nil
+	}
+	return &tlspc.TeamDependents{}, nil
+}
+
+func (f *fakeAPI) DeleteTeam(id string) error {
+	f.deletedTeams = append(f.deletedTeams, id)
+	return nil
+}
+
+// newState returns the state of r holding model.
+func newState(t *testing.T, r resource.Resource, model any) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+	s := resourceSchema(ctx, r)
+	state := tfsdk.State{Schema: *s}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("unexpected error setting state: %v", diags)
+	}
+	return state
+}
@@ -0,0 +1,227 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fireflyPrivateKeyFile is where the Firefly Helm chart mounts the service
+// account private key.
+const fireflyPrivateKeyFile = "/var/run/secrets/firefly.venafi.com/svc-acct.key"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &fireflyBootstrapDataSource{}
+	_ datasource.DataSourceWithConfigure = &fireflyBootstrapDataSource{}
+)
+
+// NewFireflyBootstrapDataSource is a helper function to simplify the provider implementation.
+func NewFireflyBootstrapDataSource() datasource.DataSource {
+	return &fireflyBootstrapDataSource{}
+}
+
+// fireflyBootstrapDataSource is the data source implementation.
+type fireflyBootstrapDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *fireflyBootstrapDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *fireflyBootstrapDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firefly_bootstrap"
+}
+
+// Schema defines the schema for the data source.
+func (d *fireflyBootstrapDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Render the bootstrap configuration for Firefly instances using a Firefly Configuration, for passing to the Firefly Helm chart.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the Firefly Configuration",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"service_account_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the service account Firefly authenticates as. Must be one of the configuration's service accounts; may be omitted if it only has one",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"config_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the Firefly Configuration",
+			},
+			"api_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The TLSPC API URL Firefly connects to",
+			},
+			"client_authentication": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "How Firefly authenticates clients requesting certificates, null if not configured",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Computed: true,
+					},
+					"urls": schema.SetAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"audience": schema.StringAttribute{
+						Computed: true,
+					},
+					"issuers": schema.SetAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"client_ids": schema.SetAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			"trust_anchors": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The JWKS or OIDC discovery URLs Firefly trusts to verify client tokens",
+			},
+			"values": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Helm values, as JSON, which bootstrap Firefly with this configuration. The service account private key must be provided separately",
+			},
+		},
+	}
+}
+
+type fireflyBootstrapDataSourceModel struct {
+	ID                   types.String               `tfsdk:"id"`
+	ServiceAccountID     types.String               `tfsdk:"service_account_id"`
+	ConfigName           types.String               `tfsdk:"config_name"`
+	APIURL               types.String               `tfsdk:"api_url"`
+	ClientAuthentication *clientAuthenticationModel `tfsdk:"client_authentication"`
+	TrustAnchors         []types.String             `tfsdk:"trust_anchors"`
+	Values               types.String               `tfsdk:"values"`
+}
+
+// fireflyBootstrapValues renders the Helm values which bootstrap Firefly.
+func fireflyBootstrapValues(apiURL, serviceAccountID string) (string, error) {
+	values := map[string]any{
+		"deployment": map[string]any{
+			"venafiClientID": serviceAccountID,
+			"config": map[string]any{
+				"bootstrap": map[string]any{
+					"vaas": map[string]any{
+						"url": apiURL,
+						"auth": map[string]any{
+							"clientID":       serviceAccountID,
+							"privateKeyFile": fireflyPrivateKeyFile,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	out, err := json.Marshal(values)
+	return string(out), err
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *fireflyBootstrapDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model fireflyBootstrapDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ff, err := d.client.GetFireflyConfig(model.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Firefly Config",
+			fmt.Sprintf("Error retrieving Firefly Config: %s", err.Error()),
+		)
+		return
+	}
+
+	sa := model.ServiceAccountID.ValueString()
+	switch {
+	case sa != "":
+		if !slices.Contains(ff.ServiceAccountIds, sa) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("service_account_id"),
+				"Invalid Service Account",
+				fmt.Sprintf("Service account %s is not used by Firefly Config %s", sa, ff.Name),
+			)
+			return
+		}
+	case len(ff.ServiceAccountIds) == 1:
+		sa = ff.ServiceAccountIds[0]
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("service_account_id"),
+			"Missing Service Account",
+			fmt.Sprintf("Firefly Config %s has %d service accounts, service_account_id must be set to choose one", ff.Name, len(ff.ServiceAccountIds)),
+		)
+		return
+	}
+
+	values, err := fireflyBootstrapValues(d.client.Endpoint(), sa)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error rendering Firefly bootstrap values",
+			fmt.Sprintf("Error rendering Firefly bootstrap values: %s", err.Error()),
+		)
+		return
+	}
+
+	model.ServiceAccountID = types.StringValue(sa)
+	model.ConfigName = types.StringValue(ff.Name)
+	model.APIURL = types.StringValue(d.client.Endpoint())
+	model.ClientAuthentication = coerceClientAuthenticationModel(ff.ClientAuthentication)
+	model.TrustAnchors = nil
+	if ff.ClientAuthentication != nil {
+		model.TrustAnchors = valuesFromStrings(ff.ClientAuthentication.URLs)
+	}
+	model.Values = types.StringValue(values)
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -5,29 +5,35 @@ package provider
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 
 	"terraform-provider-tlspc/internal/tlspc"
 	"terraform-provider-tlspc/internal/validators"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &fireflyConfigResource{}
-	_ resource.ResourceWithConfigure   = &fireflyConfigResource{}
-	_ resource.ResourceWithImportState = &fireflyConfigResource{}
+	_ resource.Resource                   = &fireflyConfigResource{}
+	_ resource.ResourceWithConfigure      = &fireflyConfigResource{}
+	_ resource.ResourceWithImportState    = &fireflyConfigResource{}
+	_ resource.ResourceWithValidateConfig = &fireflyConfigResource{}
 )
 
 type fireflyConfigResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewFireflyConfigResource() resource.Resource {
@@ -75,21 +81,226 @@ func (r *fireflyConfigResource) Schema(_ context.Context, _ resource.SchemaReque
 					setvalidator.ValueStringsAre(validators.Uuid()),
 				},
 			},
+			"client_authentication": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "How Firefly instances using this configuration authenticate clients requesting certificates. If unset, client authentication is not configured",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Required: true,
+						MarkdownDescription: `The client authentication type, valid options include:
+	* JWT_JWKS
+	* JWT_OIDC
+	* JWT_STANDARD_CLAIMS
+	* MTLS
+`,
+						Validators: []validator.String{
+							stringvalidator.OneOf("JWT_JWKS", "JWT_OIDC", "JWT_STANDARD_CLAIMS", tlspc.ClientAuthenticationMTLS),
+						},
+					},
+					"urls": schema.SetAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "JWKS or OIDC discovery URLs used to verify client tokens",
+					},
+					"audience": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Expected audience of client tokens",
+					},
+					"issuers": schema.SetAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "Trusted issuers of client tokens",
+					},
+					"client_ids": schema.SetAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "Permitted client IDs",
+					},
+					"trust_anchors": schema.SetAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "PEM encoded CA certificates trusted to issue client certificates. Required when `type` is `MTLS`, and not allowed otherwise",
+					},
+				},
+			},
+			"cloud_providers": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Cloud provider environments that Firefly instances using this configuration may run in",
+				Attributes: map[string]schema.Attribute{
+					"aws": schema.SingleNestedAttribute{
+						Optional: true,
+						Attributes: map[string]schema.Attribute{
+							"account_ids": schema.SetAttribute{
+								Required:            true,
+								ElementType:         types.StringType,
+								MarkdownDescription: "AWS account IDs",
+							},
+							"regions": schema.SetAttribute{
+								Required:            true,
+								ElementType:         types.StringType,
+								MarkdownDescription: "AWS regions, e.g. `us-east-1`",
+							},
+						},
+					},
+					"azure": schema.SingleNestedAttribute{
+						Optional: true,
+						Attributes: map[string]schema.Attribute{
+							"subscription_ids": schema.SetAttribute{
+								Required:            true,
+								ElementType:         types.StringType,
+								MarkdownDescription: "Azure subscription IDs",
+							},
+						},
+					},
+					"gcp": schema.SingleNestedAttribute{
+						Optional: true,
+						Attributes: map[string]schema.Attribute{
+							"project_identifiers": schema.SetAttribute{
+								Required:            true,
+								ElementType:         types.StringType,
+								MarkdownDescription: "GCP project IDs or numbers",
+							},
+							"regions": schema.SetAttribute{
+								Required:            true,
+								ElementType:         types.StringType,
+								MarkdownDescription: "GCP regions, e.g. `europe-west1`",
+							},
+						},
+					},
+				},
+			},
+			"min_tls_version": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("TLS13"),
+				MarkdownDescription: "The minimum TLS version Firefly instances using this configuration accept, either `TLS12` or `TLS13`. Defaults to `TLS13`",
+				Validators: []validator.String{
+					stringvalidator.OneOf("TLS12", "TLS13"),
+				},
+			},
+			"advanced_settings": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Advanced settings for Firefly instances using this configuration. If unset, the defaults are used",
+				Attributes: map[string]schema.Attribute{
+					"key_storage": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString(tlspc.FireflyKeyStorageInMemory),
+						MarkdownDescription: `Where Firefly keeps its issuing keys, valid options include:
+	* ` + tlspc.FireflyKeyStorageInMemory + ` - keys are only held in memory, and are regenerated when Firefly restarts (default)
+	* ` + tlspc.FireflyKeyStoragePersisted + ` - keys are persisted, and survive restarts
+`,
+						Validators: []validator.String{
+							stringvalidator.OneOf(tlspc.FireflyKeyStorageInMemory, tlspc.FireflyKeyStoragePersisted),
+						},
+					},
+					"min_heartbeat_interval_seconds": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "The minimum interval between heartbeats sent by Firefly instances, in seconds",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"enable_issuance_audit_log": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Whether Firefly logs every certificate it issues",
+					},
+					"include_raw_cert_data_in_audit_log": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Whether the issuance audit log includes the issued certificates",
+					},
+					"require_fips_compliant_build": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Whether only FIPS compliant builds of Firefly may use this configuration",
+					},
+				},
+			},
 		},
 	}
 }
 
+// fireflyJWTClientAuthenticationAttrs are the client_authentication attributes
+// only used by the JWT types.
+var fireflyJWTClientAuthenticationAttrs = []string{"urls", "audience", "issuers", "client_ids"}
+
+func (r *fireflyConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	caPath := path.Root("client_authentication")
+	var ca types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, caPath, &ca)...)
+	if resp.Diagnostics.HasError() || ca.IsUnknown() || ca.IsNull() {
+		return
+	}
+	attrs := ca.Attributes()
+	caType, ok := attrs["type"].(types.String)
+	if !ok || caType.IsUnknown() || caType.IsNull() {
+		return
+	}
+	trustAnchors, _ := attrs["trust_anchors"].(types.Set)
+
+	if caType.ValueString() != tlspc.ClientAuthenticationMTLS {
+		if !trustAnchors.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				caPath.AtName("trust_anchors"),
+				"Invalid Client Authentication",
+				fmt.Sprintf("trust_anchors must not be set when type is %s", caType.ValueString()),
+			)
+		}
+		return
+	}
+
+	for _, name := range fireflyJWTClientAuthenticationAttrs {
+		if v, ok := attrs[name]; ok && !v.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				caPath.AtName(name),
+				"Invalid Client Authentication",
+				fmt.Sprintf("%s must not be set when type is %s", name, tlspc.ClientAuthenticationMTLS),
+			)
+		}
+	}
+
+	if trustAnchors.IsUnknown() {
+		return
+	}
+	if len(trustAnchors.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			caPath.AtName("trust_anchors"),
+			"Invalid Client Authentication",
+			fmt.Sprintf("trust_anchors must be set when type is %s", tlspc.ClientAuthenticationMTLS),
+		)
+		return
+	}
+	for _, v := range trustAnchors.Elements() {
+		anchor, ok := v.(types.String)
+		if !ok || anchor.IsUnknown() {
+			continue
+		}
+		if block, _ := pem.Decode([]byte(anchor.ValueString())); block == nil || block.Type != "CERTIFICATE" {
+			resp.Diagnostics.AddAttributeError(
+				caPath.AtName("trust_anchors").AtSetValue(anchor),
+				"Invalid Client Authentication",
+				"trust_anchors must be PEM encoded certificates",
+			)
+		}
+	}
+}
+
 func (r *fireflyConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -99,14 +310,223 @@ func (r *fireflyConfigResource) Configure(_ context.Context, req resource.Config
 }
 
 type fireflyConfigResourceModel struct {
-	ID              types.String   `tfsdk:"id"`
-	Name            types.String   `tfsdk:"name"`
-	SubCAProvider   types.String   `tfsdk:"subca_provider"`
-	ServiceAccounts []types.String `tfsdk:"service_accounts"`
-	Policies        []types.String `tfsdk:"policies"`
+	ID                   types.String               `tfsdk:"id"`
+	Name                 types.String               `tfsdk:"name"`
+	SubCAProvider        types.String               `tfsdk:"subca_provider"`
+	ServiceAccounts      []types.String             `tfsdk:"service_accounts"`
+	Policies             []types.String             `tfsdk:"policies"`
+	ClientAuthentication *clientAuthenticationModel `tfsdk:"client_authentication"`
+	CloudProviders       *cloudProvidersModel       `tfsdk:"cloud_providers"`
+	MinTLSVersion        types.String               `tfsdk:"min_tls_version"`
+	AdvancedSettings     *advancedSettingsModel     `tfsdk:"advanced_settings"`
+}
+
+type advancedSettingsModel struct {
+	KeyStorage                   types.String `tfsdk:"key_storage"`
+	MinHeartbeatIntervalSeconds  types.Int64  `tfsdk:"min_heartbeat_interval_seconds"`
+	EnableIssuanceAuditLog       types.Bool   `tfsdk:"enable_issuance_audit_log"`
+	IncludeRawCertDataInAuditLog types.Bool   `tfsdk:"include_raw_cert_data_in_audit_log"`
+	RequireFIPSCompliantBuild    types.Bool   `tfsdk:"require_fips_compliant_build"`
+}
+
+type cloudProvidersModel struct {
+	AWS   *cloudProvidersAWSModel   `tfsdk:"aws"`
+	Azure *cloudProvidersAzureModel `tfsdk:"azure"`
+	GCP   *cloudProvidersGCPModel   `tfsdk:"gcp"`
+}
+
+type cloudProvidersAWSModel struct {
+	AccountIDs []types.String `tfsdk:"account_ids"`
+	Regions    []types.String `tfsdk:"regions"`
+}
+
+type cloudProvidersAzureModel struct {
+	SubscriptionIDs []types.String `tfsdk:"subscription_ids"`
+}
+
+type cloudProvidersGCPModel struct {
+	ProjectIdentifiers []types.String `tfsdk:"project_identifiers"`
+	Regions            []types.String `tfsdk:"regions"`
+}
+
+type clientAuthenticationModel struct {
+	Type         types.String   `tfsdk:"type"`
+	URLs         []types.String `tfsdk:"urls"`
+	Audience     types.String   `tfsdk:"audience"`
+	Issuers      []types.String `tfsdk:"issuers"`
+	ClientIDs    []types.String `tfsdk:"client_ids"`
+	TrustAnchors []types.String `tfsdk:"trust_anchors"`
+}
+
+func coerceClientAuthentication(m *clientAuthenticationModel) *tlspc.ClientAuthentication {
+	if m == nil {
+		return nil
+	}
+
+	urls := []string{}
+	for _, v := range m.URLs {
+		urls = append(urls, v.ValueString())
+	}
+
+	issuers := []string{}
+	for _, v := range m.Issuers {
+		issuers = append(issuers, v.ValueString())
+	}
+
+	clientIDs := []string{}
+	for _, v := range m.ClientIDs {
+		clientIDs = append(clientIDs, v.ValueString())
+	}
+
+	return &tlspc.ClientAuthentication{
+		Type:         m.Type.ValueString(),
+		URLs:         urls,
+		Audience:     m.Audience.ValueString(),
+		Issuers:      issuers,
+		ClientIDs:    clientIDs,
+		TrustAnchors: stringsFromValues(m.TrustAnchors),
+	}
+}
+
+func stringsFromValues(in []types.String) []string {
+	out := []string{}
+	for _, v := range in {
+		out = append(out, v.ValueString())
+	}
+	return out
+}
+
+func valuesFromStrings(in []string) []types.String {
+	out := []types.String{}
+	for _, v := range in {
+		out = append(out, types.StringValue(v))
+	}
+	return out
+}
+
+func coerceCloudProviders(m *cloudProvidersModel) tlspc.CloudProviders {
+	cp := tlspc.CloudProviders{}
+	if m == nil {
+		return cp
+	}
+	if m.AWS != nil {
+		cp.AWS = &tlspc.CloudProvidersAWS{
+			AccountIDs: stringsFromValues(m.AWS.AccountIDs),
+			Regions:    stringsFromValues(m.AWS.Regions),
+		}
+	}
+	if m.Azure != nil {
+		cp.Azure = &tlspc.CloudProvidersAzure{
+			SubscriptionIDs: stringsFromValues(m.Azure.SubscriptionIDs),
+		}
+	}
+	if m.GCP != nil {
+		cp.GCP = &tlspc.CloudProvidersGCP{
+			ProjectIdentifiers: stringsFromValues(m.GCP.ProjectIdentifiers),
+			Regions:            stringsFromValues(m.GCP.Regions),
+		}
+	}
+	return cp
+}
+
+func coerceCloudProvidersModel(cp tlspc.CloudProviders) *cloudProvidersModel {
+	if cp.AWS == nil && cp.Azure == nil && cp.GCP == nil {
+		return nil
+	}
+
+	m := cloudProvidersModel{}
+	if cp.AWS != nil {
+		m.AWS = &cloudProvidersAWSModel{
+			AccountIDs: valuesFromStrings(cp.AWS.AccountIDs),
+			Regions:    valuesFromStrings(cp.AWS.Regions),
+		}
+	}
+	if cp.Azure != nil {
+		m.Azure = &cloudProvidersAzureModel{
+			SubscriptionIDs: valuesFromStrings(cp.Azure.SubscriptionIDs),
+		}
+	}
+	if cp.GCP != nil {
+		m.GCP = &cloudProvidersGCPModel{
+			ProjectIdentifiers: valuesFromStrings(cp.GCP.ProjectIdentifiers),
+			Regions:            valuesFromStrings(cp.GCP.Regions),
+		}
+	}
+	return &m
+}
+
+func coerceAdvancedSettings(m *advancedSettingsModel) *tlspc.FireflyAdvancedSettings {
+	if m == nil {
+		return nil
+	}
+
+	return &tlspc.FireflyAdvancedSettings{
+		KeyStorage:                   m.KeyStorage.ValueString(),
+		MinHeartbeatIntervalSeconds:  m.MinHeartbeatIntervalSeconds.ValueInt64(),
+		EnableIssuanceAuditLog:       m.EnableIssuanceAuditLog.ValueBool(),
+		IncludeRawCertDataInAuditLog: m.IncludeRawCertDataInAuditLog.ValueBool(),
+		RequireFIPSCompliantBuild:    m.RequireFIPSCompliantBuild.ValueBool(),
+	}
+}
+
+// coerceAdvancedSettingsModel returns the model of as, or nil if as only
+// holds the defaults and advanced_settings wasn't set in prior.
+func coerceAdvancedSettingsModel(as *tlspc.FireflyAdvancedSettings, prior *advancedSettingsModel) *advancedSettingsModel {
+	if as == nil {
+		return nil
+	}
+	defaults := tlspc.FireflyAdvancedSettings{KeyStorage: tlspc.FireflyKeyStorageInMemory}
+	if prior == nil && (*as == defaults || *as == tlspc.FireflyAdvancedSettings{}) {
+		return nil
+	}
+
+	m := advancedSettingsModel{
+		KeyStorage:                   types.StringValue(as.KeyStorage),
+		MinHeartbeatIntervalSeconds:  types.Int64Null(),
+		EnableIssuanceAuditLog:       types.BoolValue(as.EnableIssuanceAuditLog),
+		IncludeRawCertDataInAuditLog: types.BoolValue(as.IncludeRawCertDataInAuditLog),
+		RequireFIPSCompliantBuild:    types.BoolValue(as.RequireFIPSCompliantBuild),
+	}
+	if as.KeyStorage == "" {
+		m.KeyStorage = types.StringValue(tlspc.FireflyKeyStorageInMemory)
+	}
+	if as.MinHeartbeatIntervalSeconds != 0 {
+		m.MinHeartbeatIntervalSeconds = types.Int64Value(as.MinHeartbeatIntervalSeconds)
+	}
+	return &m
+}
+
+func coerceClientAuthenticationModel(ca *tlspc.ClientAuthentication) *clientAuthenticationModel {
+	if ca == nil || ca.Type == "" || ca.Type == tlspc.ClientAuthenticationNone {
+		return nil
+	}
+
+	m := clientAuthenticationModel{
+		Type:     types.StringValue(ca.Type),
+		Audience: types.StringNull(),
+	}
+	if ca.Audience != "" {
+		m.Audience = types.StringValue(ca.Audience)
+	}
+	for _, v := range ca.URLs {
+		m.URLs = append(m.URLs, types.StringValue(v))
+	}
+	for _, v := range ca.Issuers {
+		m.Issuers = append(m.Issuers, types.StringValue(v))
+	}
+	for _, v := range ca.ClientIDs {
+		m.ClientIDs = append(m.ClientIDs, types.StringValue(v))
+	}
+	for _, v := range ca.TrustAnchors {
+		m.TrustAnchors = append(m.TrustAnchors, types.StringValue(v))
+	}
+
+	return &m
 }
 
 func (r *fireflyConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan fireflyConfigResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -125,12 +545,14 @@ func (r *fireflyConfigResource) Create(ctx context.Context, req resource.CreateR
 	}
 
 	ff := tlspc.FireflyConfig{
-		Name:              plan.Name.ValueString(),
-		SubCAProviderId:   plan.SubCAProvider.ValueString(),
-		PolicyIds:         policies,
-		ServiceAccountIds: sa,
-		MinTLSVersion:     "TLS13",
-		//ClientAuthentication: tlspc.ClientAuthentication{},
+		Name:                 plan.Name.ValueString(),
+		SubCAProviderId:      plan.SubCAProvider.ValueString(),
+		PolicyIds:            policies,
+		ServiceAccountIds:    sa,
+		MinTLSVersion:        plan.MinTLSVersion.ValueString(),
+		ClientAuthentication: coerceClientAuthentication(plan.ClientAuthentication),
+		CloudProviders:       coerceCloudProviders(plan.CloudProviders),
+		AdvancedSettings:     coerceAdvancedSettings(plan.AdvancedSettings),
 	}
 	created, err := r.client.CreateFireflyConfig(ff)
 	if err != nil {
@@ -146,6 +568,8 @@ func (r *fireflyConfigResource) Create(ctx context.Context, req resource.CreateR
 }
 
 func (r *fireflyConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state fireflyConfigResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -177,12 +601,22 @@ func (r *fireflyConfigResource) Read(ctx context.Context, req resource.ReadReque
 		policies = append(policies, types.StringValue(v.ID))
 	}
 	state.Policies = policies
+	state.ClientAuthentication = coerceClientAuthenticationModel(ff.ClientAuthentication)
+	state.CloudProviders = coerceCloudProvidersModel(ff.CloudProviders)
+	if ff.MinTLSVersion != "" {
+		state.MinTLSVersion = types.StringValue(ff.MinTLSVersion)
+	} else {
+		state.MinTLSVersion = types.StringValue("TLS13")
+	}
+	state.AdvancedSettings = coerceAdvancedSettingsModel(ff.AdvancedSettings, state.AdvancedSettings)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *fireflyConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan, state fireflyConfigResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -206,17 +640,20 @@ func (r *fireflyConfigResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	ff := tlspc.FireflyConfig{
-		ID:                state.ID.ValueString(),
-		Name:              plan.Name.ValueString(),
-		SubCAProviderId:   plan.SubCAProvider.ValueString(),
-		PolicyIds:         policies,
-		ServiceAccountIds: sa,
-		MinTLSVersion:     "TLS13",
-		/*
-			ClientAuthentication: tlspc.ClientAuthentication{
-				Type: "None",
-			},
-		*/
+		ID:                   state.ID.ValueString(),
+		Name:                 plan.Name.ValueString(),
+		SubCAProviderId:      plan.SubCAProvider.ValueString(),
+		PolicyIds:            policies,
+		ServiceAccountIds:    sa,
+		MinTLSVersion:        plan.MinTLSVersion.ValueString(),
+		ClientAuthentication: coerceClientAuthentication(plan.ClientAuthentication),
+		CloudProviders:       coerceCloudProviders(plan.CloudProviders),
+		AdvancedSettings:     coerceAdvancedSettings(plan.AdvancedSettings),
+	}
+	// Fields left out of an update are kept, so removed client
+	// authentication has to be cleared explicitly.
+	if ff.ClientAuthentication == nil && state.ClientAuthentication != nil {
+		ff.ClientAuthentication = &tlspc.ClientAuthentication{Type: tlspc.ClientAuthenticationNone}
 	}
 
 	updated, err := r.client.UpdateFireflyConfig(ff)
@@ -233,6 +670,8 @@ func (r *fireflyConfigResource) Update(ctx context.Context, req resource.UpdateR
 }
 
 func (r *fireflyConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state fireflyConfigResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -0,0 +1,176 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &fireflyPolicyDataSource{}
+	_ datasource.DataSourceWithConfigure = &fireflyPolicyDataSource{}
+)
+
+// NewFireflyPolicyDataSource is a helper function to simplify the provider implementation.
+func NewFireflyPolicyDataSource() datasource.DataSource {
+	return &fireflyPolicyDataSource{}
+}
+
+// fireflyPolicyDataSource is the data source implementation.
+type fireflyPolicyDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *fireflyPolicyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *fireflyPolicyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firefly_policy"
+}
+
+// Schema defines the schema for the data source.
+func (d *fireflyPolicyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	policyAttr := schema.SingleNestedAttribute{
+		Computed: true,
+		Attributes: map[string]schema.Attribute{
+			"allowed_values": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"default_values": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"max_occurrences": schema.Int32Attribute{
+				Computed: true,
+			},
+			"min_occurrences": schema.Int32Attribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up a Firefly Policy by name and return its definition.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the Firefly Policy",
+			},
+			"extended_key_usages": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of Extended Key usages",
+			},
+			"key_usages": schema.SetAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of Key usages",
+			},
+			"validity_period": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Validity Period in ISO8601 Period Format. e.g. P30D",
+			},
+			"key_algorithm": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"allowed_values": schema.SetAttribute{
+						Computed:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "A list of allowed Key Algorithms",
+					},
+					"default_value": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Default key algorithm",
+					},
+				},
+			},
+			"sans": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Policy for Subject Alternative Names",
+				Attributes: map[string]schema.Attribute{
+					"dns_names":    policyAttr,
+					"ip_addresses": policyAttr,
+					"rfc822_names": policyAttr,
+					"uris":         policyAttr,
+				},
+			},
+			"subject": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Policy for Subject",
+				Attributes: map[string]schema.Attribute{
+					"common_name":         policyAttr,
+					"country":             policyAttr,
+					"locality":            policyAttr,
+					"organization":        policyAttr,
+					"organizational_unit": policyAttr,
+					"state_or_province":   policyAttr,
+				},
+			},
+			"policy_json": schema.StringAttribute{
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "The policy as an API policy document in JSON, without its `id` and `name`, e.g. to copy into the `policy_json` of a `tlspc_firefly_policy`",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *fireflyPolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model fireflyPolicyResourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ff, err := d.client.GetFireflyPolicyByName(model.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Firefly Policy",
+			fmt.Sprintf("Error retrieving Firefly Policy: %s", err.Error()),
+		)
+		return
+	}
+
+	model = coerceFireflyPolicyModel(*ff)
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -5,26 +5,65 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+var fireflyKeyAlgorithms = []string{
+	"RSA_2048", "RSA_3072", "RSA_4096", "EC_P256", "EC_P384", "EC_P521", "EC_ED25519",
+}
+
+var fireflyExtendedKeyUsages = []string{
+	"ANY", "SERVER_AUTH", "CLIENT_AUTH", "CODE_SIGNING", "EMAIL_PROTECTION", "IPSEC_ENDSYSTEM",
+	"IPSEC_TUNNEL", "IPSEC_USER", "TIME_STAMPING", "OCSP_SIGNING", "DVCS", "SBGP_CERT_AA_SERVER_AUTH",
+	"SCVP_RESPONDER", "EAP_OVER_PPP", "EAP_OVER_LAN", "SCVP_SERVER", "SCVP_CLIENT", "IPSEC_IKE",
+	"CAPWAP_AC", "CAPWAP_WTP", "IPSEC_IKE_INTERMEDIATE", "SMARTCARD_LOGON",
+}
+
+var fireflyKeyUsages = []string{
+	"digitalSignature", "nonRepudiation", "keyEncipherment", "dataEncipherment", "keyAgreement",
+	"keyCertSign", "cRLSign", "encipherOnly", "decipherOnly",
+}
+
 var (
-	_ resource.Resource                = &fireflyPolicyResource{}
-	_ resource.ResourceWithConfigure   = &fireflyPolicyResource{}
-	_ resource.ResourceWithImportState = &fireflyPolicyResource{}
+	_ resource.Resource                   = &fireflyPolicyResource{}
+	_ resource.ResourceWithConfigure      = &fireflyPolicyResource{}
+	_ resource.ResourceWithImportState    = &fireflyPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &fireflyPolicyResource{}
 )
 
+// fireflyPolicyStructuredAttributes are the attributes which describe a
+// policy when policy_json isn't used.
+var fireflyPolicyStructuredAttributes = []string{
+	"extended_key_usages", "key_usages", "validity_period", "key_algorithm", "sans", "subject",
+}
+
+// fireflyPolicyRequiredAttributes are the structured attributes which must be
+// set when policy_json isn't used.
+var fireflyPolicyRequiredAttributes = []string{
+	"extended_key_usages", "key_usages", "validity_period", "key_algorithm",
+}
+
 type fireflyPolicyResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewFireflyPolicyResource() resource.Resource {
@@ -57,6 +96,9 @@ func (r *fireflyPolicyResource) Schema(_ context.Context, _ resource.SchemaReque
 			},
 			"type": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("IGNORED", "FORBIDDEN", "OPTIONAL", "REQUIRED"),
+				},
 				MarkdownDescription: `The type of this constraint, valid options include:
 	* IGNORED
 	* FORBIDDEN
@@ -81,12 +123,15 @@ func (r *fireflyPolicyResource) Schema(_ context.Context, _ resource.SchemaReque
 				MarkdownDescription: "The name of the Firefly Policy",
 			},
 			"extended_key_usages": schema.SetAttribute{
-				Required:    true,
+				Optional:    true,
 				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(fireflyExtendedKeyUsages...)),
+				},
 				MarkdownDescription: `List of Extended Key usages, valid options include:
 	* ANY
 	* SERVER_AUTH
-	* CLIENT~_AUTH
+	* CLIENT_AUTH
 	* CODE_SIGNING
 	* EMAIL_PROTECTION
 	* IPSEC_ENDSYSTEM
@@ -109,8 +154,11 @@ func (r *fireflyPolicyResource) Schema(_ context.Context, _ resource.SchemaReque
 `,
 			},
 			"key_usages": schema.SetAttribute{
-				Required:    true,
+				Optional:    true,
 				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(fireflyKeyUsages...)),
+				},
 				MarkdownDescription: `List of Key usages, valid options include:
 	* digitalSignature
 	* nonRepudiation
@@ -124,15 +172,21 @@ func (r *fireflyPolicyResource) Schema(_ context.Context, _ resource.SchemaReque
 `,
 			},
 			"validity_period": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				MarkdownDescription: "Validity Period in ISO8601 Period Format. e.g. P30D",
+				Validators: []validator.String{
+					validators.ISO8601Period(),
+				},
 			},
 			"key_algorithm": schema.SingleNestedAttribute{
-				Required: true,
+				Optional: true,
 				Attributes: map[string]schema.Attribute{
 					"allowed_values": schema.SetAttribute{
 						Required:    true,
 						ElementType: types.StringType,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(stringvalidator.OneOf(fireflyKeyAlgorithms...)),
+						},
 						MarkdownDescription: `A list of allowed Key Algorithm. Valid options include:
 	* RSA_2048
 	* RSA_3072
@@ -146,6 +200,9 @@ func (r *fireflyPolicyResource) Schema(_ context.Context, _ resource.SchemaReque
 					"default_value": schema.StringAttribute{
 						Required:            true,
 						MarkdownDescription: `Default key algorithm`,
+						Validators: []validator.String{
+							stringvalidator.OneOf(fireflyKeyAlgorithms...),
+						},
 					},
 				},
 			},
@@ -171,21 +228,91 @@ func (r *fireflyPolicyResource) Schema(_ context.Context, _ resource.SchemaReque
 					"state_or_province":   policyAttr,
 				},
 			},
+			"policy_json": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "The policy as an API policy document in JSON, without its `id` and `name`, e.g. to capture a policy authored in the UI verbatim. If set, none of `extended_key_usages`, `key_usages`, `validity_period`, `key_algorithm`, `sans` and `subject` may be set. If unset, it's computed from them",
+			},
 		},
 	}
 }
 
+// ValidateConfig checks that the policy is described by either policy_json or
+// the structured attributes, but not both.
+func (r *fireflyPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var policyJSON jsontypes.Normalized
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("policy_json"), &policyJSON)...)
+	if resp.Diagnostics.HasError() || policyJSON.IsUnknown() {
+		return
+	}
+
+	if !policyJSON.IsNull() {
+		if _, err := parseFireflyPolicyDocument(policyJSON.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("policy_json"),
+				"Invalid Firefly Policy Document",
+				err.Error(),
+			)
+		}
+		for _, name := range fireflyPolicyStructuredAttributes {
+			v, diags := fireflyPolicyConfigValue(ctx, req.Config, name)
+			resp.Diagnostics.Append(diags...)
+			if v != nil && !v.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Invalid Firefly Policy",
+					fmt.Sprintf("%s must not be set when policy_json is set", name),
+				)
+			}
+		}
+		return
+	}
+
+	for _, name := range fireflyPolicyRequiredAttributes {
+		v, diags := fireflyPolicyConfigValue(ctx, req.Config, name)
+		resp.Diagnostics.Append(diags...)
+		if v != nil && v.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid Firefly Policy",
+				fmt.Sprintf("%s must be set unless policy_json is set", name),
+			)
+		}
+	}
+}
+
+// fireflyPolicyConfigValue returns the configured value of one of the
+// structured attributes.
+func fireflyPolicyConfigValue(ctx context.Context, config tfsdk.Config, name string) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	switch name {
+	case "extended_key_usages", "key_usages":
+		var v types.Set
+		diags = config.GetAttribute(ctx, path.Root(name), &v)
+		return v, diags
+	case "validity_period":
+		var v types.String
+		diags = config.GetAttribute(ctx, path.Root(name), &v)
+		return v, diags
+	default:
+		var v types.Object
+		diags = config.GetAttribute(ctx, path.Root(name), &v)
+		return v, diags
+	}
+}
+
 func (r *fireflyPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -195,14 +322,21 @@ func (r *fireflyPolicyResource) Configure(_ context.Context, req resource.Config
 }
 
 type fireflyPolicyResourceModel struct {
-	ID                types.String      `tfsdk:"id"`
-	Name              types.String      `tfsdk:"name"`
-	ExtendedKeyUsages []types.String    `tfsdk:"extended_key_usages"`
-	KeyUsages         []types.String    `tfsdk:"key_usages"`
-	ValidityPeriod    types.String      `tfsdk:"validity_period"`
-	KeyAlgorithm      keyAlgorithmModel `tfsdk:"key_algorithm"`
-	SANs              sansModel         `tfsdk:"sans"`
-	Subject           subjectModel      `tfsdk:"subject"`
+	ID                types.String         `tfsdk:"id"`
+	Name              types.String         `tfsdk:"name"`
+	ExtendedKeyUsages []types.String       `tfsdk:"extended_key_usages"`
+	KeyUsages         []types.String       `tfsdk:"key_usages"`
+	ValidityPeriod    types.String         `tfsdk:"validity_period"`
+	KeyAlgorithm      *keyAlgorithmModel   `tfsdk:"key_algorithm"`
+	SANs              *sansModel           `tfsdk:"sans"`
+	Subject           *subjectModel        `tfsdk:"subject"`
+	PolicyJSON        jsontypes.Normalized `tfsdk:"policy_json"`
+}
+
+// usesPolicyJSON reports whether the policy is described by policy_json
+// rather than the structured attributes.
+func (m fireflyPolicyResourceModel) usesPolicyJSON() bool {
+	return !m.PolicyJSON.IsNull() && !m.PolicyJSON.IsUnknown() && m.KeyAlgorithm == nil
 }
 
 type keyAlgorithmModel struct {
@@ -235,6 +369,8 @@ type subjectModel struct {
 }
 
 func (r *fireflyPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan fireflyPolicyResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -242,7 +378,11 @@ func (r *fireflyPolicyResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	ff := coercePolicy(plan)
+	ff, err := coercePolicy(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("policy_json"), "Invalid Firefly Policy Document", err.Error())
+		return
+	}
 	created, err := r.client.CreateFireflyPolicy(ff)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -252,11 +392,27 @@ func (r *fireflyPolicyResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 	plan.ID = types.StringValue(created.ID)
+	if !plan.usesPolicyJSON() {
+		plan.PolicyJSON, err = fireflyPolicyDocument(ff)
+		if err != nil {
+			resp.Diagnostics.AddError("Error encoding Firefly Policy", err.Error())
+			return
+		}
+	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
-func coercePolicy(plan fireflyPolicyResourceModel) tlspc.FireflyPolicy {
+func coercePolicy(plan fireflyPolicyResourceModel) (tlspc.FireflyPolicy, error) {
+	if plan.usesPolicyJSON() {
+		ff, err := parseFireflyPolicyDocument(plan.PolicyJSON.ValueString())
+		if err != nil {
+			return ff, err
+		}
+		ff.Name = plan.Name.ValueString()
+		return ff, nil
+	}
+
 	extKeys := []string{}
 	for _, v := range plan.ExtendedKeyUsages {
 		extKeys = append(extKeys, v.ValueString())
@@ -276,27 +432,105 @@ func coercePolicy(plan fireflyPolicyResourceModel) tlspc.FireflyPolicy {
 		keyUses = append(keyUses, v.ValueString())
 	}
 
-	return tlspc.FireflyPolicy{
+	ff := tlspc.FireflyPolicy{
 		Name:              plan.Name.ValueString(),
 		ExtendedKeyUsages: extKeys,
 		KeyAlgorithm:      keyAlg,
 		KeyUsages:         keyUses,
-		SANs: tlspc.SANs{
+		ValidityPeriod:    plan.ValidityPeriod.ValueString(),
+	}
+
+	// Omitted blocks are left out of the request rather than sent empty.
+	if plan.SANs != nil {
+		ff.SANs = &tlspc.SANs{
 			DNSNames:    coercePolicyDetails(plan.SANs.DNSNames),
 			IPAddresses: coercePolicyDetails(plan.SANs.IPAddresses),
 			RFC822Names: coercePolicyDetails(plan.SANs.RFC822Names),
 			URIs:        coercePolicyDetails(plan.SANs.URIs),
-		},
-		Subject: tlspc.FireflyPolicySubject{
+		}
+	}
+	if plan.Subject != nil {
+		ff.Subject = &tlspc.FireflyPolicySubject{
 			CommonName:         coercePolicyDetails(plan.Subject.CommonName),
 			Country:            coercePolicyDetails(plan.Subject.Country),
 			Locality:           coercePolicyDetails(plan.Subject.Locality),
 			Organization:       coercePolicyDetails(plan.Subject.Organization),
 			OrganizationalUnit: coercePolicyDetails(plan.Subject.OrganizationalUnit),
 			StateOrProvince:    coercePolicyDetails(plan.Subject.StateOrProvince),
-		},
-		ValidityPeriod: plan.ValidityPeriod.ValueString(),
+		}
+	}
+
+	return ff, nil
+}
+
+// parseFireflyPolicyDocument decodes a policy_json document. Fields unknown
+// to the provider are rejected, rather than silently dropped.
+func parseFireflyPolicyDocument(doc string) (tlspc.FireflyPolicy, error) {
+	var ff tlspc.FireflyPolicy
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&ff); err != nil {
+		return ff, fmt.Errorf("Could not decode policy document: %s", err)
+	}
+	ff.ID = ""
+	ff.Name = ""
+	return ff, nil
+}
+
+// fireflyPolicyDocument returns the policy_json document of ff. Empty blocks
+// and lists are normalised so that equivalent policies have the same
+// document.
+func fireflyPolicyDocument(ff tlspc.FireflyPolicy) (jsontypes.Normalized, error) {
+	ff.ID = ""
+	ff.Name = ""
+	ff.ExtendedKeyUsages = nonNilStrings(ff.ExtendedKeyUsages)
+	ff.KeyUsages = nonNilStrings(ff.KeyUsages)
+	ff.KeyAlgorithm.AllowedValues = nonNilStrings(ff.KeyAlgorithm.AllowedValues)
+	if sans := ff.SANs; sans != nil && emptyPolicyDetails(sans.DNSNames, sans.IPAddresses, sans.RFC822Names, sans.URIs) {
+		ff.SANs = nil
+	} else if sans != nil {
+		ff.SANs = &tlspc.SANs{
+			DNSNames:    normalisePolicyDetails(sans.DNSNames),
+			IPAddresses: normalisePolicyDetails(sans.IPAddresses),
+			RFC822Names: normalisePolicyDetails(sans.RFC822Names),
+			URIs:        normalisePolicyDetails(sans.URIs),
+		}
+	}
+	if sub := ff.Subject; sub != nil && emptyPolicyDetails(sub.CommonName, sub.Country, sub.Locality, sub.Organization, sub.OrganizationalUnit, sub.StateOrProvince) {
+		ff.Subject = nil
+	} else if sub != nil {
+		ff.Subject = &tlspc.FireflyPolicySubject{
+			CommonName:         normalisePolicyDetails(sub.CommonName),
+			Country:            normalisePolicyDetails(sub.Country),
+			Locality:           normalisePolicyDetails(sub.Locality),
+			Organization:       normalisePolicyDetails(sub.Organization),
+			OrganizationalUnit: normalisePolicyDetails(sub.OrganizationalUnit),
+			StateOrProvince:    normalisePolicyDetails(sub.StateOrProvince),
+		}
+	}
+
+	doc, err := json.Marshal(struct {
+		tlspc.FireflyPolicy
+		ID   string `json:"id,omitempty"`
+		Name string `json:"name,omitempty"`
+	}{FireflyPolicy: ff})
+	if err != nil {
+		return jsontypes.NewNormalizedNull(), err
 	}
+	return jsontypes.NewNormalizedValue(string(doc)), nil
+}
+
+func normalisePolicyDetails(p tlspc.PolicyDetails) tlspc.PolicyDetails {
+	p.AllowedValues = nonNilStrings(p.AllowedValues)
+	p.DefaultValues = nonNilStrings(p.DefaultValues)
+	return p
+}
+
+func nonNilStrings(in []string) []string {
+	if in == nil {
+		return []string{}
+	}
+	return in
 }
 
 func coercePolicyDetails(p policyModel) tlspc.PolicyDetails {
@@ -326,7 +560,8 @@ func coercePolicyModel(p tlspc.PolicyDetails) policyModel {
 		av = append(av, types.StringValue(v))
 	}
 
-	dv := []types.String{}
+	// default_values is optional, so none is kept null rather than empty.
+	var dv []types.String
 	for _, v := range p.DefaultValues {
 		dv = append(dv, types.StringValue(v))
 	}
@@ -340,63 +575,116 @@ func coercePolicyModel(p tlspc.PolicyDetails) policyModel {
 	}
 }
 
-func (r *fireflyPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state fireflyPolicyResourceModel
-
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+// emptyPolicyDetails reports whether all of details are unset.
+func emptyPolicyDetails(details ...tlspc.PolicyDetails) bool {
+	for _, p := range details {
+		if len(p.AllowedValues) > 0 || len(p.DefaultValues) > 0 || p.MaxOccurrences != 0 || p.MinOccurrences != 0 || p.Type != "" {
+			return false
+		}
 	}
+	return true
+}
 
-	ff, err := r.client.GetFireflyPolicy(state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading FireflyConfig",
-			"Could not read FireflyConfig ID "+state.ID.ValueString()+": "+err.Error(),
-		)
-		return
-	}
+func coerceFireflyPolicyModel(ff tlspc.FireflyPolicy) fireflyPolicyResourceModel {
+	var m fireflyPolicyResourceModel
 
-	state.ID = types.StringValue(ff.ID)
-	state.Name = types.StringValue(ff.Name)
-	state.ValidityPeriod = types.StringValue(ff.ValidityPeriod)
+	m.ID = types.StringValue(ff.ID)
+	m.Name = types.StringValue(ff.Name)
+	m.ValidityPeriod = types.StringValue(ff.ValidityPeriod)
 
 	extKeys := []types.String{}
 	for _, v := range ff.ExtendedKeyUsages {
 		extKeys = append(extKeys, types.StringValue(v))
 	}
-	state.ExtendedKeyUsages = extKeys
+	m.ExtendedKeyUsages = extKeys
 
 	keyUses := []types.String{}
 	for _, v := range ff.KeyUsages {
 		keyUses = append(keyUses, types.StringValue(v))
 	}
-	state.KeyUsages = keyUses
+	m.KeyUsages = keyUses
 
 	allowed := []types.String{}
 	for _, v := range ff.KeyAlgorithm.AllowedValues {
 		allowed = append(allowed, types.StringValue(v))
 	}
-	state.KeyAlgorithm = keyAlgorithmModel{
+	m.KeyAlgorithm = &keyAlgorithmModel{
 		AllowedValues: allowed,
 		DefaultValue:  types.StringValue(ff.KeyAlgorithm.DefaultValue),
 	}
 
-	state.SANs = sansModel{
-		DNSNames:    coercePolicyModel(ff.SANs.DNSNames),
-		IPAddresses: coercePolicyModel(ff.SANs.IPAddresses),
-		RFC822Names: coercePolicyModel(ff.SANs.RFC822Names),
-		URIs:        coercePolicyModel(ff.SANs.URIs),
+	// Blocks which are missing or empty in the API are kept null, matching
+	// configuration which omits them.
+	if sans := ff.SANs; sans != nil && !emptyPolicyDetails(sans.DNSNames, sans.IPAddresses, sans.RFC822Names, sans.URIs) {
+		m.SANs = &sansModel{
+			DNSNames:    coercePolicyModel(ff.SANs.DNSNames),
+			IPAddresses: coercePolicyModel(ff.SANs.IPAddresses),
+			RFC822Names: coercePolicyModel(ff.SANs.RFC822Names),
+			URIs:        coercePolicyModel(ff.SANs.URIs),
+		}
+	}
+
+	if sub := ff.Subject; sub != nil && !emptyPolicyDetails(sub.CommonName, sub.Country, sub.Locality, sub.Organization, sub.OrganizationalUnit, sub.StateOrProvince) {
+		m.Subject = &subjectModel{
+			CommonName:         coercePolicyModel(ff.Subject.CommonName),
+			Country:            coercePolicyModel(ff.Subject.Country),
+			Locality:           coercePolicyModel(ff.Subject.Locality),
+			Organization:       coercePolicyModel(ff.Subject.Organization),
+			OrganizationalUnit: coercePolicyModel(ff.Subject.OrganizationalUnit),
+			StateOrProvince:    coercePolicyModel(ff.Subject.StateOrProvince),
+		}
+	}
+
+	// An unencodable policy leaves policy_json null
+	m.PolicyJSON, _ = fireflyPolicyDocument(ff)
+
+	return m
+}
+
+// coerceFireflyPolicyDocumentModel returns the model of ff described by
+// policy_json, keeping prior's document if it's equivalent.
+func coerceFireflyPolicyDocumentModel(ff tlspc.FireflyPolicy, prior jsontypes.Normalized) fireflyPolicyResourceModel {
+	m := fireflyPolicyResourceModel{
+		ID:   types.StringValue(ff.ID),
+		Name: types.StringValue(ff.Name),
+	}
+	m.PolicyJSON, _ = fireflyPolicyDocument(ff)
+
+	if parsed, err := parseFireflyPolicyDocument(prior.ValueString()); err == nil {
+		if doc, err := fireflyPolicyDocument(parsed); err == nil {
+			if equal, _ := doc.StringSemanticEquals(context.Background(), m.PolicyJSON); equal {
+				m.PolicyJSON = prior
+			}
+		}
+	}
+
+	return m
+}
+
+func (r *fireflyPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state fireflyPolicyResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ff, err := r.client.GetFireflyPolicy(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading FireflyConfig",
+			"Could not read FireflyConfig ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
 	}
 
-	state.Subject = subjectModel{
-		CommonName:         coercePolicyModel(ff.Subject.CommonName),
-		Country:            coercePolicyModel(ff.Subject.Country),
-		Locality:           coercePolicyModel(ff.Subject.Locality),
-		Organization:       coercePolicyModel(ff.Subject.Organization),
-		OrganizationalUnit: coercePolicyModel(ff.Subject.OrganizationalUnit),
-		StateOrProvince:    coercePolicyModel(ff.Subject.StateOrProvince),
+	if state.usesPolicyJSON() {
+		state = coerceFireflyPolicyDocumentModel(*ff, state.PolicyJSON)
+	} else {
+		state = coerceFireflyPolicyModel(*ff)
 	}
 
 	diags = resp.State.Set(ctx, state)
@@ -404,6 +692,8 @@ func (r *fireflyPolicyResource) Read(ctx context.Context, req resource.ReadReque
 }
 
 func (r *fireflyPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan, state fireflyPolicyResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -417,7 +707,11 @@ func (r *fireflyPolicyResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	ff := coercePolicy(plan)
+	ff, err := coercePolicy(plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("policy_json"), "Invalid Firefly Policy Document", err.Error())
+		return
+	}
 	ff.ID = state.ID.ValueString()
 
 	updated, err := r.client.UpdateFireflyPolicy(ff)
@@ -429,11 +723,20 @@ func (r *fireflyPolicyResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 	plan.ID = types.StringValue(updated.ID)
+	if !plan.usesPolicyJSON() {
+		plan.PolicyJSON, err = fireflyPolicyDocument(ff)
+		if err != nil {
+			resp.Diagnostics.AddError("Error encoding Firefly Policy", err.Error())
+			return
+		}
+	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *fireflyPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state fireflyPolicyResourceModel
 
 	diags := req.State.Get(ctx, &state)
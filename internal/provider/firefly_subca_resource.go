@@ -8,23 +8,29 @@ import (
 	"fmt"
 
 	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &fireflySubCAResource{}
-	_ resource.ResourceWithConfigure   = &fireflySubCAResource{}
-	_ resource.ResourceWithImportState = &fireflySubCAResource{}
+	_ resource.Resource                   = &fireflySubCAResource{}
+	_ resource.ResourceWithConfigure      = &fireflySubCAResource{}
+	_ resource.ResourceWithImportState    = &fireflySubCAResource{}
+	_ resource.ResourceWithValidateConfig = &fireflySubCAResource{}
 )
 
 type fireflySubCAResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewFireflySubCAResource() resource.Resource {
@@ -50,16 +56,22 @@ func (r *fireflySubCAResource) Schema(_ context.Context, _ resource.SchemaReques
 				MarkdownDescription: "The name of the Firefly Sub CA Provider",
 			},
 			"ca_type": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The type of Certificate Authority",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "The type of Certificate Authority, or `" + tlspc.FireflySelfSignedCAType + "` for a self-signed Sub CA, e.g. for air-gapped deployments. Changing this forces a new resource to be created",
 			},
 			"ca_account_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The ID of the Certificate Authority Account",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				MarkdownDescription: "The ID of the Certificate Authority Account. Required unless `ca_type` is `" + tlspc.FireflySelfSignedCAType + "`. Changing this forces a new resource to be created",
 			},
 			"ca_product_option_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The ID of the Certificate Authority Product Option",
+				Optional:            true,
+				MarkdownDescription: "The ID of the Certificate Authority Product Option. Required unless `ca_type` is `" + tlspc.FireflySelfSignedCAType + "`",
 			},
 			"common_name": schema.StringAttribute{
 				Required:            true,
@@ -67,6 +79,9 @@ func (r *fireflySubCAResource) Schema(_ context.Context, _ resource.SchemaReques
 			},
 			"key_algorithm": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(fireflyKeyAlgorithms...),
+				},
 				MarkdownDescription: `Key Algorithm. Valid options include:
 	* RSA_2048
 	* RSA_3072
@@ -80,22 +95,89 @@ func (r *fireflySubCAResource) Schema(_ context.Context, _ resource.SchemaReques
 			"validity_period": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Validity Period in ISO8601 Period Format. e.g. P30D",
+				Validators: []validator.String{
+					validators.ISO8601Period(),
+				},
+			},
+			"pkcs11": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Keep the Sub CA's keys in an HSM, accessed with PKCS#11, rather than in memory",
+				Attributes: map[string]schema.Attribute{
+					"allowed_client_libraries": schema.SetAttribute{
+						Required:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "The paths of the PKCS#11 client libraries Firefly may load",
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+						},
+					},
+					"partition_label": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The label of the HSM partition holding the keys",
+					},
+					"partition_serial_number": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "The serial number of the HSM partition, if the label isn't unique",
+					},
+					"pin": schema.StringAttribute{
+						Required:            true,
+						Sensitive:           true,
+						MarkdownDescription: "The PIN used to log in to the partition. It can't be read back from the API, so changes made outside Terraform aren't detected",
+					},
+					"signing_enabled": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(true),
+						MarkdownDescription: "Whether the keys in the HSM are used to sign certificates, defaults to `true`",
+					},
+				},
 			},
 		},
 	}
 }
 
+func (r *fireflySubCAResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var caType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ca_type"), &caType)...)
+	if resp.Diagnostics.HasError() || caType.IsUnknown() || caType.IsNull() {
+		return
+	}
+	selfSigned := caType.ValueString() == tlspc.FireflySelfSignedCAType
+
+	for _, attr := range []string{"ca_account_id", "ca_product_option_id"} {
+		var v types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attr), &v)...)
+		if resp.Diagnostics.HasError() || v.IsUnknown() {
+			continue
+		}
+		if selfSigned && !v.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr),
+				"Invalid Firefly SubCA Provider",
+				fmt.Sprintf("%s must not be set when ca_type is %s", attr, tlspc.FireflySelfSignedCAType),
+			)
+		}
+		if !selfSigned && v.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr),
+				"Invalid Firefly SubCA Provider",
+				fmt.Sprintf("%s must be set unless ca_type is %s", attr, tlspc.FireflySelfSignedCAType),
+			)
+		}
+	}
+}
+
 func (r *fireflySubCAResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -105,17 +187,59 @@ func (r *fireflySubCAResource) Configure(_ context.Context, req resource.Configu
 }
 
 type fireflySubCAResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	CAType            types.String `tfsdk:"ca_type"`
-	CAAccountID       types.String `tfsdk:"ca_account_id"`
-	CAProductOptionID types.String `tfsdk:"ca_product_option_id"`
-	CommonName        types.String `tfsdk:"common_name"`
-	KeyAlgorithm      types.String `tfsdk:"key_algorithm"`
-	ValidityPeriod    types.String `tfsdk:"validity_period"`
+	ID                types.String             `tfsdk:"id"`
+	Name              types.String             `tfsdk:"name"`
+	CAType            types.String             `tfsdk:"ca_type"`
+	CAAccountID       types.String             `tfsdk:"ca_account_id"`
+	CAProductOptionID types.String             `tfsdk:"ca_product_option_id"`
+	CommonName        types.String             `tfsdk:"common_name"`
+	KeyAlgorithm      types.String             `tfsdk:"key_algorithm"`
+	ValidityPeriod    types.String             `tfsdk:"validity_period"`
+	PKCS11            *fireflySubCAPKCS11Model `tfsdk:"pkcs11"`
+}
+
+type fireflySubCAPKCS11Model struct {
+	AllowedClientLibraries []types.String `tfsdk:"allowed_client_libraries"`
+	PartitionLabel         types.String   `tfsdk:"partition_label"`
+	PartitionSerialNumber  types.String   `tfsdk:"partition_serial_number"`
+	PIN                    types.String   `tfsdk:"pin"`
+	SigningEnabled         types.Bool     `tfsdk:"signing_enabled"`
+}
+
+func (m fireflySubCAResourceModel) subCAProvider() tlspc.FireflySubCAProvider {
+	ff := tlspc.FireflySubCAProvider{
+		ID:                m.ID.ValueString(),
+		Name:              m.Name.ValueString(),
+		CAType:            m.CAType.ValueString(),
+		CAAccountID:       m.CAAccountID.ValueString(),
+		CAProductOptionID: m.CAProductOptionID.ValueString(),
+		CommonName:        m.CommonName.ValueString(),
+		KeyAlgorithm:      m.KeyAlgorithm.ValueString(),
+		ValidityPeriod:    m.ValidityPeriod.ValueString(),
+	}
+	if m.PKCS11 != nil {
+		ff.PKCS11 = &tlspc.FireflyPKCS11{
+			AllowedClientLibraries: stringsFromValues(m.PKCS11.AllowedClientLibraries),
+			PartitionLabel:         m.PKCS11.PartitionLabel.ValueString(),
+			PartitionSerialNumber:  m.PKCS11.PartitionSerialNumber.ValueString(),
+			PIN:                    m.PKCS11.PIN.ValueString(),
+			SigningEnabled:         m.PKCS11.SigningEnabled.ValueBool(),
+		}
+	}
+	return ff
+}
+
+// optionalString returns s, or null if it's empty and prior is null.
+func optionalString(s string, prior types.String) types.String {
+	if s == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
 }
 
 func (r *fireflySubCAResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan fireflySubCAResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -123,16 +247,7 @@ func (r *fireflySubCAResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	ff := tlspc.FireflySubCAProvider{
-		Name:              plan.Name.ValueString(),
-		CAType:            plan.CAType.ValueString(),
-		CAAccountID:       plan.CAAccountID.ValueString(),
-		CAProductOptionID: plan.CAProductOptionID.ValueString(),
-		CommonName:        plan.CommonName.ValueString(),
-		KeyAlgorithm:      plan.KeyAlgorithm.ValueString(),
-		ValidityPeriod:    plan.ValidityPeriod.ValueString(),
-	}
-	created, err := r.client.CreateFireflySubCAProvider(ff)
+	created, err := r.client.CreateFireflySubCAProvider(plan.subCAProvider())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating Firefly SubCA Provider",
@@ -146,6 +261,8 @@ func (r *fireflySubCAResource) Create(ctx context.Context, req resource.CreateRe
 }
 
 func (r *fireflySubCAResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state fireflySubCAResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -166,17 +283,37 @@ func (r *fireflySubCAResource) Read(ctx context.Context, req resource.ReadReques
 	state.ID = types.StringValue(ff.ID)
 	state.Name = types.StringValue(ff.Name)
 	state.CAType = types.StringValue(ff.CAType)
-	state.CAAccountID = types.StringValue(ff.CAAccountID)
-	state.CAProductOptionID = types.StringValue(ff.CAProductOptionID)
+	state.CAAccountID = optionalString(ff.CAAccountID, state.CAAccountID)
+	state.CAProductOptionID = optionalString(ff.CAProductOptionID, state.CAProductOptionID)
 	state.CommonName = types.StringValue(ff.CommonName)
 	state.KeyAlgorithm = types.StringValue(ff.KeyAlgorithm)
 	state.ValidityPeriod = types.StringValue(ff.ValidityPeriod)
+	if ff.PKCS11 == nil {
+		state.PKCS11 = nil
+	} else {
+		// The PIN isn't returned, so the one in state is kept
+		pin := types.StringNull()
+		var serialNumber types.String
+		if state.PKCS11 != nil {
+			pin = state.PKCS11.PIN
+			serialNumber = state.PKCS11.PartitionSerialNumber
+		}
+		state.PKCS11 = &fireflySubCAPKCS11Model{
+			AllowedClientLibraries: valuesFromStrings(ff.PKCS11.AllowedClientLibraries),
+			PartitionLabel:         types.StringValue(ff.PKCS11.PartitionLabel),
+			PartitionSerialNumber:  optionalString(ff.PKCS11.PartitionSerialNumber, serialNumber),
+			PIN:                    pin,
+			SigningEnabled:         types.BoolValue(ff.PKCS11.SigningEnabled),
+		}
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *fireflySubCAResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan, state fireflySubCAResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -190,18 +327,8 @@ func (r *fireflySubCAResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	ff := tlspc.FireflySubCAProvider{
-		ID:                state.ID.ValueString(),
-		Name:              plan.Name.ValueString(),
-		CAType:            plan.CAType.ValueString(),
-		CAAccountID:       plan.CAAccountID.ValueString(),
-		CAProductOptionID: plan.CAProductOptionID.ValueString(),
-		CommonName:        plan.CommonName.ValueString(),
-		KeyAlgorithm:      plan.KeyAlgorithm.ValueString(),
-		ValidityPeriod:    plan.ValidityPeriod.ValueString(),
-	}
-
-	updated, err := r.client.UpdateFireflySubCAProvider(ff)
+	plan.ID = state.ID
+	updated, err := r.client.UpdateFireflySubCAProvider(plan.subCAProvider())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating Firefly SubCA Provider",
@@ -215,6 +342,8 @@ func (r *fireflySubCAResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *fireflySubCAResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state fireflySubCAResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -0,0 +1,37 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// idIdentitySchema is the identity schema of resources which are identified
+// by their ID alone.
+var idIdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"id": identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       "The ID of this resource",
+		},
+	},
+}
+
+type idIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// setIDIdentity sets the identity of a resource identified by its ID. The
+// identity is nil when Terraform doesn't support resource identity.
+func setIDIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, idIdentityModel{ID: id})
+}
@@ -0,0 +1,39 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// importStateComposite handles import IDs of the form parentID/childID for
+// association resources. Each part of the ID is saved to the corresponding
+// attribute, and the whole ID is saved to the id attribute.
+func importStateComposite(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, attrs ...string) {
+	parts := strings.Split(req.ID, "/")
+
+	valid := len(parts) == len(attrs)
+	for _, p := range parts {
+		if p == "" {
+			valid = false
+		}
+	}
+	if !valid {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: %s. Got: %q", strings.Join(attrs, "/"), req.ID),
+		)
+		return
+	}
+
+	for i, attr := range attrs {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attr), parts[i])...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &isISO8601PeriodFunction{}
+
+type isISO8601PeriodFunction struct{}
+
+func NewIsISO8601PeriodFunction() function.Function {
+	return &isISO8601PeriodFunction{}
+}
+
+func (f *isISO8601PeriodFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_iso8601_period"
+}
+
+func (f *isISO8601PeriodFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Check whether a string is an ISO8601 period",
+		MarkdownDescription: "Returns true if the given string is a valid ISO8601 period, such as those used for `validity_period` (e.g. `P30D` or `PT8H`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "period",
+				MarkdownDescription: "The period to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isISO8601PeriodFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var period string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &period))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, validators.IsISO8601Period(period)))
+}
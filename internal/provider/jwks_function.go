@@ -0,0 +1,141 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &jwksFunction{}
+
+type jwksFunction struct{}
+
+func NewJWKSFunction() function.Function {
+	return &jwksFunction{}
+}
+
+func (f *jwksFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "jwks"
+}
+
+func (f *jwksFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Render a JSON Web Key Set from PEM encoded public keys",
+		MarkdownDescription: "Returns a JSON Web Key Set containing the given PEM encoded RSA or EC public keys, each identified by its RFC 7638 thumbprint. Served from the `jwks_uri` of a `tlspc_service_account_wif`, it lets the keys workloads sign tokens with be rotated without downtime, by serving both the old and new keys until every workload uses the new one.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "public_keys",
+				ElementType:         types.StringType,
+				MarkdownDescription: "The PEM encoded public keys",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// jwk is a JSON Web Key. Its members are in the order of RFC 7638 so that
+// the thumbprint can be computed by marshalling it with only the required
+// members set.
+type jwk struct {
+	Crv string `json:"crv,omitempty"`
+	E   string `json:"e,omitempty"`
+	Kty string `json:"kty"`
+	N   string `json:"n,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+
+	Alg string `json:"alg,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+}
+
+// newJWK returns the JSON Web Key of a PEM encoded public key.
+func newJWK(s string) (*jwk, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := base64.RawURLEncoding.EncodeToString
+	var key jwk
+	var alg string
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		key = jwk{Kty: "RSA", N: enc(k.N.Bytes()), E: enc(big.NewInt(int64(k.E)).Bytes())}
+		alg = "RS256"
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		pt, err := k.ECDH()
+		if err != nil {
+			return nil, err
+		}
+		raw := pt.Bytes()[1:]
+		key = jwk{Crv: k.Curve.Params().Name, Kty: "EC", X: enc(raw[:size]), Y: enc(raw[size:])}
+		switch k.Curve {
+		case elliptic.P256():
+			alg = "ES256"
+		case elliptic.P384():
+			alg = "ES384"
+		case elliptic.P521():
+			alg = "ES512"
+		}
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+
+	thumbprint, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(thumbprint)
+	key.Alg = alg
+	key.Kid = enc(sum[:])
+	key.Use = "sig"
+	return &key, nil
+}
+
+func (f *jwksFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var publicKeys []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &publicKeys))
+	if resp.Error != nil {
+		return
+	}
+
+	keys := []*jwk{}
+	for i, pk := range publicKeys {
+		key, err := newJWK(pk)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Could not read public key %d: %s", i, err))
+			return
+		}
+		keys = append(keys, key)
+	}
+
+	out, err := json.Marshal(map[string]any{"keys": keys})
+	if err != nil {
+		resp.Error = function.NewFuncError("Could not render JWKS: " + err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(out)))
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The example key of RFC 7638 section 3.1, and its thumbprint.
+const (
+	rfc7638N   = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	rfc7638E   = "AQAB"
+	rfc7638Kid = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+)
+
+// runJWKS calls provider::tlspc::jwks with publicKeys.
+func runJWKS(t *testing.T, publicKeys ...string) ([]jwk, *function.FuncError) {
+	t.Helper()
+
+	ctx := context.Background()
+	values := []attr.Value{}
+	for _, pk := range publicKeys {
+		values = append(values, types.StringValue(pk))
+	}
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.ListValueMust(types.StringType, values)}),
+	}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewJWKSFunction().Run(ctx, req, &resp)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(resp.Result.Value().(types.String).ValueString()), &set); err != nil {
+		t.Fatalf("unexpected result: %s", err)
+	}
+	return set.Keys, nil
+}
+
+func publicKeyPEM(t *testing.T, pub any) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestJWKSRFC7638Thumbprint(t *testing.T) {
+	n, err := base64.RawURLEncoding.DecodeString(rfc7638N)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}
+
+	keys, ferr := runJWKS(t, publicKeyPEM(t, pub))
+	if ferr != nil {
+		t.Fatalf("unexpected error: %s", ferr)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("expected 1 key, got %d", len(keys))
+	}
+	if k := keys[0]; k.Kid != rfc7638Kid || k.N != rfc7638N || k.E != rfc7638E || k.Alg != "RS256" {
+		t.Errorf("unexpected key %+v", k)
+	}
+}
+
+func TestJWKSECKeys(t *testing.T) {
+	cases := []struct {
+		curve elliptic.Curve
+		size  int
+		alg   string
+	}{
+		{curve: elliptic.P256(), size: 32, alg: "ES256"},
+		{curve: elliptic.P521(), size: 66, alg: "ES512"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.curve.Params().Name, func(t *testing.T) {
+			priv, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			keys, ferr := runJWKS(t, publicKeyPEM(t, &priv.PublicKey))
+			if ferr != nil {
+				t.Fatalf("unexpected error: %s", ferr)
+			}
+			k := keys[0]
+
+			// The coordinates are padded to the size of the curve.
+			enc := base64.RawURLEncoding.EncodeToString
+			x := enc(priv.X.FillBytes(make([]byte, tc.size)))
+			y := enc(priv.Y.FillBytes(make([]byte, tc.size)))
+			if k.X != x || k.Y != y {
+				t.Errorf("expected coordinates %s, %s, got %s, %s", x, y, k.X, k.Y)
+			}
+
+			canonical := fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, tc.curve.Params().Name, x, y)
+			sum := sha256.Sum256([]byte(canonical))
+			if want := enc(sum[:]); k.Kid != want {
+				t.Errorf("expected kid %s, got %s", want, k.Kid)
+			}
+			if k.Crv != tc.curve.Params().Name || k.Kty != "EC" || k.Alg != tc.alg || k.Use != "sig" {
+				t.Errorf("unexpected key %+v", k)
+			}
+		})
+	}
+}
+
+func TestJWKSInvalidKey(t *testing.T) {
+	for _, pk := range []string{"not a key", "-----BEGIN PUBLIC KEY-----\nAAAA\n-----END PUBLIC KEY-----\n"} {
+		_, err := runJWKS(t, pk)
+		if err == nil {
+			t.Fatalf("expected an error for %q", pk)
+		}
+		if err.FunctionArgument == nil || *err.FunctionArgument != 0 {
+			t.Errorf("expected an error for argument 0, got %s", err)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &kubernetesAgentResource{}
+	_ resource.ResourceWithConfigure   = &kubernetesAgentResource{}
+	_ resource.ResourceWithImportState = &kubernetesAgentResource{}
+	_ resource.ResourceWithIdentity    = &kubernetesAgentResource{}
+)
+
+const (
+	// kubernetesAgentScope is the scope of the service accounts used by the
+	// Kubernetes discovery agent.
+	kubernetesAgentScope = "kubernetes-discovery"
+	// kubernetesAgentSecretKey is the key of the private key in the agent's
+	// credentials secret.
+	kubernetesAgentSecretKey = "privatekey.pem"
+
+	defaultKubernetesAgentSecretName         = "agent-credentials"
+	defaultKubernetesAgentCredentialLifetime = 365
+)
+
+type kubernetesAgentResource struct {
+	client tlspc.API
+}
+
+func NewKubernetesAgentResource() resource.Resource {
+	return &kubernetesAgentResource{}
+}
+
+func (r *kubernetesAgentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_agent"
+}
+
+func (r *kubernetesAgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Register an instance of the Kubernetes discovery agent, creating a service account for it with the `" + kubernetesAgentScope + "` scope, and get the values for the venafi-kubernetes-agent Helm chart. Existing registrations can be imported using the service account ID, though `cluster_description` and `secret_name` can't be read back.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource, which is the client ID of the agent's service account",
+			},
+			"cluster_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the cluster the agent runs in, which is also used as the name of its service account",
+			},
+			"cluster_description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A description of the cluster",
+			},
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the team that owns the agent's service account",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The PEM encoded public key of the key pair the agent authenticates with. The private key must be stored in the Kubernetes secret `secret_name`, under the key `" + kubernetesAgentSecretKey + "`",
+			},
+			"credential_lifetime": schema.Int32Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int32default.StaticInt32(defaultKubernetesAgentCredentialLifetime),
+				MarkdownDescription: fmt.Sprintf("Credential Lifetime in days, defaults to %d", defaultKubernetesAgentCredentialLifetime),
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"secret_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultKubernetesAgentSecretName),
+				MarkdownDescription: "The name of the Kubernetes secret holding the agent's private key, defaults to `" + defaultKubernetesAgentSecretName + "`",
+			},
+			"helm_values": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The values to install the venafi-kubernetes-agent Helm chart with, as JSON (which is also YAML), e.g. for the `values` of a `helm_release`",
+			},
+			"config_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The SHA-256 hash of `helm_values`, e.g. for a pod annotation which restarts the agent when its configuration changes",
+			},
+		},
+	}
+}
+
+func (r *kubernetesAgentResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
+func (r *kubernetesAgentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type kubernetesAgentResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ClusterName        types.String `tfsdk:"cluster_name"`
+	ClusterDescription types.String `tfsdk:"cluster_description"`
+	Owner              types.String `tfsdk:"owner"`
+	PublicKey          types.String `tfsdk:"public_key"`
+	CredentialLifetime types.Int32  `tfsdk:"credential_lifetime"`
+	SecretName         types.String `tfsdk:"secret_name"`
+	HelmValues         types.String `tfsdk:"helm_values"`
+	ConfigHash         types.String `tfsdk:"config_hash"`
+}
+
+func (m kubernetesAgentResourceModel) serviceAccount() tlspc.ServiceAccount {
+	return tlspc.ServiceAccount{
+		ID:                 m.ID.ValueString(),
+		Name:               m.ClusterName.ValueString(),
+		Owner:              m.Owner.ValueString(),
+		Scopes:             []string{kubernetesAgentScope},
+		PublicKey:          m.PublicKey.ValueString(),
+		CredentialLifetime: m.CredentialLifetime.ValueInt32(),
+		AuthenticationType: "rsaKey",
+	}
+}
+
+// setHelmValues sets helm_values and config_hash from the rest of the model,
+// for an agent using server.
+func (m *kubernetesAgentResourceModel) setHelmValues(server string) error {
+	config := map[string]string{
+		"clientId":    m.ID.ValueString(),
+		"clusterName": m.ClusterName.ValueString(),
+		"server":      server,
+	}
+	if !m.ClusterDescription.IsNull() {
+		config["clusterDescription"] = m.ClusterDescription.ValueString()
+	}
+	values, err := json.Marshal(map[string]any{
+		"config": config,
+		"authentication": map[string]string{
+			"secretName": m.SecretName.ValueString(),
+			"secretKey":  kubernetesAgentSecretKey,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(values)
+	m.HelmValues = types.StringValue(string(values))
+	m.ConfigHash = types.StringValue(hex.EncodeToString(sum[:]))
+	return nil
+}
+
+func (r *kubernetesAgentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan kubernetesAgentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateServiceAccount(plan.serviceAccount())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Kubernetes Agent",
+			"Could not create service account, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+	if err := plan.setHelmValues(r.client.Endpoint()); err != nil {
+		resp.Diagnostics.AddError("Error creating Kubernetes Agent", "Could not encode Helm values: "+err.Error())
+		return
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *kubernetesAgentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state kubernetesAgentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sa, err := r.client.GetServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Kubernetes Agent",
+			"Could not read service account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(sa.ID)
+	state.ClusterName = types.StringValue(sa.Name)
+	state.Owner = types.StringValue(sa.Owner)
+	state.PublicKey = types.StringValue(sa.PublicKey)
+	state.CredentialLifetime = types.Int32Value(sa.CredentialLifetime)
+	if state.SecretName.IsNull() {
+		state.SecretName = types.StringValue(defaultKubernetesAgentSecretName)
+	}
+	if err := state.setHelmValues(r.client.Endpoint()); err != nil {
+		resp.Diagnostics.AddError("Error Reading Kubernetes Agent", "Could not encode Helm values: "+err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+}
+
+func (r *kubernetesAgentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state kubernetesAgentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	err := r.client.UpdateServiceAccount(plan.serviceAccount())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Kubernetes Agent",
+			"Could not update service account, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if err := plan.setHelmValues(r.client.Endpoint()); err != nil {
+		resp.Diagnostics.AddError("Error updating Kubernetes Agent", "Could not encode Helm values: "+err.Error())
+		return
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *kubernetesAgentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state kubernetesAgentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Kubernetes Agent",
+			"Could not delete service account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *kubernetesAgentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &machineDataSource{}
+	_ datasource.DataSourceWithConfigure = &machineDataSource{}
+)
+
+// NewMachineDataSource is a helper function to simplify the provider implementation.
+func NewMachineDataSource() datasource.DataSource {
+	return &machineDataSource{}
+}
+
+// machineDataSource is the data source implementation.
+type machineDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *machineDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *machineDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_machine"
+}
+
+// Schema defines the schema for the data source.
+func (d *machineDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up a machine by name",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the machine",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the machine",
+			},
+			"machine_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of machine",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the machine",
+			},
+			"plugin_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the plugin used to connect to the machine",
+			},
+			"vsatellite_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the VSatellite which connects to the machine",
+			},
+			"owner": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the team that owns the machine",
+			},
+		},
+	}
+}
+
+type machineDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	MachineType  types.String `tfsdk:"machine_type"`
+	Status       types.String `tfsdk:"status"`
+	PluginID     types.String `tfsdk:"plugin_id"`
+	VSatelliteID types.String `tfsdk:"vsatellite_id"`
+	Owner        types.String `tfsdk:"owner"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *machineDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model machineDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := d.client.GetMachineByName(model.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Machine",
+			fmt.Sprintf("Error retrieving Machine: %s", err.Error()),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(m.ID)
+	model.MachineType = types.StringValue(m.MachineType)
+	model.Status = types.StringValue(m.Status)
+	model.PluginID = types.StringValue(m.PluginID)
+	model.VSatelliteID = types.StringValue(m.VSatelliteID)
+	model.Owner = types.StringValue(m.Owner)
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
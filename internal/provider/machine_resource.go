@@ -0,0 +1,284 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &machineResource{}
+	_ resource.ResourceWithConfigure   = &machineResource{}
+	_ resource.ResourceWithImportState = &machineResource{}
+)
+
+type machineResource struct {
+	client tlspc.API
+}
+
+func NewMachineResource() resource.Resource {
+	return &machineResource{}
+}
+
+func (r *machineResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_machine"
+}
+
+func (r *machineResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manage a machine, a target such as an F5 BIG-IP or Citrix ADC that certificates are installed on through a VSatellite.
+
+The connection details aren't returned by the API, so changes made to them outside of Terraform aren't detected.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the machine",
+			},
+			"plugin_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the `MACHINE` plugin used to connect to the machine. Changing this forces a new resource to be created",
+			},
+			"vsatellite_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the VSatellite which connects to the machine",
+			},
+			"owner": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the team that owns the machine",
+			},
+			"connection_details": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "JSON string of the connection details, as defined by the domain schema of the plugin",
+			},
+			"machine_type": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The type of machine, from the plugin",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the machine",
+			},
+		},
+	}
+}
+
+func (r *machineResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type machineResourceModel struct {
+	ID                types.String         `tfsdk:"id"`
+	Name              types.String         `tfsdk:"name"`
+	PluginID          types.String         `tfsdk:"plugin_id"`
+	VSatelliteID      types.String         `tfsdk:"vsatellite_id"`
+	Owner             types.String         `tfsdk:"owner"`
+	ConnectionDetails jsontypes.Normalized `tfsdk:"connection_details"`
+	MachineType       types.String         `tfsdk:"machine_type"`
+	Status            types.String         `tfsdk:"status"`
+}
+
+func (m machineResourceModel) machine() (tlspc.Machine, error) {
+	var details any
+	err := json.Unmarshal([]byte(m.ConnectionDetails.ValueString()), &details)
+	if err != nil {
+		return tlspc.Machine{}, err
+	}
+
+	return tlspc.Machine{
+		ID:                m.ID.ValueString(),
+		Name:              m.Name.ValueString(),
+		PluginID:          m.PluginID.ValueString(),
+		VSatelliteID:      m.VSatelliteID.ValueString(),
+		Owner:             m.Owner.ValueString(),
+		ConnectionDetails: details,
+	}, nil
+}
+
+func (r *machineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan machineResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := plan.machine()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Machine",
+			"Could not create Machine, invalid connection details: "+err.Error(),
+		)
+		return
+	}
+
+	created, err := r.client.CreateMachine(m)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Machine",
+			"Could not create Machine, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+	plan.MachineType = types.StringValue(created.MachineType)
+	plan.Status = types.StringValue(created.Status)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *machineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state machineResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := r.client.GetMachine(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Machine",
+			"Could not read Machine ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(m.ID)
+	state.Name = types.StringValue(m.Name)
+	state.PluginID = types.StringValue(m.PluginID)
+	state.VSatelliteID = types.StringValue(m.VSatelliteID)
+	state.Owner = types.StringValue(m.Owner)
+	state.MachineType = types.StringValue(m.MachineType)
+	state.Status = types.StringValue(m.Status)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *machineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state machineResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, err := plan.machine()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Machine",
+			"Could not update Machine, invalid connection details: "+err.Error(),
+		)
+		return
+	}
+	m.ID = state.ID.ValueString()
+
+	updated, err := r.client.UpdateMachine(m)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Machine",
+			"Could not update Machine, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = state.ID
+	plan.MachineType = types.StringValue(updated.MachineType)
+	plan.Status = types.StringValue(updated.Status)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *machineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state machineResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteMachine(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Machine",
+			"Could not delete Machine ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *machineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
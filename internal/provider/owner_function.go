@@ -0,0 +1,69 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ownerFunction{}
+
+// ownerFunction builds an entry for the owners attribute of tlspc_application.
+type ownerFunction struct {
+	ownerType string
+}
+
+func NewTeamOwnerFunction() function.Function {
+	return &ownerFunction{ownerType: "TEAM"}
+}
+
+func NewUserOwnerFunction() function.Function {
+	return &ownerFunction{ownerType: "USER"}
+}
+
+func (f *ownerFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = strings.ToLower(f.ownerType) + "_owner"
+}
+
+func (f *ownerFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	kind := strings.ToLower(f.ownerType)
+	resp.Definition = function.Definition{
+		Summary:             "Build a " + kind + " owner for an application",
+		MarkdownDescription: "Returns an owner entry for the `owners` attribute of `tlspc_application`, with `type` set to `" + f.ownerType + "`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "The ID of the " + kind,
+				Validators: []function.StringParameterValidator{
+					validators.Uuid(),
+				},
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ownerFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	owner := map[string]string{
+		"type": f.ownerType,
+		"id":   id,
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, owner))
+}
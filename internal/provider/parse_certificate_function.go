@@ -0,0 +1,140 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &parseCertificateFunction{}
+
+var parsedCertificateAttrTypes = map[string]attr.Type{
+	"common_name":     types.StringType,
+	"dns_names":       types.ListType{ElemType: types.StringType},
+	"ip_addresses":    types.ListType{ElemType: types.StringType},
+	"uris":            types.ListType{ElemType: types.StringType},
+	"email_addresses": types.ListType{ElemType: types.StringType},
+	"issuer":          types.StringType,
+	"serial_number":   types.StringType,
+	"not_before":      types.StringType,
+	"not_after":       types.StringType,
+	"key_algorithm":   types.StringType,
+}
+
+type parsedCertificate struct {
+	CommonName     string   `tfsdk:"common_name"`
+	DNSNames       []string `tfsdk:"dns_names"`
+	IPAddresses    []string `tfsdk:"ip_addresses"`
+	URIs           []string `tfsdk:"uris"`
+	EmailAddresses []string `tfsdk:"email_addresses"`
+	Issuer         string   `tfsdk:"issuer"`
+	SerialNumber   string   `tfsdk:"serial_number"`
+	NotBefore      string   `tfsdk:"not_before"`
+	NotAfter       string   `tfsdk:"not_after"`
+	KeyAlgorithm   string   `tfsdk:"key_algorithm"`
+}
+
+// certificateKeyAlgorithm returns the key algorithm of a certificate using the
+// names TLS Protect Cloud uses, e.g. RSA_2048 or EC_P256.
+func certificateKeyAlgorithm(cert *x509.Certificate) string {
+	switch k := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA_%d", k.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "EC_P" + k.Curve.Params().Name[len("P-"):]
+	case ed25519.PublicKey:
+		return "EC_ED25519"
+	}
+	return "UNKNOWN"
+}
+
+// newParsedCertificate returns the attributes of cert.
+func newParsedCertificate(cert *x509.Certificate) parsedCertificate {
+	parsed := parsedCertificate{
+		CommonName:     cert.Subject.CommonName,
+		DNSNames:       []string{},
+		IPAddresses:    []string{},
+		URIs:           []string{},
+		EmailAddresses: []string{},
+		Issuer:         cert.Issuer.String(),
+		SerialNumber:   cert.SerialNumber.Text(16),
+		NotBefore:      cert.NotBefore.UTC().Format(time.RFC3339),
+		NotAfter:       cert.NotAfter.UTC().Format(time.RFC3339),
+		KeyAlgorithm:   certificateKeyAlgorithm(cert),
+	}
+	parsed.DNSNames = append(parsed.DNSNames, cert.DNSNames...)
+	for _, v := range cert.IPAddresses {
+		parsed.IPAddresses = append(parsed.IPAddresses, v.String())
+	}
+	for _, v := range cert.URIs {
+		parsed.URIs = append(parsed.URIs, v.String())
+	}
+	parsed.EmailAddresses = append(parsed.EmailAddresses, cert.EmailAddresses...)
+	return parsed
+}
+
+type parseCertificateFunction struct{}
+
+func NewParseCertificateFunction() function.Function {
+	return &parseCertificateFunction{}
+}
+
+func (f *parseCertificateFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_certificate"
+}
+
+func (f *parseCertificateFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a PEM encoded certificate",
+		MarkdownDescription: `Parses a PEM encoded certificate and returns an object with the following attributes:
+	* common_name
+	* dns_names
+	* ip_addresses
+	* uris
+	* email_addresses
+	* issuer
+	* serial_number
+	* not_before (RFC3339)
+	* not_after (RFC3339)
+	* key_algorithm (e.g. RSA_2048, EC_P256)
+
+If the PEM contains a chain, only the first certificate is used.`,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "pem",
+				MarkdownDescription: "The PEM encoded certificate",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedCertificateAttrTypes,
+		},
+	}
+}
+
+func (f *parseCertificateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var certPEM string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &certPEM))
+	if resp.Error != nil {
+		return
+	}
+
+	cert, err := parseCertificatePEM(certPEM)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Could not parse certificate: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, newParsedCertificate(cert)))
+}
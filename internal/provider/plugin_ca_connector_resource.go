@@ -0,0 +1,469 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// caConnectorManifestKeys are the top level manifest fields rendered from the
+// typed attributes of tlspc_plugin_ca_connector, which can't also be given in
+// extra_manifest.
+var caConnectorManifestKeys = []string{"name", "version", "workTypes", "deployment", "domainSchema", "pluginType"}
+
+var (
+	_ resource.Resource                   = &pluginCAConnectorResource{}
+	_ resource.ResourceWithConfigure      = &pluginCAConnectorResource{}
+	_ resource.ResourceWithImportState    = &pluginCAConnectorResource{}
+	_ resource.ResourceWithValidateConfig = &pluginCAConnectorResource{}
+)
+
+type pluginCAConnectorResource struct {
+	client tlspc.API
+}
+
+func NewPluginCAConnectorResource() resource.Resource {
+	return &pluginCAConnectorResource{}
+}
+
+func (r *pluginCAConnectorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugin_ca_connector"
+}
+
+func (r *pluginCAConnectorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manage a CA connector plugin for a TLS Protect Cloud tenant.
+
+This renders the plugin manifest from its attributes, rather than requiring it to be written as JSON as with ` + "`tlspc_plugin`" + `.
+Fields of the manifest without an attribute can be given in ` + "`extra_manifest`" + `.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the CA connector",
+			},
+			"version": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The version of the CA connector, e.g. `1.0.0`",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(semverRegex, "must be a semantic version, e.g. 1.2.3"),
+				},
+			},
+			"work_types": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The work types the CA connector supports, e.g. `ISSUANCE` and `REVOCATION`",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"image": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The container image of the CA connector, e.g. `org/image:v0.1.0`",
+			},
+			"execution_target": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("vsat"),
+				MarkdownDescription: "Where the CA connector runs, defaults to `vsat`",
+			},
+			"domain_schema": schema.StringAttribute{
+				Required:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "JSON string of the domain schema of the CA connector, which must include a `connection` object",
+			},
+			"extra_manifest": schema.StringAttribute{
+				Optional:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "JSON object of any other fields to include in the manifest",
+			},
+			"manifest": schema.StringAttribute{
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "The rendered plugin manifest",
+			},
+		},
+	}
+}
+
+func (r *pluginCAConnectorResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type pluginCAConnectorResourceModel struct {
+	ID              types.String         `tfsdk:"id"`
+	Name            types.String         `tfsdk:"name"`
+	Version         types.String         `tfsdk:"version"`
+	WorkTypes       []types.String       `tfsdk:"work_types"`
+	Image           types.String         `tfsdk:"image"`
+	ExecutionTarget types.String         `tfsdk:"execution_target"`
+	DomainSchema    jsontypes.Normalized `tfsdk:"domain_schema"`
+	ExtraManifest   jsontypes.Normalized `tfsdk:"extra_manifest"`
+	Manifest        jsontypes.Normalized `tfsdk:"manifest"`
+}
+
+// renderManifest builds the plugin manifest from the model.
+func (m pluginCAConnectorResourceModel) renderManifest() (map[string]any, error) {
+	manifest := map[string]any{}
+	if !m.ExtraManifest.IsNull() {
+		if err := json.Unmarshal([]byte(m.ExtraManifest.ValueString()), &manifest); err != nil {
+			return nil, fmt.Errorf("extra_manifest must be a JSON object: %s", err)
+		}
+	}
+
+	var domainSchema any
+	if err := json.Unmarshal([]byte(m.DomainSchema.ValueString()), &domainSchema); err != nil {
+		return nil, fmt.Errorf("Invalid domain_schema: %s", err)
+	}
+
+	workTypes := stringsFromValues(m.WorkTypes)
+	sort.Strings(workTypes)
+
+	manifest["name"] = m.Name.ValueString()
+	manifest["version"] = m.Version.ValueString()
+	manifest["workTypes"] = workTypes
+	manifest["deployment"] = map[string]any{
+		"executionTarget": m.ExecutionTarget.ValueString(),
+		"image":           m.Image.ValueString(),
+	}
+	manifest["domainSchema"] = domainSchema
+
+	return manifest, nil
+}
+
+// caConnectorManifestAttrs are the attributes which render each top level
+// manifest field.
+var caConnectorManifestAttrs = map[string]string{
+	"name":         "name",
+	"version":      "version",
+	"workTypes":    "work_types",
+	"deployment":   "image",
+	"domainSchema": "domain_schema",
+}
+
+// caConnectorManifestAttr returns the attribute which renders the top level
+// manifest field.
+func caConnectorManifestAttr(field string) path.Path {
+	if attr, ok := caConnectorManifestAttrs[field]; ok {
+		return path.Root(attr)
+	}
+	return path.Root("extra_manifest")
+}
+
+// setManifest sets the model from a manifest returned by the API, so that
+// changes made outside of Terraform show as drift on the typed attributes.
+func (m *pluginCAConnectorResourceModel) setManifest(manifest any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rendered, err := json.Marshal(manifest)
+	if err != nil {
+		diags.AddError("Error Reading Plugin", "Could not read plugin manifest: "+err.Error())
+		return diags
+	}
+	m.Manifest = jsontypes.NewNormalizedValue(string(rendered))
+
+	root, ok := manifest.(map[string]any)
+	if !ok {
+		diags.AddError("Error Reading Plugin", fmt.Sprintf("Plugin manifest must be a JSON object, got %s", manifestKind(manifest)))
+		return diags
+	}
+
+	extra := map[string]any{}
+	for k, v := range root {
+		extra[k] = v
+	}
+	for _, k := range caConnectorManifestKeys {
+		delete(extra, k)
+	}
+
+	str := func(path string) types.String {
+		v, _ := lookupManifestField(root, path)
+		s, _ := v.(string)
+		return types.StringValue(s)
+	}
+	m.Name = str("name")
+	m.Version = str("version")
+	m.Image = str("deployment.image")
+	m.ExecutionTarget = str("deployment.executionTarget")
+
+	workTypes := []types.String{}
+	if v, ok := root["workTypes"].([]any); ok {
+		for _, w := range v {
+			if s, ok := w.(string); ok {
+				workTypes = append(workTypes, types.StringValue(s))
+			}
+		}
+	}
+	m.WorkTypes = workTypes
+
+	domainSchema, err := json.Marshal(root["domainSchema"])
+	if err != nil {
+		diags.AddError("Error Reading Plugin", "Could not read plugin domain schema: "+err.Error())
+		return diags
+	}
+	m.DomainSchema = jsontypes.NewNormalizedValue(string(domainSchema))
+
+	m.ExtraManifest = jsontypes.NewNormalizedNull()
+	if len(extra) > 0 {
+		rendered, err := json.Marshal(extra)
+		if err != nil {
+			diags.AddError("Error Reading Plugin", "Could not read plugin manifest: "+err.Error())
+			return diags
+		}
+		m.ExtraManifest = jsontypes.NewNormalizedValue(string(rendered))
+	}
+
+	return diags
+}
+
+// ValidateConfig checks the rendered manifest, so that mistakes in
+// extra_manifest are reported at plan time rather than by the API.
+func (r *pluginCAConnectorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// work_types may be unknown, so only extra_manifest is read
+	var extraManifest jsontypes.Normalized
+	diags := req.Config.GetAttribute(ctx, path.Root("extra_manifest"), &extraManifest)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if extraManifest.IsUnknown() || extraManifest.IsNull() {
+		return
+	}
+
+	var extra any
+	if err := json.Unmarshal([]byte(extraManifest.ValueString()), &extra); err != nil {
+		// Invalid JSON is reported by the attribute type
+		return
+	}
+	obj, ok := extra.(map[string]any)
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("extra_manifest"),
+			"Invalid Plugin Manifest",
+			fmt.Sprintf("extra_manifest must be a JSON object, got %s", manifestKind(extra)),
+		)
+		return
+	}
+	for _, k := range caConnectorManifestKeys {
+		if _, ok := obj[k]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra_manifest"),
+				"Invalid Plugin Manifest",
+				fmt.Sprintf("%s: is set by another attribute, and can't be given in extra_manifest", k),
+			)
+		}
+	}
+}
+
+func (r *pluginCAConnectorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan pluginCAConnectorResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	manifest, err := plan.renderManifest()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating plugin",
+			"Could not create plugin, invalid manifest: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(checkPluginManifest("CA", manifest, caConnectorManifestAttr)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plugin := tlspc.Plugin{
+		Type:     "CA",
+		Manifest: manifest,
+	}
+
+	created, err := r.client.CreatePlugin(plugin)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating plugin",
+			"Could not create plugin, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+
+	rendered, err := json.Marshal(manifest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating plugin",
+			"Could not render plugin manifest: "+err.Error(),
+		)
+		return
+	}
+	plan.Manifest = jsontypes.NewNormalizedValue(string(rendered))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *pluginCAConnectorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state pluginCAConnectorResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plugin, err := r.client.GetPlugin(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Plugin",
+			"Could not read plugin ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if plugin.Type != "CA" {
+		resp.Diagnostics.AddError(
+			"Error Reading Plugin",
+			fmt.Sprintf("Plugin ID %s is a %s plugin, not a CA connector", state.ID.ValueString(), plugin.Type),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(plugin.ID)
+	resp.Diagnostics.Append(state.setManifest(plugin.Manifest)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *pluginCAConnectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state, plan pluginCAConnectorResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	manifest, err := plan.renderManifest()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Plugin",
+			"Could not update plugin, invalid manifest: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(checkPluginManifest("CA", manifest, caConnectorManifestAttr)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plugin := tlspc.Plugin{
+		ID:       state.ID.ValueString(),
+		Type:     "CA",
+		Manifest: manifest,
+	}
+	err = r.client.UpdatePlugin(plugin)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Plugin",
+			"Could not update plugin: "+err.Error(),
+		)
+		return
+	}
+
+	rendered, err := json.Marshal(manifest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Plugin",
+			"Could not render plugin manifest: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = state.ID
+	plan.Manifest = jsontypes.NewNormalizedValue(string(rendered))
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *pluginCAConnectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state pluginCAConnectorResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeletePlugin(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Plugin",
+			"Could not delete plugin ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *pluginCAConnectorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &pluginDataSource{}
+	_ datasource.DataSourceWithConfigure = &pluginDataSource{}
+)
+
+// NewPluginDataSource is a helper function to simplify the provider implementation.
+func NewPluginDataSource() datasource.DataSource {
+	return &pluginDataSource{}
+}
+
+// pluginDataSource is the data source implementation.
+type pluginDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pluginDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *pluginDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugin"
+}
+
+// Schema defines the schema for the data source.
+func (d *pluginDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up an installed plugin by ID, or by name and optionally type.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Plugin ID, exactly one of `id` or `name` must be set",
+				Validators: []validator.String{
+					validators.Uuid(),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Plugin name, as given in its manifest. Exactly one of `id` or `name` must be set",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Type of plugin, e.g. `CA` or `MACHINE`. When looking up by name, only plugins of this type are considered",
+			},
+			"manifest": schema.StringAttribute{
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "JSON string of the plugin manifest",
+			},
+		},
+	}
+}
+
+type pluginDataSourceModel struct {
+	ID       types.String         `tfsdk:"id"`
+	Name     types.String         `tfsdk:"name"`
+	Type     types.String         `tfsdk:"type"`
+	Manifest jsontypes.Normalized `tfsdk:"manifest"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pluginDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pluginDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plugin *tlspc.Plugin
+	var err error
+	if !state.ID.IsNull() {
+		plugin, err = d.client.GetPlugin(state.ID.ValueString())
+	} else {
+		plugin, err = d.client.GetPluginByName(state.Type.ValueString(), state.Name.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving plugin",
+			fmt.Sprintf("Error retrieving plugin: %s", err.Error()),
+		)
+		return
+	}
+
+	manifest, err := json.Marshal(plugin.Manifest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving plugin",
+			fmt.Sprintf("Could not read plugin manifest: %s", err.Error()),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(plugin.ID)
+	state.Name = types.StringValue(plugin.PluginName())
+	state.Type = types.StringValue(plugin.Type)
+	state.Manifest = jsontypes.NewNormalizedValue(string(manifest))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
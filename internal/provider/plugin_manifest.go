@@ -0,0 +1,158 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+var semverRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// manifestField describes a field which a plugin manifest must contain.
+type manifestField struct {
+	// path is the dotted path to the field, e.g. deployment.image
+	path string
+	// kind is the JSON type of the field: string, object or array
+	kind   string
+	semver bool
+}
+
+// Fields required in every plugin manifest.
+var pluginManifestFields = []manifestField{
+	{path: "name", kind: "string"},
+	{path: "version", kind: "string", semver: true},
+	{path: "workTypes", kind: "array"},
+	{path: "deployment", kind: "object"},
+	{path: "deployment.executionTarget", kind: "string"},
+	{path: "deployment.image", kind: "string"},
+}
+
+// Additional fields required by each type of plugin. CA connectors describe
+// how to connect to the CA, while machine connectors also describe the
+// keystore certificates are installed to and how they're bound to services.
+var pluginManifestTypeFields = map[string][]manifestField{
+	"CA": {
+		{path: "domainSchema", kind: "object"},
+		{path: "domainSchema.connection", kind: "object"},
+	},
+	"MACHINE": {
+		{path: "domainSchema", kind: "object"},
+		{path: "domainSchema.connection", kind: "object"},
+		{path: "domainSchema.keystore", kind: "object"},
+		{path: "domainSchema.binding", kind: "object"},
+	},
+}
+
+// manifestKind returns the JSON type of a decoded value.
+func manifestKind(v any) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
+
+// validatePluginManifest checks a decoded manifest for the fields required by
+// its plugin type, returning a description of each problem found.
+func validatePluginManifest(pluginType string, manifest any) []string {
+	root, ok := manifest.(map[string]any)
+	if !ok {
+		return []string{fmt.Sprintf("manifest must be a JSON object, got %s", manifestKind(manifest))}
+	}
+
+	problems := []string{}
+
+	if t, ok := root["pluginType"]; ok && t != pluginType {
+		problems = append(problems, fmt.Sprintf("pluginType: %v does not match the plugin type %s", t, pluginType))
+	}
+
+	fields := append([]manifestField{}, pluginManifestFields...)
+	fields = append(fields, pluginManifestTypeFields[pluginType]...)
+	for _, f := range fields {
+		v, found := lookupManifestField(root, f.path)
+		if !found {
+			// A missing parent is reported once, not for each of its children.
+			if parent, nested := manifestParent(f.path); !nested || hasManifestField(root, parent) {
+				problems = append(problems, fmt.Sprintf("%s: is required", f.path))
+			}
+			continue
+		}
+		if kind := manifestKind(v); kind != f.kind {
+			problems = append(problems, fmt.Sprintf("%s: must be of type %s, got %s", f.path, f.kind, kind))
+			continue
+		}
+		if f.semver && !semverRegex.MatchString(v.(string)) {
+			problems = append(problems, fmt.Sprintf("%s: %q is not a semantic version, e.g. 1.2.3", f.path, v))
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+// checkPluginManifest validates a manifest before it's sent to the API, as
+// parts of it may have been unknown when planning. Each problem is reported
+// against the attribute attrFor returns for the top level field it's about.
+func checkPluginManifest(pluginType string, manifest any, attrFor func(field string) path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, problem := range validatePluginManifest(pluginType, manifest) {
+		diags.AddAttributeError(
+			attrFor(manifestProblemField(problem)),
+			"Invalid Plugin Manifest",
+			problem,
+		)
+	}
+	return diags
+}
+
+// manifestParent returns the path of the parent of the field at path, and
+// whether it has one.
+func manifestParent(path string) (string, bool) {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return "", false
+	}
+	return path[:i], true
+}
+
+// manifestProblemField returns the top level field a problem returned by
+// validatePluginManifest is about.
+func manifestProblemField(problem string) string {
+	field, _, _ := strings.Cut(problem, ":")
+	field, _, _ = strings.Cut(field, ".")
+	return field
+}
+
+func lookupManifestField(root map[string]any, path string) (any, bool) {
+	var v any = root
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func hasManifestField(root map[string]any, path string) bool {
+	v, ok := lookupManifestField(root, path)
+	return ok && manifestKind(v) == "object"
+}
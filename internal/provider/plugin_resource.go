@@ -5,28 +5,35 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"os"
 
 	"terraform-provider-tlspc/internal/tlspc"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
-	_ resource.Resource                = &pluginResource{}
-	_ resource.ResourceWithConfigure   = &pluginResource{}
-	_ resource.ResourceWithImportState = &pluginResource{}
+	_ resource.Resource                   = &pluginResource{}
+	_ resource.ResourceWithConfigure      = &pluginResource{}
+	_ resource.ResourceWithImportState    = &pluginResource{}
+	_ resource.ResourceWithValidateConfig = &pluginResource{}
+	_ resource.ResourceWithModifyPlan     = &pluginResource{}
 )
 
 type pluginResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewPluginResource() resource.Resource {
@@ -64,9 +71,21 @@ This would additionally need a deployment block to meet the required specificati
 				MarkdownDescription: "Type of plugin, e.g. `CA` or `MACHINE`",
 			},
 			"manifest": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 				CustomType:          jsontypes.NormalizedType{},
-				MarkdownDescription: "JSON string of a plugin manifest",
+				MarkdownDescription: "JSON string of a plugin manifest. The manifest is checked for the fields required by the plugin type when planning, e.g. the `domainSchema` of a `CA` plugin must include a `connection` object, and that of a `MACHINE` plugin `connection`, `keystore` and `binding` objects. Exactly one of `manifest` and `manifest_file` must be set",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("manifest"), path.MatchRoot("manifest_file")),
+				},
+			},
+			"manifest_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to a JSON file of a plugin manifest, relative paths are relative to the working directory. The manifest isn't saved to state, instead changes to it are detected using `manifest_hash`",
+			},
+			"manifest_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 hash of the manifest, ignoring formatting",
 			},
 		},
 	}
@@ -77,12 +96,12 @@ func (r *pluginResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -92,12 +111,123 @@ func (r *pluginResource) Configure(_ context.Context, req resource.ConfigureRequ
 }
 
 type pluginResourceModel struct {
-	ID       types.String         `tfsdk:"id"`
-	Type     types.String         `tfsdk:"type"`
-	Manifest jsontypes.Normalized `tfsdk:"manifest"`
+	ID           types.String         `tfsdk:"id"`
+	Type         types.String         `tfsdk:"type"`
+	Manifest     jsontypes.Normalized `tfsdk:"manifest"`
+	ManifestFile types.String         `tfsdk:"manifest_file"`
+	ManifestHash types.String         `tfsdk:"manifest_hash"`
+}
+
+// manifest returns the decoded manifest, read from manifest_file if it's set.
+func (m pluginResourceModel) manifest() (any, error) {
+	data := []byte(m.Manifest.ValueString())
+	if !m.ManifestFile.IsNull() {
+		var err error
+		data, err = os.ReadFile(m.ManifestFile.ValueString())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var manifest any
+	err := json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %s", err)
+	}
+	return manifest, nil
+}
+
+// manifestAttr returns the attribute the manifest is configured by.
+func (m pluginResourceModel) manifestAttr(string) path.Path {
+	if !m.ManifestFile.IsNull() {
+		return path.Root("manifest_file")
+	}
+	return path.Root("manifest")
+}
+
+// pluginManifestHash returns the hash of manifest. It's hashed once encoded
+// canonically, so that formatting and key order don't change it.
+func pluginManifestHash(manifest any) (string, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func manifestHashValue(manifest any) (types.String, error) {
+	hash, err := pluginManifestHash(manifest)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(hash), nil
+}
+
+// ValidateConfig checks the manifest has the fields required for the plugin
+// type, so that mistakes are reported at plan time rather than by the API.
+func (r *pluginResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config pluginResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Type.IsUnknown() || config.Manifest.IsUnknown() || config.ManifestFile.IsUnknown() {
+		return
+	}
+
+	if config.ManifestFile.IsNull() && config.Manifest.IsNull() {
+		return
+	}
+
+	manifest, err := config.manifest()
+	if err != nil {
+		// Invalid JSON in manifest is reported by the attribute type
+		if !config.ManifestFile.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("manifest_file"), "Invalid Plugin Manifest", err.Error())
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(checkPluginManifest(config.Type.ValueString(), manifest, config.manifestAttr)...)
+}
+
+// ModifyPlan computes the hash of the planned manifest, so that changes to
+// manifest_file are planned.
+func (r *pluginResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan pluginResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.ManifestFile.IsUnknown() {
+		return
+	}
+	if plan.ManifestFile.IsNull() && (plan.Manifest.IsUnknown() || plan.Manifest.IsNull()) {
+		return
+	}
+
+	manifest, err := plan.manifest()
+	if err != nil {
+		// Reported by ValidateConfig
+		return
+	}
+	hash, err := pluginManifestHash(manifest)
+	if err != nil {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("manifest_hash"), hash)...)
+	if !plan.ManifestFile.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("manifest"), jsontypes.NewNormalizedNull())...)
+	}
 }
 
 func (r *pluginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan pluginResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -105,15 +235,18 @@ func (r *pluginResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	var manifest any
-	err := json.Unmarshal([]byte(plan.Manifest.ValueString()), &manifest)
+	manifest, err := plan.manifest()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating plugin",
-			"Could not create team, invalid manifest: "+err.Error(),
+			"Could not create plugin, "+err.Error(),
 		)
 		return
 	}
+	resp.Diagnostics.Append(checkPluginManifest(plan.Type.ValueString(), manifest, plan.manifestAttr)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	plugin := tlspc.Plugin{
 		ID:       plan.ID.ValueString(),
@@ -130,11 +263,18 @@ func (r *pluginResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	plan.ID = types.StringValue(created.ID)
+	plan.ManifestHash, err = manifestHashValue(manifest)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating plugin", err.Error())
+		return
+	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *pluginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state pluginResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -155,6 +295,9 @@ func (r *pluginResource) Read(ctx context.Context, req resource.ReadRequest, res
 	state.ID = types.StringValue(plugin.ID)
 	state.Type = types.StringValue(plugin.Type)
 	stateManifest, err := json.Marshal(plugin.Manifest)
+	if err == nil {
+		state.ManifestHash, err = manifestHashValue(plugin.Manifest)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Plugin",
@@ -162,13 +305,18 @@ func (r *pluginResource) Read(ctx context.Context, req resource.ReadRequest, res
 		)
 		return
 	}
-	state.Manifest = jsontypes.NewNormalizedValue(string(stateManifest))
+	// The manifest of manifest_file is only tracked by its hash
+	if state.ManifestFile.IsNull() {
+		state.Manifest = jsontypes.NewNormalizedValue(string(stateManifest))
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *pluginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state, plan pluginResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -182,8 +330,7 @@ func (r *pluginResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	var manifest any
-	err := json.Unmarshal([]byte(plan.Manifest.ValueString()), &manifest)
+	manifest, err := plan.manifest()
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Plugin",
@@ -191,6 +338,11 @@ func (r *pluginResource) Update(ctx context.Context, req resource.UpdateRequest,
 		)
 		return
 	}
+	resp.Diagnostics.Append(checkPluginManifest(plan.Type.ValueString(), manifest, plan.manifestAttr)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	plugin := tlspc.Plugin{
 		ID:       state.ID.ValueString(),
 		Type:     plan.Type.ValueString(),
@@ -206,11 +358,18 @@ func (r *pluginResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	plan.ID = state.ID
+	plan.ManifestHash, err = manifestHashValue(manifest)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating Plugin", err.Error())
+		return
+	}
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *pluginResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state pluginResourceModel
 
 	diags := req.State.Get(ctx, &state)
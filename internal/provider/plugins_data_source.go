@@ -0,0 +1,149 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &pluginsDataSource{}
+	_ datasource.DataSourceWithConfigure = &pluginsDataSource{}
+)
+
+// NewPluginsDataSource is a helper function to simplify the provider implementation.
+func NewPluginsDataSource() datasource.DataSource {
+	return &pluginsDataSource{}
+}
+
+// pluginsDataSource is the data source implementation.
+type pluginsDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pluginsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *pluginsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugins"
+}
+
+// Schema defines the schema for the data source.
+func (d *pluginsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List the installed plugins, optionally filtered by type.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list plugins of this type, e.g. `CA` or `MACHINE`",
+			},
+			"plugins": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The installed plugins",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the plugin",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the plugin, as given in its manifest",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the plugin",
+						},
+						"manifest": schema.StringAttribute{
+							Computed:            true,
+							CustomType:          jsontypes.NormalizedType{},
+							MarkdownDescription: "JSON string of the plugin manifest",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type pluginsDataSourceModel struct {
+	Type    types.String              `tfsdk:"type"`
+	Plugins []pluginsDataSourcePlugin `tfsdk:"plugins"`
+}
+
+type pluginsDataSourcePlugin struct {
+	ID       types.String         `tfsdk:"id"`
+	Name     types.String         `tfsdk:"name"`
+	Type     types.String         `tfsdk:"type"`
+	Manifest jsontypes.Normalized `tfsdk:"manifest"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pluginsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state pluginsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plugins, err := d.client.GetPlugins(state.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving plugins",
+			fmt.Sprintf("Error retrieving plugins: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Plugins = []pluginsDataSourcePlugin{}
+	for _, p := range plugins {
+		manifest, err := json.Marshal(p.Manifest)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error retrieving plugins",
+				fmt.Sprintf("Could not read manifest of plugin %s: %s", p.ID, err.Error()),
+			)
+			return
+		}
+		state.Plugins = append(state.Plugins, pluginsDataSourcePlugin{
+			ID:       types.StringValue(p.ID),
+			Name:     types.StringValue(p.PluginName()),
+			Type:     types.StringValue(p.Type),
+			Manifest: jsontypes.NewNormalizedValue(string(manifest)),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,232 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &policyViolationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &policyViolationsDataSource{}
+)
+
+// policyViolationStatuses are the statuses of certificate requests blocked
+// for not meeting the constraints of their issuing template.
+var policyViolationStatuses = []string{"REJECTED"}
+
+// NewPolicyViolationsDataSource is a helper function to simplify the provider implementation.
+func NewPolicyViolationsDataSource() datasource.DataSource {
+	return &policyViolationsDataSource{}
+}
+
+// policyViolationsDataSource is the data source implementation.
+type policyViolationsDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *policyViolationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *policyViolationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_violations"
+}
+
+// defaultPolicyViolationsLimit is the number of violations returned if no
+// limit is set.
+const defaultPolicyViolationsLimit = 100
+
+// Schema defines the schema for the data source.
+func (d *policyViolationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List certificate requests which were rejected for violating the policy of their issuing template, newest first, e.g. for audits.",
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return requests for this application",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"certificate_template_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return requests using this issuing template",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"from": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return requests made at or after this time, in RFC3339 format",
+			},
+			"to": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return requests made at or before this time, in RFC3339 format",
+			},
+			"limit": schema.Int32Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("The maximum number of violations to return, defaults to %d", defaultPolicyViolationsLimit),
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"violations": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The rejected certificate requests",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the certificate request",
+						},
+						"date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the request was made",
+						},
+						"application_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the application the certificate was requested for",
+						},
+						"certificate_template_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the issuing template used",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The status of the request",
+						},
+						"code": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The code of the error which blocked the request",
+						},
+						"message": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Why the request was blocked",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type policyViolationsDataSourceModel struct {
+	ApplicationID types.String      `tfsdk:"application_id"`
+	TemplateID    types.String      `tfsdk:"certificate_template_id"`
+	From          types.String      `tfsdk:"from"`
+	To            types.String      `tfsdk:"to"`
+	Limit         types.Int32       `tfsdk:"limit"`
+	Violations    []policyViolation `tfsdk:"violations"`
+}
+
+type policyViolation struct {
+	ID            types.String `tfsdk:"id"`
+	Date          types.String `tfsdk:"date"`
+	ApplicationID types.String `tfsdk:"application_id"`
+	TemplateID    types.String `tfsdk:"certificate_template_id"`
+	Status        types.String `tfsdk:"status"`
+	Code          types.Int64  `tfsdk:"code"`
+	Message       types.String `tfsdk:"message"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *policyViolationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model policyViolationsDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for attr, v := range map[string]types.String{"from": model.From, "to": model.To} {
+		if v.IsNull() {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, v.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr),
+				"Invalid Time",
+				fmt.Sprintf("Expected an RFC3339 timestamp, e.g. 2025-01-02T15:04:05Z: %s", err.Error()),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultPolicyViolationsLimit
+	if !model.Limit.IsNull() {
+		limit = int(model.Limit.ValueInt32())
+	}
+
+	requests, err := d.client.SearchCertificateRequests(tlspc.CertificateRequestFilter{
+		ApplicationID: model.ApplicationID.ValueString(),
+		TemplateID:    model.TemplateID.ValueString(),
+		Statuses:      policyViolationStatuses,
+		From:          model.From.ValueString(),
+		To:            model.To.ValueString(),
+		Limit:         limit,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving Policy Violations",
+			fmt.Sprintf("Error searching certificate requests: %s", err.Error()),
+		)
+		return
+	}
+
+	model.Violations = []policyViolation{}
+	for _, cr := range requests {
+		v := policyViolation{
+			ID:            types.StringValue(cr.ID),
+			Date:          types.StringValue(cr.CreationDate),
+			ApplicationID: types.StringValue(cr.ApplicationID),
+			TemplateID:    types.StringValue(cr.TemplateID),
+			Status:        types.StringValue(cr.Status),
+			Code:          types.Int64Null(),
+			Message:       types.StringNull(),
+		}
+		if cr.ErrorInformation != nil {
+			v.Code = types.Int64Value(int64(cr.ErrorInformation.Code))
+			v.Message = types.StringValue(cr.ErrorInformation.Message)
+		}
+		model.Violations = append(model.Violations, v)
+	}
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -6,21 +6,35 @@ package provider
 import (
 	"context"
 	"os"
+	"regexp"
+	"time"
 
 	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
+// userAgentProductRegex matches one or more User-Agent product tokens, each
+// optionally followed by a version, e.g. "my-platform/2.3".
+var userAgentProductRegex = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+(/[!#$%&'*+.^_`|~0-9A-Za-z-]+)?( [!#$%&'*+.^_`|~0-9A-Za-z-]+(/[!#$%&'*+.^_`|~0-9A-Za-z-]+)?)*$")
+
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
 var _ provider.Provider = &tlspcProvider{}
 var _ provider.ProviderWithFunctions = &tlspcProvider{}
+var _ provider.ProviderWithEphemeralResources = &tlspcProvider{}
 
 // tlspcProvider defines the provider implementation.
 type tlspcProvider struct {
@@ -32,8 +46,22 @@ type tlspcProvider struct {
 
 // tlspcProviderModel describes the provider data model.
 type tlspcProviderModel struct {
-	ApiKey   types.String `tfsdk:"apikey"`
-	Endpoint types.String `tfsdk:"endpoint"`
+	ApiKey                types.String `tfsdk:"apikey"`
+	Endpoint              types.String `tfsdk:"endpoint"`
+	UserAgentSuffix       types.String `tfsdk:"user_agent_suffix"`
+	APIVersion            types.String `tfsdk:"api_version"`
+	CompressRequests      types.Bool   `tfsdk:"compress_requests"`
+	ReadCacheTTL          types.String `tfsdk:"read_cache_ttl"`
+	RetryJitter           types.String `tfsdk:"retry_jitter"`
+	RetryBudget           types.Int64  `tfsdk:"retry_budget"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	PinnedSPKISHA256      types.Set    `tfsdk:"pinned_spki_sha256"`
+	ServiceAccount        types.Object `tfsdk:"service_account"`
+}
+
+type tlspcProviderServiceAccountModel struct {
+	ClientID   types.String `tfsdk:"client_id"`
+	PrivateKey types.String `tfsdk:"private_key"`
 }
 
 func (p *tlspcProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -56,13 +84,86 @@ We recommend that you create a custom user with the [permissions required](https
 		Description: "Provider for the Venafi TLS Protect Cloud Platform",
 		Attributes: map[string]schema.Attribute{
 			"apikey": schema.StringAttribute{
-				MarkdownDescription: "API Key. Required unless specified by setting the environment variable `TLSPC_APIKEY`",
+				MarkdownDescription: "API Key. Required unless specified by setting the environment variable `TLSPC_APIKEY`, or `service_account` is set. Provider configuration is never saved to plans or state, so this may be an ephemeral value, e.g. from an ephemeral resource (requires Terraform 1.10 or later)",
 				Optional:            true,
+				Sensitive:           true,
 			},
 			"endpoint": schema.StringAttribute{
 				MarkdownDescription: "TLSPC API Endpoint",
 				Optional:            true,
 			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Appended to the User-Agent sent with API requests, to attribute them to a module or platform, e.g. `my-platform/2.3`. Can also be specified by setting the environment variable `TLSPC_USER_AGENT_SUFFIX`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(userAgentProductRegex, "must be one or more space separated product tokens, each optionally followed by /version"),
+				},
+			},
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: "Pin the version of the API requested, so that the shape of responses, and so the provider's behaviour, doesn't change when the API's default version does. Can also be specified by setting the environment variable `TLSPC_API_VERSION`. Unset by default, using the API's default version",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"compress_requests": schema.BoolAttribute{
+				MarkdownDescription: "Gzip compress large request bodies. Responses are always requested compressed",
+				Optional:            true,
+			},
+			"read_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "Cache successful API reads for this long, e.g. `30s`, so that data sources reading the same object share a request. Any change made by the provider clears the cache. Disabled by default",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"retry_jitter": schema.StringAttribute{
+				MarkdownDescription: "When the API rate limits requests, all requests are held back for the time it asks. This is how they are spread out afterwards, so that they don't all resume at once: `none`, `equal` (within half the pause; the default) or `full` (within the length of the pause)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(tlspc.JitterStrategies...),
+				},
+			},
+			"retry_budget": schema.Int64Attribute{
+				MarkdownDescription: "The most times rate limited requests are retried in total, across every request in a plan or apply. Once it's used up, rate limited requests fail straight away. Unlimited by default, though each request is retried at most 5 times",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "The most requests to the API in flight at once, across every resource and data source, so that large applies don't trip rate limits. Terraform runs up to 10 operations at once by default, each of which may make requests. Unlimited by default",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"pinned_spki_sha256": schema.SetAttribute{
+				MarkdownDescription: "Pin the API endpoint's certificate, so that requests fail unless its certificate chain includes a certificate whose public key has one of these hashes. Each is the base64 encoded SHA-256 hash of a DER encoded SubjectPublicKeyInfo, e.g. from `openssl x509 -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64`. Pin a backup key too, so that rotating the certificate doesn't break the provider",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"service_account": schema.SingleNestedAttribute{
+				MarkdownDescription: "Authenticate as a service account instead of with an API key, using short-lived access tokens got with its private key for the duration of the run. The provider can then only do what the service account's scopes allow, limiting what leaked CI credentials could be used for",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "The client ID of the service account",
+						Required:            true,
+						Validators: []validator.String{
+							validators.Uuid(),
+						},
+					},
+					"private_key": schema.StringAttribute{
+						MarkdownDescription: "The PEM encoded private key of the service account. RSA, EC P-256 and Ed25519 keys are supported",
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -78,42 +179,146 @@ func (p *tlspcProvider) Configure(ctx context.Context, req provider.ConfigureReq
 
 	apikey := os.Getenv("TLSPC_APIKEY")
 	endpoint := os.Getenv("TLSPC_ENDPOINT")
+	userAgentSuffix := os.Getenv("TLSPC_USER_AGENT_SUFFIX")
+	apiVersion := os.Getenv("TLSPC_API_VERSION")
+	if config.ApiKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("apikey"),
+			"Unknown API Key",
+			"The provider cannot create the TLSPC API client as the API Key is unknown. If it comes from a resource, use an ephemeral resource or data source instead so that it is known when planning",
+		)
+		return
+	}
 	if !config.ApiKey.IsNull() {
 		apikey = config.ApiKey.ValueString()
 	}
-	if apikey == "" {
+	var serviceAccount *tlspcProviderServiceAccountModel
+	if config.ServiceAccount.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("service_account"),
+			"Unknown service account",
+			"The provider cannot create the TLSPC API client as the service account is unknown. If it comes from a resource, use an ephemeral resource or data source instead so that it is known when planning",
+		)
+		return
+	}
+	if !config.ServiceAccount.IsNull() {
+		resp.Diagnostics.Append(config.ServiceAccount.As(ctx, &serviceAccount, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if serviceAccount.ClientID.IsUnknown() || serviceAccount.PrivateKey.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("service_account"),
+				"Unknown service account",
+				"The provider cannot create the TLSPC API client as the service account client ID or private key is unknown. If it comes from a resource, use an ephemeral resource or data source instead so that it is known when planning",
+			)
+			return
+		}
+	}
+	if apikey == "" && serviceAccount == nil {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("apikey"),
 			"API Key not provided",
-			"The provider cannot create the TLSPC API client as the API Key has not been provided",
+			"The provider cannot create the TLSPC API client as neither the API Key nor a service account has been provided",
 		)
 	}
 	if !config.Endpoint.IsNull() {
 		endpoint = config.Endpoint.ValueString()
 	}
+	if !config.UserAgentSuffix.IsNull() {
+		userAgentSuffix = config.UserAgentSuffix.ValueString()
+	}
+	if userAgentSuffix != "" && !userAgentProductRegex.MatchString(userAgentSuffix) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_agent_suffix"),
+			"Invalid User-Agent suffix",
+			"TLSPC_USER_AGENT_SUFFIX must be one or more space separated product tokens, each optionally followed by /version",
+		)
+	}
+	if !config.APIVersion.IsNull() {
+		apiVersion = config.APIVersion.ValueString()
+	}
+	// read_cache_ttl has been validated, so is either unset or a duration.
+	readCacheTTL, _ := time.ParseDuration(config.ReadCacheTTL.ValueString())
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	client, _ := tlspc.NewClient(apikey, endpoint, p.version)
+	client.SetUserAgentSuffix(userAgentSuffix)
+	client.SetAPIVersion(apiVersion)
+	client.SetCompressRequests(config.CompressRequests.ValueBool())
+	client.SetResponseCacheTTL(readCacheTTL)
+	if serviceAccount != nil {
+		err := client.SetServiceAccount(serviceAccount.ClientID.ValueString(), serviceAccount.PrivateKey.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("service_account").AtName("private_key"), "Invalid service account private key", err.Error())
+			return
+		}
+	}
+	if !config.RetryJitter.IsNull() {
+		if err := client.SetRetryJitter(tlspc.JitterStrategy(config.RetryJitter.ValueString())); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_jitter"), "Invalid retry jitter", err.Error())
+			return
+		}
+	}
+	if !config.RetryBudget.IsNull() {
+		client.SetRetryBudget(int(config.RetryBudget.ValueInt64()))
+	}
+	if !config.PinnedSPKISHA256.IsNull() {
+		var pins []string
+		resp.Diagnostics.Append(config.PinnedSPKISHA256.ElementsAs(ctx, &pins, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err := client.SetPinnedSPKIHashes(pins); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("pinned_spki_sha256"), "Invalid pinned SPKI hash", err.Error())
+			return
+		}
+	}
+	if !config.MaxConcurrentRequests.IsNull() {
+		client.SetMaxConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64()))
+	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *tlspcProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewTeamResource,
 		NewServiceAccountResource,
+		NewServiceAccountAgentResource,
+		NewServiceAccountWIFResource,
+		NewServiceAccountGitHubActionsResource,
+		NewServiceAccountAzureResource,
+		NewKubernetesAgentResource,
 		NewRegistryAccountResource,
 		NewPluginResource,
 		NewCertificateTemplateResource,
 		NewApplicationResource,
+		NewApplicationOutageDetectionResource,
 		NewFireflyConfigResource,
 		NewFireflySubCAResource,
 		NewFireflyPolicyResource,
 		NewCloudProviderGCPResource,
 		NewCloudProviderGCPValidateResource,
+		NewVSatelliteResource,
+		NewVSatelliteWorkerResource,
+		NewTagResource,
+		NewTagAssignmentResource,
+		NewMachineResource,
+		NewPluginCAConnectorResource,
+		NewCertificateApplicationResource,
+		NewApprovalWorkflowResource,
+		NewCertificateResource,
+	}
+}
+
+func (p *tlspcProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewVSatellitePairingCodeEphemeralResource,
 	}
 }
 
@@ -125,11 +330,36 @@ func (p *tlspcProvider) DataSources(ctx context.Context) []func() datasource.Dat
 		NewTeamDataSource,
 		NewApplicationDataSource,
 		NewTenantDataSource,
+		NewFireflyPolicyDataSource,
+		NewVSatelliteDataSource,
+		NewTeamMembersDataSource,
+		NewPluginDataSource,
+		NewPluginsDataSource,
+		NewMachineDataSource,
+		NewCertificateInstallationDataSource,
+		NewActivityLogDataSource,
+		NewWhoamiDataSource,
+		NewFireflyBootstrapDataSource,
+		NewCertificateDataSource,
+		NewTagsDataSource,
+		NewCertificateMetricsDataSource,
+		NewRiskyCertificatesDataSource,
+		NewPolicyViolationsDataSource,
+		NewVSatelliteHealthDataSource,
 	}
 }
 
 func (p *tlspcProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewIsISO8601PeriodFunction,
+		NewCertificateFingerprintFunction,
+		NewParseCertificateFunction,
+		NewTeamOwnerFunction,
+		NewUserOwnerFunction,
+		NewDockerConfigJSONFunction,
+		NewJWKSFunction,
+		NewSANRegexesFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -5,28 +5,42 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"terraform-provider-tlspc/internal/tlspc"
 	"terraform-provider-tlspc/internal/validators"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+const defaultRegistryHostname = "private-registry.venafi.cloud"
+
+// regenerateTokenKey is the private state key set when an account is moved
+// from a tlspc_service_account, whose registry token then needs generating.
+const regenerateTokenKey = "regenerate_token"
+
 var (
 	_ resource.Resource                = &registryAccountResource{}
 	_ resource.ResourceWithConfigure   = &registryAccountResource{}
 	_ resource.ResourceWithImportState = &registryAccountResource{}
+	_ resource.ResourceWithModifyPlan  = &registryAccountResource{}
 )
 
 type registryAccountResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewRegistryAccountResource() resource.Resource {
@@ -39,7 +53,7 @@ func (r *registryAccountResource) Metadata(_ context.Context, req resource.Metad
 
 func (r *registryAccountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manage pull credentials for the Venafi OCI private registry",
+		MarkdownDescription: "Manage pull credentials for the Venafi OCI private registry. A `tlspc_service_account` can be moved to this resource with a `moved` block, keeping the account and generating a new registry token for it.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -61,7 +75,7 @@ func (r *registryAccountResource) Schema(_ context.Context, _ resource.SchemaReq
 			"scopes": schema.SetAttribute{
 				Required:    true,
 				ElementType: types.StringType,
-				MarkdownDescription: `A list of the images that this service account is authorised to access; valid options include:
+				MarkdownDescription: `A list of the images that this service account is authorised to access, which are checked against the scopes available from the API when planning; valid options include:
     * oci-registry-cm
     * oci-registry-cm-ape
     * oci-registry-cm-vei
@@ -71,16 +85,50 @@ func (r *registryAccountResource) Schema(_ context.Context, _ resource.SchemaReq
 			"oci_account_name": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Generated OCI account name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"oci_registry_token": schema.StringAttribute{
 				Computed:            true,
 				Sensitive:           true,
-				MarkdownDescription: "Generated OCI registry token",
+				MarkdownDescription: "Generated OCI registry token. This is null after an import until the token is next rotated",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"credential_lifetime": schema.Int32Attribute{
 				Required:            true,
 				MarkdownDescription: "Credential Lifetime in days",
 			},
+			"credential_expiry": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Expiry date of the current OCI registry token (RFC3339). A new token is generated for the same account once it has expired",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"registry_hostname": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultRegistryHostname),
+				MarkdownDescription: "Hostname of the Venafi OCI private registry used in `dockerconfigjson`, defaults to `" + defaultRegistryHostname + "` (use `private-registry.venafi.eu` for EU tenants)",
+			},
+			"dockerconfigjson": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "A `.dockerconfigjson` document containing the generated credentials for `registry_hostname`, suitable for a `kubernetes.io/dockerconfigjson` secret",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotate_when_expiring_within": schema.Int32Attribute{
+				Optional:            true,
+				MarkdownDescription: "If set, a new OCI registry token will be generated for the same account when the current token expires within this many days, rather than only once it has expired",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -90,12 +138,12 @@ func (r *registryAccountResource) Configure(_ context.Context, req resource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -112,9 +160,43 @@ type registryAccountResourceModel struct {
 	OciAccountName     types.String   `tfsdk:"oci_account_name"`
 	OciRegistryToken   types.String   `tfsdk:"oci_registry_token"`
 	CredentialLifetime types.Int32    `tfsdk:"credential_lifetime"`
+	CredentialExpiry   types.String   `tfsdk:"credential_expiry"`
+	RegistryHostname   types.String   `tfsdk:"registry_hostname"`
+	DockerConfigJSON   types.String   `tfsdk:"dockerconfigjson"`
+	RotateWithin       types.Int32    `tfsdk:"rotate_when_expiring_within"`
+}
+
+type dockerConfigAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+type dockerConfig struct {
+	Auths map[string]dockerConfigAuth `json:"auths"`
+}
+
+func dockerConfigJSON(hostname, username, password string) (string, error) {
+	cfg := dockerConfig{
+		Auths: map[string]dockerConfigAuth{
+			hostname: {
+				Username: username,
+				Password: password,
+				Auth:     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+			},
+		},
+	}
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
 }
 
 func (r *registryAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan registryAccountResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -145,11 +227,23 @@ func (r *registryAccountResource) Create(ctx context.Context, req resource.Creat
 	plan.ID = types.StringValue(created.ID)
 	plan.OciAccountName = types.StringValue(created.OciAccountName)
 	plan.OciRegistryToken = types.StringValue(created.OciRegistryToken)
+	plan.CredentialExpiry = types.StringValue(created.CredentialsExpiry)
+	dockercfg, err := dockerConfigJSON(plan.RegistryHostname.ValueString(), created.OciAccountName, created.OciRegistryToken)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating registryAccount",
+			"Could not render dockerconfigjson: "+err.Error(),
+		)
+		return
+	}
+	plan.DockerConfigJSON = types.StringValue(dockercfg)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *registryAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state registryAccountResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -170,6 +264,13 @@ func (r *registryAccountResource) Read(ctx context.Context, req resource.ReadReq
 	state.ID = types.StringValue(sa.ID)
 	state.Name = types.StringValue(sa.Name)
 	state.Owner = types.StringValue(sa.Owner)
+	if sa.CredentialsExpiry != "" {
+		state.CredentialExpiry = types.StringValue(sa.CredentialsExpiry)
+	}
+	// Not set after an import, or by versions before registry_hostname.
+	if state.RegistryHostname.IsNull() {
+		state.RegistryHostname = types.StringValue(defaultRegistryHostname)
+	}
 
 	scopes := []types.String{}
 	for _, v := range sa.Scopes {
@@ -182,6 +283,8 @@ func (r *registryAccountResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r *registryAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan, state registryAccountResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -216,14 +319,52 @@ func (r *registryAccountResource) Update(ctx context.Context, req resource.Updat
 		)
 		return
 	}
+	// ModifyPlan marks the token as unknown when it is due for rotation.
+	rotate := plan.OciRegistryToken.IsUnknown()
+
 	plan.ID = state.ID
 	plan.OciAccountName = state.OciAccountName
 	plan.OciRegistryToken = state.OciRegistryToken
+	plan.CredentialExpiry = state.CredentialExpiry
+
+	if rotate {
+		rotated, err := r.client.RotateServiceAccountCredentials(state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error rotating registryAccount credentials",
+				"Could not rotate registryAccount credentials, unexpected error: "+err.Error(),
+			)
+			return
+		}
+		if rotated.OciAccountName != "" {
+			plan.OciAccountName = types.StringValue(rotated.OciAccountName)
+		}
+		plan.OciRegistryToken = types.StringValue(rotated.OciRegistryToken)
+		plan.CredentialExpiry = types.StringValue(rotated.CredentialsExpiry)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, regenerateTokenKey, nil)...)
+	}
+
+	// An imported account has no token until it is rotated.
+	plan.DockerConfigJSON = types.StringNull()
+	if !plan.OciRegistryToken.IsNull() {
+		dockercfg, err := dockerConfigJSON(plan.RegistryHostname.ValueString(), plan.OciAccountName.ValueString(), plan.OciRegistryToken.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating registryAccount",
+				"Could not render dockerconfigjson: "+err.Error(),
+			)
+			return
+		}
+		plan.DockerConfigJSON = types.StringValue(dockercfg)
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r *registryAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state registryAccountResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -242,6 +383,107 @@ func (r *registryAccountResource) Delete(ctx context.Context, req resource.Delet
 	}
 }
 
+func (r *registryAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkScopes(ctx, r.client, req.Plan, knownRegistryAccountScopes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to rotate on create.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// Only the attributes needed are read from the plan, as others such as
+	// scopes may still be unknown.
+	var state registryAccountResourceModel
+	var hostname types.String
+	var rotateWithin types.Int32
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("registry_hostname"), &hostname)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotate_when_expiring_within"), &rotateWithin)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !hostname.Equal(state.RegistryHostname) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("dockerconfigjson"), types.StringUnknown())...)
+	}
+
+	// The token isn't in state after the account is moved from a
+	// tlspc_service_account, so a new one is needed. It isn't in state after
+	// an import either, but the existing token is left in use there until it
+	// is due for rotation, as it may already be in pull secrets.
+	regenerate, diags := req.Private.GetKey(ctx, regenerateTokenKey)
+	resp.Diagnostics.Append(diags...)
+	if regenerate != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("oci_registry_token"),
+			"Registry account credentials will be generated",
+			"The OCI registry token for this account isn't known, so a new token will be generated.",
+		)
+		resp.Diagnostics.Append(rotateRegistryToken(ctx, &resp.Plan)...)
+		return
+	}
+
+	if rotateWithin.IsUnknown() || state.CredentialExpiry.ValueString() == "" {
+		return
+	}
+
+	expiry, err := time.Parse(time.RFC3339, state.CredentialExpiry.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("credential_expiry"),
+			"Unable to determine credential expiry",
+			"Could not parse credential expiry "+state.CredentialExpiry.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// An expired token is always replaced, as the pull secrets built from it
+	// no longer work.
+	if !expiry.After(time.Now()) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("oci_registry_token"),
+			"Registry account credentials have expired",
+			fmt.Sprintf("The OCI registry token expired at %s; a new token will be generated.", expiry.Format(time.RFC3339)),
+		)
+		resp.Diagnostics.Append(rotateRegistryToken(ctx, &resp.Plan)...)
+		return
+	}
+
+	if rotateWithin.IsNull() {
+		return
+	}
+
+	threshold := time.Duration(rotateWithin.ValueInt32()) * 24 * time.Hour
+	if time.Until(expiry) > threshold {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("oci_registry_token"),
+		"Registry account credentials will be rotated",
+		fmt.Sprintf("The OCI registry token expires at %s, which is within %d days; a new token will be generated.", expiry.Format(time.RFC3339), rotateWithin.ValueInt32()),
+	)
+	resp.Diagnostics.Append(rotateRegistryToken(ctx, &resp.Plan)...)
+}
+
+// rotateRegistryToken marks the registry token as unknown in the plan, so that
+// Update generates a new one. The account name may change with it.
+func rotateRegistryToken(ctx context.Context, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(plan.SetAttribute(ctx, path.Root("oci_account_name"), types.StringUnknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("oci_registry_token"), types.StringUnknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("credential_expiry"), types.StringUnknown())...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("dockerconfigjson"), types.StringUnknown())...)
+	return diags
+}
+
 func (r *registryAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
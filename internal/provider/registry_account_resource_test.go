@@ -0,0 +1,108 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testAccountID = "4a7c1e2b-8d3f-4e6a-b5c9-0f1d2e3a4b5c"
+
+func TestRegistryAccountReadDefaultsHostname(t *testing.T) {
+	ctx := context.Background()
+	r := &registryAccountResource{client: &fakeAPI{
+		serviceAccounts: map[string]*tlspc.ServiceAccount{
+			testAccountID: {ID: testAccountID, Name: "registry", Owner: testTeamID, Scopes: []string{"oci-registry-cm"}},
+		},
+	}}
+
+	// As after an import, only the ID is known.
+	state := newState(t, r, registryAccountResourceModel{
+		ID:                 types.StringValue(testAccountID),
+		Name:               types.StringNull(),
+		Owner:              types.StringNull(),
+		OciAccountName:     types.StringNull(),
+		OciRegistryToken:   types.StringNull(),
+		CredentialLifetime: types.Int32Null(),
+		CredentialExpiry:   types.StringNull(),
+		RegistryHostname:   types.StringNull(),
+		DockerConfigJSON:   types.StringNull(),
+		RotateWithin:       types.Int32Null(),
+	})
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var hostname types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("registry_hostname"), &hostname)...)
+	if hostname.ValueString() != defaultRegistryHostname {
+		t.Errorf("expected registry_hostname %q, got %s", defaultRegistryHostname, hostname)
+	}
+}
+
+func TestRegistryAccountModifyPlan(t *testing.T) {
+	day := 24 * time.Hour
+	cases := []struct {
+		name         string
+		token        types.String
+		expiresIn    time.Duration
+		rotateWithin types.Int32
+		wantRotate   bool
+	}{
+		{name: "current", token: types.StringValue("token"), expiresIn: 30 * day, rotateWithin: types.Int32Null()},
+		{name: "expired", token: types.StringValue("token"), expiresIn: -day, rotateWithin: types.Int32Null(), wantRotate: true},
+		{name: "expiring within threshold", token: types.StringValue("token"), expiresIn: 2 * day, rotateWithin: types.Int32Value(7), wantRotate: true},
+		{name: "expiring after threshold", token: types.StringValue("token"), expiresIn: 30 * day, rotateWithin: types.Int32Value(7)},
+		// An imported account's token may be in use, so is left alone.
+		{name: "imported", token: types.StringNull(), expiresIn: 30 * day, rotateWithin: types.Int32Null()},
+		{name: "imported and expired", token: types.StringNull(), expiresIn: -day, rotateWithin: types.Int32Null(), wantRotate: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &registryAccountResource{client: &fakeAPI{scopes: knownRegistryAccountScopes}}
+
+			model := registryAccountResourceModel{
+				ID:                 types.StringValue(testAccountID),
+				Name:               types.StringValue("registry"),
+				Owner:              types.StringValue(testTeamID),
+				Scopes:             []types.String{types.StringValue("oci-registry-cm")},
+				OciAccountName:     types.StringValue("account"),
+				OciRegistryToken:   tc.token,
+				CredentialLifetime: types.Int32Value(365),
+				CredentialExpiry:   types.StringValue(time.Now().Add(tc.expiresIn).Format(time.RFC3339)),
+				RegistryHostname:   types.StringValue(defaultRegistryHostname),
+				DockerConfigJSON:   types.StringNull(),
+				RotateWithin:       tc.rotateWithin,
+			}
+			state := newState(t, r, model)
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+
+			req := resource.ModifyPlanRequest{State: state, Plan: plan}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var token types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("oci_registry_token"), &token)...)
+			if got := token.IsUnknown(); got != tc.wantRotate {
+				t.Errorf("unexpected rotation %t", got)
+			}
+		})
+	}
+}
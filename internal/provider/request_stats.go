@@ -0,0 +1,42 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-tlspc/internal/tlspc"
+)
+
+// logRequestStats logs the totals of the API requests made so far, so that
+// the provider's use of the API during an apply can be followed in the logs.
+// If the tenant's rate limit quota has become low, a warning is added to
+// diags. It is deferred at the start of each resource operation.
+func logRequestStats(ctx context.Context, client tlspc.InfoAPI, diags *diag.Diagnostics) {
+	if client == nil {
+		return
+	}
+	stats := client.Stats()
+	tflog.Debug(ctx, "TLSPC API requests", map[string]any{
+		"requests": stats.Requests,
+		"retries":  stats.Retries,
+		"errors":   stats.Errors,
+	})
+
+	if quota, low := client.LowRateLimitQuota(); low {
+		reset := ""
+		if !quota.Reset.IsZero() {
+			reset = fmt.Sprintf(", until it resets in %s", time.Until(quota.Reset).Round(time.Second))
+		}
+		diags.AddWarning(
+			"TLSPC API rate limit nearly reached",
+			fmt.Sprintf("Only %d of the tenant's quota of %d API requests remain%s. Consider staggering large applies, or reducing -parallelism, so that requests aren't rate limited.", quota.Remaining, quota.Limit, reset),
+		)
+	}
+}
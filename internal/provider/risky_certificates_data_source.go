@@ -0,0 +1,228 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &riskyCertificatesDataSource{}
+	_ datasource.DataSourceWithConfigure = &riskyCertificatesDataSource{}
+)
+
+const (
+	defaultMinRSAKeySize   = 2048
+	defaultMaxValidityDays = 398
+)
+
+// weakSignatureHashes are the signature hash algorithms considered weak.
+var weakSignatureHashes = []string{"MD2", "MD5", "SHA1"}
+
+// NewRiskyCertificatesDataSource is a helper function to simplify the provider implementation.
+func NewRiskyCertificatesDataSource() datasource.DataSource {
+	return &riskyCertificatesDataSource{}
+}
+
+// riskyCertificatesDataSource is the data source implementation.
+type riskyCertificatesDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *riskyCertificatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *riskyCertificatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_risky_certificates"
+}
+
+// Schema defines the schema for the data source.
+func (d *riskyCertificatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List the certificates in the tenant, or in an application, with security weaknesses, e.g. to fail a pipeline when there are any.",
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only check certificates belonging to this application",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only check certificates with this status, e.g. `ACTIVE`",
+			},
+			"min_rsa_key_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("RSA keys smaller than this are weak. Defaults to %d", defaultMinRSAKeySize),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_validity_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Certificates valid for longer than this many days are risky. Defaults to %d", defaultMaxValidityDays),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"certificates": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The certificates with weaknesses, in order of expiry",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the certificate",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the certificate",
+						},
+						"applications": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The IDs of the applications the certificate belongs to",
+						},
+						"risks": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							MarkdownDescription: "The certificate's weaknesses:\n" +
+								"	* WEAK_SIGNATURE_HASH (signed using MD2, MD5 or SHA-1)\n" +
+								"	* WEAK_KEY (an RSA key smaller than `min_rsa_key_size`)\n" +
+								"	* LONG_VALIDITY (valid for longer than `max_validity_days`)\n" +
+								"	* SELF_SIGNED",
+						},
+					},
+				},
+			},
+			"ids": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the certificates with weaknesses",
+			},
+		},
+	}
+}
+
+type riskyCertificatesDataSourceModel struct {
+	ApplicationID   types.String                      `tfsdk:"application_id"`
+	Status          types.String                      `tfsdk:"status"`
+	MinRSAKeySize   types.Int64                       `tfsdk:"min_rsa_key_size"`
+	MaxValidityDays types.Int64                       `tfsdk:"max_validity_days"`
+	Certificates    []riskyCertificatesDataSourceCert `tfsdk:"certificates"`
+	IDs             []types.String                    `tfsdk:"ids"`
+}
+
+type riskyCertificatesDataSourceCert struct {
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	Applications []types.String `tfsdk:"applications"`
+	Risks        []types.String `tfsdk:"risks"`
+}
+
+// certificateRisks returns the weaknesses of a certificate.
+func certificateRisks(cert tlspc.Certificate, minRSAKeySize int, maxValidity time.Duration) []string {
+	risks := []string{}
+	for _, h := range weakSignatureHashes {
+		if strings.EqualFold(cert.SignatureHashAlgorithm, h) {
+			risks = append(risks, "WEAK_SIGNATURE_HASH")
+			break
+		}
+	}
+	if cert.KeyType == "RSA" && cert.KeyStrength > 0 && cert.KeyStrength < minRSAKeySize {
+		risks = append(risks, "WEAK_KEY")
+	}
+	start, startErr := time.Parse(time.RFC3339, cert.ValidityStart)
+	end, endErr := time.Parse(time.RFC3339, cert.ValidityEnd)
+	if startErr == nil && endErr == nil && end.Sub(start) > maxValidity {
+		risks = append(risks, "LONG_VALIDITY")
+	}
+	if cert.SelfSigned {
+		risks = append(risks, "SELF_SIGNED")
+	}
+	return risks
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *riskyCertificatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state riskyCertificatesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	minRSAKeySize := defaultMinRSAKeySize
+	if !state.MinRSAKeySize.IsNull() {
+		minRSAKeySize = int(state.MinRSAKeySize.ValueInt64())
+	}
+	maxValidityDays := defaultMaxValidityDays
+	if !state.MaxValidityDays.IsNull() {
+		maxValidityDays = int(state.MaxValidityDays.ValueInt64())
+	}
+
+	certs, err := d.client.SearchCertificates(tlspc.CertificateFilter{
+		ApplicationID: state.ApplicationID.ValueString(),
+		Status:        state.Status.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving certificates",
+			fmt.Sprintf("Error searching certificates: %s", err.Error()),
+		)
+		return
+	}
+
+	state.Certificates = []riskyCertificatesDataSourceCert{}
+	state.IDs = []types.String{}
+	for _, cert := range certs {
+		risks := certificateRisks(cert, minRSAKeySize, time.Duration(maxValidityDays)*24*time.Hour)
+		if len(risks) == 0 {
+			continue
+		}
+		state.Certificates = append(state.Certificates, riskyCertificatesDataSourceCert{
+			ID:           types.StringValue(cert.ID),
+			Name:         types.StringValue(cert.CertificateName),
+			Applications: valuesFromStrings(cert.ApplicationIDs),
+			Risks:        valuesFromStrings(risks),
+		})
+		state.IDs = append(state.IDs, types.StringValue(cert.ID))
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
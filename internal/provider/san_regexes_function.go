@@ -0,0 +1,98 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &sanRegexesFunction{}
+
+// domainLabelRegex matches a single DNS label.
+var domainLabelRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// sanRegex returns the anchored regular expression matching domain. A leading
+// "*." matches any subdomain of the rest, at any depth.
+func sanRegex(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	wildcard := strings.HasPrefix(domain, "*.")
+	rest := strings.TrimPrefix(domain, "*.")
+	if rest == "" {
+		return "", fmt.Errorf("%q is not a domain", domain)
+	}
+	for _, label := range strings.Split(rest, ".") {
+		if strings.Contains(label, "*") {
+			return "", fmt.Errorf("%q is not a domain, wildcards may only be used as the first label", domain)
+		}
+		if !domainLabelRegex.MatchString(label) {
+			return "", fmt.Errorf("%q is not a domain", domain)
+		}
+	}
+
+	re := regexp.QuoteMeta(rest)
+	if wildcard {
+		re = `.*\.` + re
+	}
+	return "^" + re + "$", nil
+}
+
+type sanRegexesFunction struct{}
+
+func NewSANRegexesFunction() function.Function {
+	return &sanRegexesFunction{}
+}
+
+func (f *sanRegexesFunction) Metadata(_ context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "san_regexes"
+}
+
+func (f *sanRegexesFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert domains to SAN regular expressions",
+		MarkdownDescription: "Returns an anchored, escaped regular expression for each of the given domains, in order and without duplicates, for the SAN and Common Name constraints of issuing templates and Firefly policies. e.g. `example.com` becomes `^example\\.com$`. A domain starting with `*.` matches any of its subdomains, so `*.internal` becomes `^.*\\.internal$`.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "domains",
+				ElementType:         types.StringType,
+				MarkdownDescription: "The domains, optionally starting with `*.`",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *sanRegexesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domains []string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &domains))
+	if resp.Error != nil {
+		return
+	}
+
+	regexes := []string{}
+	seen := map[string]bool{}
+	for _, d := range domains {
+		re, err := sanRegex(d)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, err.Error())
+			return
+		}
+		if seen[re] {
+			continue
+		}
+		seen[re] = true
+		regexes = append(regexes, re)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, regexes))
+}
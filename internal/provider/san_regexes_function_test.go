@@ -0,0 +1,113 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runSANRegexes calls provider::tlspc::san_regexes with domains.
+func runSANRegexes(t *testing.T, domains ...string) ([]string, *function.FuncError) {
+	t.Helper()
+
+	ctx := context.Background()
+	values := []attr.Value{}
+	for _, d := range domains {
+		values = append(values, types.StringValue(d))
+	}
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.ListValueMust(types.StringType, values)}),
+	}
+	resp := function.RunResponse{Result: function.NewResultData(types.ListUnknown(types.StringType))}
+	NewSANRegexesFunction().Run(ctx, req, &resp)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var regexes []string
+	if diags := resp.Result.Value().(types.List).ElementsAs(ctx, &regexes, false); diags.HasError() {
+		t.Fatalf("unexpected result: %v", diags)
+	}
+	return regexes, nil
+}
+
+func TestSANRegexes(t *testing.T) {
+	cases := []struct {
+		name     string
+		domains  []string
+		want     []string
+		match    []string
+		noMatch  []string
+		wantFail bool
+	}{
+		{
+			name:    "domain",
+			domains: []string{"example.com"},
+			want:    []string{`^example\.com$`},
+			match:   []string{"example.com"},
+			noMatch: []string{"exampleXcom", "www.example.com", "example.com.evil.net", "badexample.com"},
+		},
+		{
+			name:    "wildcard",
+			domains: []string{"*.example.com"},
+			want:    []string{`^.*\.example\.com$`},
+			match:   []string{"www.example.com", "a.b.example.com"},
+			noMatch: []string{"example.com", "wwwexample.com", "www.example.com.evil.net"},
+		},
+		{
+			name:    "lowercased, trailing dot and duplicates removed",
+			domains: []string{"Example.COM.", "*.example.com", "example.com"},
+			want:    []string{`^example\.com$`, `^.*\.example\.com$`},
+		},
+		{
+			name:    "IP address",
+			domains: []string{"10.0.0.1"},
+			want:    []string{`^10\.0\.0\.1$`},
+			match:   []string{"10.0.0.1"},
+			noMatch: []string{"10a0b0c1", "110.0.0.1", "10.0.0.10"},
+		},
+		{name: "email address", domains: []string{"user@example.com"}, wantFail: true},
+		{name: "inner wildcard", domains: []string{"www.*.example.com"}, wantFail: true},
+		{name: "partial wildcard", domains: []string{"w*.example.com"}, wantFail: true},
+		{name: "bare wildcard", domains: []string{"*."}, wantFail: true},
+		{name: "regex", domains: []string{".*"}, wantFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := runSANRegexes(t, tc.domains...)
+			if tc.wantFail {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+
+			re := regexp.MustCompile(got[0])
+			for _, s := range tc.match {
+				if !re.MatchString(s) {
+					t.Errorf("expected %s to match %q", got[0], s)
+				}
+			}
+			for _, s := range tc.noMatch {
+				if re.MatchString(s) {
+					t.Errorf("expected %s not to match %q", got[0], s)
+				}
+			}
+		})
+	}
+}
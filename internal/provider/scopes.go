@@ -0,0 +1,67 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Scopes known when this provider was released, used to check scopes when
+// the API's scope catalog can't be fetched.
+var (
+	knownServiceAccountScopes  = []string{"certificate-issuance", "kubernetes-discovery"}
+	knownRegistryAccountScopes = []string{"oci-registry-cm", "oci-registry-cm-ape", "oci-registry-cm-vei", "oci-registry-cm-os"}
+)
+
+// checkScopes checks that the planned scopes are all in the API's catalog of
+// service account scopes, falling back to known if it can't be fetched.
+// Unknown scopes are skipped.
+func checkScopes(ctx context.Context, client tlspc.ServiceAccountsAPI, plan tfsdk.Plan, known []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var scopes types.Set
+	diags.Append(plan.GetAttribute(ctx, path.Root("scopes"), &scopes)...)
+	if diags.HasError() || scopes.IsNull() || scopes.IsUnknown() {
+		return diags
+	}
+
+	available := known
+	if client != nil {
+		catalog, err := client.GetServiceAccountScopes()
+		if err != nil {
+			tflog.Debug(ctx, "Could not fetch scope catalog, checking scopes against those known to the provider", map[string]any{
+				"error": err.Error(),
+			})
+		} else {
+			available = catalog
+		}
+	}
+
+	for _, v := range scopes.Elements() {
+		s, ok := v.(types.String)
+		if !ok || s.IsUnknown() || s.IsNull() {
+			continue
+		}
+		if !slices.Contains(available, s.ValueString()) {
+			diags.AddAttributeError(
+				path.Root("scopes"),
+				"Invalid scope",
+				fmt.Sprintf("Scope %q is not available; valid scopes are: %s", s.ValueString(), strings.Join(available, ", ")),
+			)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,302 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &serviceAccountAgentResource{}
+	_ resource.ResourceWithConfigure   = &serviceAccountAgentResource{}
+	_ resource.ResourceWithImportState = &serviceAccountAgentResource{}
+	_ resource.ResourceWithIdentity    = &serviceAccountAgentResource{}
+	_ resource.ResourceWithModifyPlan  = &serviceAccountAgentResource{}
+)
+
+type serviceAccountAgentResource struct {
+	client tlspc.API
+}
+
+func NewServiceAccountAgentResource() resource.Resource {
+	return &serviceAccountAgentResource{}
+}
+
+func (r *serviceAccountAgentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account_agent"
+}
+
+func (r *serviceAccountAgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage a service account which authenticates with a key pair, such as one used by an agent",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the service account",
+			},
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the team that owns this service account",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"scopes": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: `
+A list of scopes that this service account is authorised for, which are checked against the scopes available from the API when planning. Available options include:
+    * certificate-issuance
+    * kubernetes-discovery
+`,
+			},
+			"public_key": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Public Key. Either this or `public_key_wo` must be specified",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("public_key_wo")),
+				},
+			},
+			"public_key_wo": schema.StringAttribute{
+				Optional:            true,
+				WriteOnly:           true,
+				MarkdownDescription: "Public Key, supplied as a write-only argument so that it is never persisted to the plan or state (requires Terraform 1.11 or later). Changes are only applied when `public_key_wo_version` is changed",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("public_key_wo_version")),
+				},
+			},
+			"public_key_wo_version": schema.Int32Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of the write-only `public_key_wo`; increment this to update the Public Key",
+				Validators: []validator.Int32{
+					int32validator.AlsoRequires(path.MatchRoot("public_key_wo")),
+				},
+			},
+			"credential_lifetime": schema.Int32Attribute{
+				Required:            true,
+				MarkdownDescription: "Credential Lifetime in days",
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *serviceAccountAgentResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
+func (r *serviceAccountAgentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type serviceAccountAgentResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Owner              types.String   `tfsdk:"owner"`
+	Scopes             []types.String `tfsdk:"scopes"`
+	PublicKey          types.String   `tfsdk:"public_key"`
+	PublicKeyWO        types.String   `tfsdk:"public_key_wo"`
+	PublicKeyWOVersion types.Int32    `tfsdk:"public_key_wo_version"`
+	CredentialLifetime types.Int32    `tfsdk:"credential_lifetime"`
+}
+
+func (r *serviceAccountAgentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkScopes(ctx, r.client, req.Plan, knownServiceAccountScopes)...)
+}
+
+func (r *serviceAccountAgentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan serviceAccountAgentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Write-only attributes are always null in the plan, so they must be retrieved from config.
+	diags = req.Config.GetAttribute(ctx, path.Root("public_key_wo"), &plan.PublicKeyWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceAccount := tlspc.ServiceAccount{
+		Name:               plan.Name.ValueString(),
+		Owner:              plan.Owner.ValueString(),
+		Scopes:             stringsFromValues(plan.Scopes),
+		PublicKey:          plan.PublicKey.ValueString(),
+		CredentialLifetime: plan.CredentialLifetime.ValueInt32(),
+		AuthenticationType: "rsaKey",
+	}
+	if !plan.PublicKeyWO.IsNull() {
+		serviceAccount.PublicKey = plan.PublicKeyWO.ValueString()
+	}
+
+	created, err := r.client.CreateServiceAccount(serviceAccount)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating serviceAccount",
+			"Could not create serviceAccount, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+	plan.PublicKeyWO = types.StringNull()
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *serviceAccountAgentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state serviceAccountAgentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sa, err := r.client.GetServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Service Account",
+			"Could not read service account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(sa.ID)
+	state.Name = types.StringValue(sa.Name)
+	state.Owner = types.StringValue(sa.Owner)
+	state.Scopes = valuesFromStrings(sa.Scopes)
+	state.CredentialLifetime = types.Int32Value(sa.CredentialLifetime)
+	// A key supplied through public_key_wo must never be written back to state.
+	if state.PublicKeyWOVersion.IsNull() {
+		state.PublicKey = types.StringValue(sa.PublicKey)
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+}
+
+func (r *serviceAccountAgentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state serviceAccountAgentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Config.GetAttribute(ctx, path.Root("public_key_wo"), &plan.PublicKeyWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceAccount := tlspc.ServiceAccount{
+		ID:                 state.ID.ValueString(),
+		Name:               plan.Name.ValueString(),
+		Owner:              plan.Owner.ValueString(),
+		Scopes:             stringsFromValues(plan.Scopes),
+		PublicKey:          plan.PublicKey.ValueString(),
+		CredentialLifetime: plan.CredentialLifetime.ValueInt32(),
+		AuthenticationType: "rsaKey",
+	}
+	// The write-only key is only sent when its version changes, as the prior value isn't available to compare.
+	if !plan.PublicKeyWO.IsNull() && !plan.PublicKeyWOVersion.Equal(state.PublicKeyWOVersion) {
+		serviceAccount.PublicKey = plan.PublicKeyWO.ValueString()
+	}
+
+	err := r.client.UpdateServiceAccount(serviceAccount)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating serviceAccount",
+			"Could not update serviceAccount, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = state.ID
+	plan.PublicKeyWO = types.StringNull()
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *serviceAccountAgentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state serviceAccountAgentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Service Account",
+			"Could not delete Service Account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *serviceAccountAgentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
@@ -0,0 +1,360 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// azureDefaultAudience is the audience of tokens requested for federation.
+const azureDefaultAudience = "api://AzureADTokenExchange"
+
+// azureLoginURL is the Microsoft Entra ID (Azure AD) endpoint which issues
+// tokens to managed identities.
+const azureLoginURL = "https://login.microsoftonline.com"
+
+// azureIssuer returns the issuer of Entra ID access tokens of version v for
+// tenant.
+func azureIssuer(tenant string, v int32) string {
+	if v == 2 {
+		return azureLoginURL + "/" + tenant + "/v2.0"
+	}
+	return "https://sts.windows.net/" + tenant + "/"
+}
+
+// azureJwksURI returns the JWKS URI of Entra ID access tokens of version v
+// for tenant.
+func azureJwksURI(tenant string, v int32) string {
+	if v == 2 {
+		return azureLoginURL + "/" + tenant + "/discovery/v2.0/keys"
+	}
+	return azureLoginURL + "/" + tenant + "/discovery/keys"
+}
+
+var (
+	_ resource.Resource                = &serviceAccountAzureResource{}
+	_ resource.ResourceWithConfigure   = &serviceAccountAzureResource{}
+	_ resource.ResourceWithImportState = &serviceAccountAzureResource{}
+	_ resource.ResourceWithIdentity    = &serviceAccountAzureResource{}
+	_ resource.ResourceWithModifyPlan  = &serviceAccountAzureResource{}
+)
+
+type serviceAccountAzureResource struct {
+	client tlspc.API
+}
+
+func NewServiceAccountAzureResource() resource.Resource {
+	return &serviceAccountAzureResource{}
+}
+
+func (r *serviceAccountAzureResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account_azure"
+}
+
+func (r *serviceAccountAzureResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manage a Workload Identity Federation service account for an Azure managed identity
+
+The issuer, JWKS URI and subject are set for the Microsoft Entra ID access tokens issued to the managed identity, such as one used by AKS workload identity.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the service account",
+			},
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the team that owns this service account",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"scopes": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: `
+A list of scopes that this service account is authorised for, which are checked against the scopes available from the API when planning. Available options include:
+    * certificate-issuance
+    * kubernetes-discovery
+`,
+			},
+			"applications": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of Applications which this service account is authorised for",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.Uuid()),
+				},
+			},
+			"tenant_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the Microsoft Entra ID tenant of the managed identity",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"principal_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The principal (object) ID of the managed identity, e.g. the `principal_id` of an `azurerm_user_assigned_identity`",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"token_version": schema.Int32Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int32default.StaticInt32(1),
+				MarkdownDescription: "The version of the access tokens, which depends on the `accessTokenAcceptedVersion` of the application they're requested for. One of `1` (the default) or `2`",
+				Validators: []validator.Int32{
+					int32validator.OneOf(1, 2),
+				},
+			},
+			"audience": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The audience the managed identity requests tokens for; the application ID URI or client ID of the application. Defaults to `api://AzureADTokenExchange`",
+			},
+			"issuer_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The issuer of the tenant's access tokens",
+			},
+			"jwks_uri": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The JWKS URI of the tenant's access tokens",
+			},
+			"subject": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The subject of the tokens issued to the managed identity, which is its principal ID",
+			},
+		},
+	}
+}
+
+func (r *serviceAccountAzureResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
+func (r *serviceAccountAzureResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type serviceAccountAzureResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	Owner        types.String   `tfsdk:"owner"`
+	Scopes       []types.String `tfsdk:"scopes"`
+	Applications []types.String `tfsdk:"applications"`
+	TenantID     types.String   `tfsdk:"tenant_id"`
+	PrincipalID  types.String   `tfsdk:"principal_id"`
+	TokenVersion types.Int32    `tfsdk:"token_version"`
+	Audience     types.String   `tfsdk:"audience"`
+	IssuerURL    types.String   `tfsdk:"issuer_url"`
+	JwksURI      types.String   `tfsdk:"jwks_uri"`
+	Subject      types.String   `tfsdk:"subject"`
+}
+
+// wif returns the WIF service account the model describes.
+func (m serviceAccountAzureResourceModel) wif() serviceAccountWIFResourceModel {
+	return serviceAccountWIFResourceModel{
+		ID:           m.ID,
+		Name:         m.Name,
+		Owner:        m.Owner,
+		Scopes:       m.Scopes,
+		JwksURI:      m.JwksURI,
+		IssuerURL:    m.IssuerURL,
+		Audience:     m.Audience,
+		Subject:      m.Subject,
+		Applications: m.Applications,
+	}
+}
+
+// refresh updates the model from sa. The tenant and principal IDs aren't
+// returned by the API, so changes to the issuer or subject show as drift
+// from them.
+func (m *serviceAccountAzureResourceModel) refresh(sa *tlspc.ServiceAccount) {
+	wif := m.wif()
+	wif.refresh(sa)
+
+	m.ID = wif.ID
+	m.Name = wif.Name
+	m.Owner = wif.Owner
+	m.Scopes = wif.Scopes
+	m.JwksURI = wif.JwksURI
+	m.IssuerURL = wif.IssuerURL
+	m.Audience = wif.Audience
+	m.Subject = wif.Subject
+	m.Applications = wif.Applications
+}
+
+// ModifyPlan fills in the issuer, JWKS URI, subject and default audience from
+// the tenant and principal IDs.
+func (r *serviceAccountAzureResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkScopes(ctx, r.client, req.Plan, knownServiceAccountScopes)...)
+
+	var tenant, principal, audience types.String
+	var version types.Int32
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tenant_id"), &tenant)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("principal_id"), &principal)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("token_version"), &version)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("audience"), &audience)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if audience.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("audience"), azureDefaultAudience)...)
+	}
+	if !tenant.IsUnknown() && !version.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("issuer_url"), azureIssuer(tenant.ValueString(), version.ValueInt32()))...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("jwks_uri"), azureJwksURI(tenant.ValueString(), version.ValueInt32()))...)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("subject"), principal)...)
+}
+
+func (r *serviceAccountAzureResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan serviceAccountAzureResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateServiceAccount(plan.wif().serviceAccount(nil))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating serviceAccount",
+			"Could not create serviceAccount, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *serviceAccountAzureResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state serviceAccountAzureResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sa, err := r.client.GetServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Service Account",
+			"Could not read service account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	state.refresh(sa)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+}
+
+func (r *serviceAccountAzureResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state serviceAccountAzureResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	prior := state.wif()
+	err := r.client.UpdateServiceAccount(plan.wif().serviceAccount(&prior))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating serviceAccount",
+			"Could not update serviceAccount, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *serviceAccountAzureResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state serviceAccountAzureResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Service Account",
+			"Could not delete Service Account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *serviceAccountAzureResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
@@ -0,0 +1,346 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The issuer of GitHub Actions OIDC tokens, and its JWKS.
+const (
+	githubActionsIssuer  = "https://token.actions.githubusercontent.com"
+	githubActionsJwksURI = githubActionsIssuer + "/.well-known/jwks"
+)
+
+var (
+	_ resource.Resource                = &serviceAccountGitHubActionsResource{}
+	_ resource.ResourceWithConfigure   = &serviceAccountGitHubActionsResource{}
+	_ resource.ResourceWithImportState = &serviceAccountGitHubActionsResource{}
+	_ resource.ResourceWithIdentity    = &serviceAccountGitHubActionsResource{}
+	_ resource.ResourceWithModifyPlan  = &serviceAccountGitHubActionsResource{}
+)
+
+type serviceAccountGitHubActionsResource struct {
+	client tlspc.API
+}
+
+func NewServiceAccountGitHubActionsResource() resource.Resource {
+	return &serviceAccountGitHubActionsResource{}
+}
+
+func (r *serviceAccountGitHubActionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account_github_actions"
+}
+
+func (r *serviceAccountGitHubActionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manage a Workload Identity Federation service account for GitHub Actions workflows
+
+The issuer, JWKS URI and subject are set for the OIDC tokens GitHub Actions issues to workflows in the given repository and branch or environment. Workflows need the ` + "`id-token: write`" + ` permission to request a token.`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the service account",
+			},
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the team that owns this service account",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"scopes": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: `
+A list of scopes that this service account is authorised for, which are checked against the scopes available from the API when planning. Available options include:
+    * certificate-issuance
+    * kubernetes-discovery
+`,
+			},
+			"applications": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of Applications which this service account is authorised for",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.Uuid()),
+				},
+			},
+			"organization": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The GitHub organization or user which owns the repository",
+			},
+			"repository": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the repository, without the organization",
+			},
+			"branch": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The branch whose workflows may use the service account. Either this or `environment` must be specified",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("environment")),
+				},
+			},
+			"environment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The deployment environment whose jobs may use the service account",
+			},
+			"audience": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The audience workflows request tokens for. Defaults to GitHub's default audience, `https://github.com/<organization>`",
+			},
+			"issuer_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The issuer of GitHub Actions tokens",
+			},
+			"jwks_uri": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The JWKS URI of GitHub Actions tokens",
+			},
+			"subject": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The subject of the tokens issued to the workflows",
+			},
+		},
+	}
+}
+
+func (r *serviceAccountGitHubActionsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
+func (r *serviceAccountGitHubActionsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type serviceAccountGitHubActionsResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	Owner        types.String   `tfsdk:"owner"`
+	Scopes       []types.String `tfsdk:"scopes"`
+	Applications []types.String `tfsdk:"applications"`
+	Organization types.String   `tfsdk:"organization"`
+	Repository   types.String   `tfsdk:"repository"`
+	Branch       types.String   `tfsdk:"branch"`
+	Environment  types.String   `tfsdk:"environment"`
+	Audience     types.String   `tfsdk:"audience"`
+	IssuerURL    types.String   `tfsdk:"issuer_url"`
+	JwksURI      types.String   `tfsdk:"jwks_uri"`
+	Subject      types.String   `tfsdk:"subject"`
+}
+
+// githubActionsSubject returns the subject of the tokens issued to workflows
+// in org/repo running on branch, or in environment if branch is empty.
+func githubActionsSubject(org, repo, branch, environment string) string {
+	if branch != "" {
+		return fmt.Sprintf("repo:%s/%s:ref:refs/heads/%s", org, repo, branch)
+	}
+	return fmt.Sprintf("repo:%s/%s:environment:%s", org, repo, environment)
+}
+
+// wif returns the WIF service account the model describes.
+func (m serviceAccountGitHubActionsResourceModel) wif() serviceAccountWIFResourceModel {
+	return serviceAccountWIFResourceModel{
+		ID:           m.ID,
+		Name:         m.Name,
+		Owner:        m.Owner,
+		Scopes:       m.Scopes,
+		JwksURI:      m.JwksURI,
+		IssuerURL:    m.IssuerURL,
+		Audience:     m.Audience,
+		Subject:      m.Subject,
+		Applications: m.Applications,
+	}
+}
+
+// refresh updates the model from sa. The repository attributes aren't
+// returned by the API, so changes to the subject show as drift from them.
+func (m *serviceAccountGitHubActionsResourceModel) refresh(sa *tlspc.ServiceAccount) {
+	wif := m.wif()
+	wif.refresh(sa)
+
+	m.ID = wif.ID
+	m.Name = wif.Name
+	m.Owner = wif.Owner
+	m.Scopes = wif.Scopes
+	m.JwksURI = wif.JwksURI
+	m.IssuerURL = wif.IssuerURL
+	m.Audience = wif.Audience
+	m.Subject = wif.Subject
+	m.Applications = wif.Applications
+}
+
+// ModifyPlan fills in the issuer, JWKS URI, subject and default audience from
+// the repository attributes.
+func (r *serviceAccountGitHubActionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkScopes(ctx, r.client, req.Plan, knownServiceAccountScopes)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("issuer_url"), githubActionsIssuer)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("jwks_uri"), githubActionsJwksURI)...)
+
+	var org, repo, branch, environment, audience types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("organization"), &org)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("repository"), &repo)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("branch"), &branch)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("environment"), &environment)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("audience"), &audience)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if audience.IsNull() && !org.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("audience"), "https://github.com/"+org.ValueString())...)
+	}
+	if !org.IsUnknown() && !repo.IsUnknown() && !branch.IsUnknown() && !environment.IsUnknown() {
+		subject := githubActionsSubject(org.ValueString(), repo.ValueString(), branch.ValueString(), environment.ValueString())
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("subject"), subject)...)
+	}
+}
+
+func (r *serviceAccountGitHubActionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan serviceAccountGitHubActionsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateServiceAccount(plan.wif().serviceAccount(nil))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating serviceAccount",
+			"Could not create serviceAccount, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *serviceAccountGitHubActionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state serviceAccountGitHubActionsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sa, err := r.client.GetServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Service Account",
+			"Could not read service account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	state.refresh(sa)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+}
+
+func (r *serviceAccountGitHubActionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state serviceAccountGitHubActionsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	prior := state.wif()
+	err := r.client.UpdateServiceAccount(plan.wif().serviceAccount(&prior))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating serviceAccount",
+			"Could not update serviceAccount, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *serviceAccountGitHubActionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state serviceAccountGitHubActionsResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Service Account",
+			"Could not delete Service Account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *serviceAccountGitHubActionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
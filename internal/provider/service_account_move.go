@@ -0,0 +1,188 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Service accounts and registry accounts are both backed by the
+// serviceaccounts API, so state can be moved between them with a moved block
+// without recreating the account. The deprecated tlspc_service_account can
+// also be moved to the agent and WIF resources which replace it.
+
+var (
+	_ resource.ResourceWithMoveState = &serviceAccountResource{}
+	_ resource.ResourceWithMoveState = &registryAccountResource{}
+	_ resource.ResourceWithMoveState = &serviceAccountAgentResource{}
+	_ resource.ResourceWithMoveState = &serviceAccountWIFResource{}
+)
+
+// resourceSchema returns the current schema of r.
+func resourceSchema(ctx context.Context, r resource.Resource) *schema.Schema {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+	return &resp.Schema
+}
+
+// movedFrom reports whether a state move is from the given resource type of
+// this provider.
+func movedFrom(req resource.MoveStateRequest, typeName string) bool {
+	return req.SourceTypeName == typeName && strings.HasSuffix(req.SourceProviderAddress, "/tlspc") && req.SourceState != nil
+}
+
+func (r *serviceAccountResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: resourceSchema(ctx, &registryAccountResource{}),
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !movedFrom(req, "tlspc_registry_account") {
+					return
+				}
+
+				var source registryAccountResourceModel
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				target := serviceAccountResourceModel{
+					ID:                 source.ID,
+					Name:               source.Name,
+					Owner:              source.Owner,
+					Scopes:             source.Scopes,
+					PublicKey:          types.StringNull(),
+					PublicKeyWO:        types.StringNull(),
+					PublicKeyWOVersion: types.Int32Null(),
+					CredentialLifetime: source.CredentialLifetime,
+					JwksURI:            types.StringNull(),
+					IssuerURL:          types.StringNull(),
+					Audience:           types.StringNull(),
+					Subject:            types.StringNull(),
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, target)...)
+			},
+		},
+	}
+}
+
+func (r *registryAccountResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: resourceSchema(ctx, &serviceAccountResource{}),
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !movedFrom(req, "tlspc_service_account") {
+					return
+				}
+
+				var source serviceAccountResourceModel
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// The registry token is only returned when it's generated, so
+				// it's left null here and marked in private state; ModifyPlan
+				// then generates a new one for the same account.
+				target := registryAccountResourceModel{
+					ID:                 source.ID,
+					Name:               source.Name,
+					Owner:              source.Owner,
+					Scopes:             source.Scopes,
+					OciAccountName:     types.StringNull(),
+					OciRegistryToken:   types.StringNull(),
+					CredentialLifetime: source.CredentialLifetime,
+					CredentialExpiry:   types.StringNull(),
+					RegistryHostname:   types.StringValue(defaultRegistryHostname),
+					DockerConfigJSON:   types.StringNull(),
+					RotateWithin:       types.Int32Null(),
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, target)...)
+				resp.Diagnostics.Append(resp.TargetPrivate.SetKey(ctx, regenerateTokenKey, []byte("true"))...)
+			},
+		},
+	}
+}
+
+func (r *serviceAccountAgentResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: resourceSchema(ctx, &serviceAccountResource{}),
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !movedFrom(req, "tlspc_service_account") {
+					return
+				}
+
+				var source serviceAccountResourceModel
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				if !source.JwksURI.IsNull() || !source.IssuerURL.IsNull() {
+					resp.Diagnostics.AddError(
+						"Unable to Move Service Account",
+						"Service Account "+source.ID.ValueString()+" uses Workload Identity Federation, so must be moved to tlspc_service_account_wif",
+					)
+					return
+				}
+
+				target := serviceAccountAgentResourceModel{
+					ID:                 source.ID,
+					Name:               source.Name,
+					Owner:              source.Owner,
+					Scopes:             source.Scopes,
+					PublicKey:          source.PublicKey,
+					PublicKeyWO:        types.StringNull(),
+					PublicKeyWOVersion: source.PublicKeyWOVersion,
+					CredentialLifetime: source.CredentialLifetime,
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, target)...)
+			},
+		},
+	}
+}
+
+func (r *serviceAccountWIFResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: resourceSchema(ctx, &serviceAccountResource{}),
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !movedFrom(req, "tlspc_service_account") {
+					return
+				}
+
+				var source serviceAccountResourceModel
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				if !source.PublicKey.IsNull() || !source.PublicKeyWOVersion.IsNull() || !source.CredentialLifetime.IsNull() {
+					resp.Diagnostics.AddError(
+						"Unable to Move Service Account",
+						"Service Account "+source.ID.ValueString()+" authenticates with a public key, so must be moved to tlspc_service_account_agent",
+					)
+					return
+				}
+
+				target := serviceAccountWIFResourceModel{
+					ID:           source.ID,
+					Name:         source.Name,
+					Owner:        source.Owner,
+					Scopes:       source.Scopes,
+					JwksURI:      source.JwksURI,
+					IssuerURL:    source.IssuerURL,
+					Audience:     source.Audience,
+					Subject:      source.Subject,
+					Applications: source.Applications,
+				}
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, target)...)
+			},
+		},
+	}
+}
@@ -6,11 +6,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"terraform-provider-tlspc/internal/tlspc"
 	"terraform-provider-tlspc/internal/validators"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,10 +28,12 @@ var (
 	_ resource.Resource                = &serviceAccountResource{}
 	_ resource.ResourceWithConfigure   = &serviceAccountResource{}
 	_ resource.ResourceWithImportState = &serviceAccountResource{}
+	_ resource.ResourceWithIdentity    = &serviceAccountResource{}
+	_ resource.ResourceWithModifyPlan  = &serviceAccountResource{}
 )
 
 type serviceAccountResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewServiceAccountResource() resource.Resource {
@@ -40,6 +46,10 @@ func (r *serviceAccountResource) Metadata(_ context.Context, req resource.Metada
 
 func (r *serviceAccountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manage a service account
+
+~> This resource is deprecated. Use ` + "`tlspc_service_account_agent`" + ` for service accounts with a public key, or ` + "`tlspc_service_account_wif`" + ` for Workload Identity Federation. Existing service accounts can be moved to them with a ` + "`moved`" + ` block, without being recreated.`,
+		DeprecationMessage: "Use tlspc_service_account_agent or tlspc_service_account_wif instead, moving existing service accounts to them with a moved block",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -63,7 +73,7 @@ func (r *serviceAccountResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Required:    true,
 				ElementType: types.StringType,
 				MarkdownDescription: `
-A list of scopes that this service account is authorised for. Available options include:
+A list of scopes that this service account is authorised for, which are checked against the scopes available from the API when planning. Available options include:
     * certificate-issuance
     * kubernetes-discovery
 `,
@@ -72,6 +82,24 @@ A list of scopes that this service account is authorised for. Available options
 			"public_key": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Public Key",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("public_key_wo")),
+				},
+			},
+			"public_key_wo": schema.StringAttribute{
+				Optional:            true,
+				WriteOnly:           true,
+				MarkdownDescription: "Public Key, supplied as a write-only argument so that it is never persisted to the plan or state (requires Terraform 1.11 or later). Changes are only applied when `public_key_wo_version` is changed",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("public_key_wo_version")),
+				},
+			},
+			"public_key_wo_version": schema.Int32Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of the write-only `public_key_wo`; increment this to update the Public Key",
+				Validators: []validator.Int32{
+					int32validator.AlsoRequires(path.MatchRoot("public_key_wo")),
+				},
 			},
 			"credential_lifetime": schema.Int32Attribute{
 				Optional:            true,
@@ -106,17 +134,21 @@ A list of scopes that this service account is authorised for. Available options
 	}
 }
 
+func (r *serviceAccountResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
 func (r *serviceAccountResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -131,6 +163,8 @@ type serviceAccountResourceModel struct {
 	Owner              types.String   `tfsdk:"owner"`
 	Scopes             []types.String `tfsdk:"scopes"`
 	PublicKey          types.String   `tfsdk:"public_key"`
+	PublicKeyWO        types.String   `tfsdk:"public_key_wo"`
+	PublicKeyWOVersion types.Int32    `tfsdk:"public_key_wo_version"`
 	CredentialLifetime types.Int32    `tfsdk:"credential_lifetime"`
 	JwksURI            types.String   `tfsdk:"jwks_uri"`
 	IssuerURL          types.String   `tfsdk:"issuer_url"`
@@ -139,13 +173,138 @@ type serviceAccountResourceModel struct {
 	Applications       []types.String `tfsdk:"applications"`
 }
 
+// serviceAccountAuthModel holds the attributes which determine how a service
+// account authenticates. They're read individually when planning, as other
+// attributes such as scopes may still be unknown.
+type serviceAccountAuthModel struct {
+	PublicKey          types.String
+	PublicKeyWOVersion types.Int32
+	CredentialLifetime types.Int32
+	JwksURI            types.String
+	IssuerURL          types.String
+	Audience           types.String
+	Subject            types.String
+	Applications       types.Set
+}
+
+// attributeGetter is implemented by tfsdk.Config, tfsdk.Plan and tfsdk.State.
+type attributeGetter interface {
+	GetAttribute(context.Context, path.Path, interface{}) diag.Diagnostics
+}
+
+func getServiceAccountAuth(ctx context.Context, src attributeGetter) (serviceAccountAuthModel, diag.Diagnostics) {
+	var m serviceAccountAuthModel
+	var diags diag.Diagnostics
+
+	diags.Append(src.GetAttribute(ctx, path.Root("public_key"), &m.PublicKey)...)
+	diags.Append(src.GetAttribute(ctx, path.Root("public_key_wo_version"), &m.PublicKeyWOVersion)...)
+	diags.Append(src.GetAttribute(ctx, path.Root("credential_lifetime"), &m.CredentialLifetime)...)
+	diags.Append(src.GetAttribute(ctx, path.Root("jwks_uri"), &m.JwksURI)...)
+	diags.Append(src.GetAttribute(ctx, path.Root("issuer_url"), &m.IssuerURL)...)
+	diags.Append(src.GetAttribute(ctx, path.Root("audience"), &m.Audience)...)
+	diags.Append(src.GetAttribute(ctx, path.Root("subject"), &m.Subject)...)
+	diags.Append(src.GetAttribute(ctx, path.Root("applications"), &m.Applications)...)
+
+	return m, diags
+}
+
+// authenticationType returns the authentication type implied by the model, or
+// an empty string if no authentication attributes are set.
+func (m serviceAccountAuthModel) authenticationType() string {
+	if !m.PublicKey.IsNull() || !m.PublicKeyWOVersion.IsNull() || !m.CredentialLifetime.IsNull() {
+		return "rsaKey"
+	}
+	if !m.JwksURI.IsNull() || !m.IssuerURL.IsNull() || !m.Audience.IsNull() || !m.Subject.IsNull() || !m.Applications.IsNull() {
+		return "rsaKeyFederated"
+	}
+	return ""
+}
+
+// sameStringSet reports whether a and b contain the same strings, ignoring
+// order and duplicates.
+func sameStringSet(a, b []string) bool {
+	for _, v := range b {
+		if !slices.Contains(a, v) {
+			return false
+		}
+	}
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *serviceAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkScopes(ctx, r.client, req.Plan, knownServiceAccountScopes)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to do on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	plan, diags := getServiceAccountAuth(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	state, diags := getServiceAccountAuth(ctx, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planType := plan.authenticationType()
+	stateType := state.authenticationType()
+	if planType == "" || stateType == "" || planType == stateType {
+		return
+	}
+
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+
+	// The authentication type of a service account can't be changed in place
+	changed := map[string]bool{
+		"public_key":            !plan.PublicKey.Equal(state.PublicKey),
+		"public_key_wo_version": !plan.PublicKeyWOVersion.Equal(state.PublicKeyWOVersion),
+		"credential_lifetime":   !plan.CredentialLifetime.Equal(state.CredentialLifetime),
+		"jwks_uri":              !plan.JwksURI.Equal(state.JwksURI),
+		"issuer_url":            !plan.IssuerURL.Equal(state.IssuerURL),
+		"audience":              !plan.Audience.Equal(state.Audience),
+		"subject":               !plan.Subject.Equal(state.Subject),
+	}
+	for attr, c := range changed {
+		if c {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root(attr))
+		}
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Service Account will be replaced",
+		fmt.Sprintf("Service Account %s is changing authentication type from %s to %s, which requires it to be replaced. Any existing credentials for it will stop working.", id.ValueString(), stateType, planType),
+	)
+}
+
 func (r *serviceAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan serviceAccountResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// Write-only attributes are always null in the plan, so they must be retrieved from config.
+	diags = req.Config.GetAttribute(ctx, path.Root("public_key_wo"), &plan.PublicKeyWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	scopes := []string{}
 	for _, v := range plan.Scopes {
 		scopes = append(scopes, v.ValueString())
@@ -159,8 +318,11 @@ func (r *serviceAccountResource) Create(ctx context.Context, req resource.Create
 
 	configured := false
 	// Agent type
-	if plan.PublicKey.ValueString() != "" || plan.CredentialLifetime.ValueInt32() > 0 {
+	if plan.PublicKey.ValueString() != "" || plan.PublicKeyWO.ValueString() != "" || plan.CredentialLifetime.ValueInt32() > 0 {
 		serviceAccount.PublicKey = plan.PublicKey.ValueString()
+		if plan.PublicKeyWO.ValueString() != "" {
+			serviceAccount.PublicKey = plan.PublicKeyWO.ValueString()
+		}
 		serviceAccount.CredentialLifetime = plan.CredentialLifetime.ValueInt32()
 		serviceAccount.AuthenticationType = "rsaKey"
 		configured = true
@@ -205,11 +367,15 @@ func (r *serviceAccountResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 	plan.ID = types.StringValue(created.ID)
+	plan.PublicKeyWO = types.StringNull()
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *serviceAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state serviceAccountResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -230,7 +396,8 @@ func (r *serviceAccountResource) Read(ctx context.Context, req resource.ReadRequ
 	state.ID = types.StringValue(sa.ID)
 	state.Name = types.StringValue(sa.Name)
 	state.Owner = types.StringValue(sa.Owner)
-	if sa.PublicKey != state.PublicKey.ValueString() {
+	// A key supplied through public_key_wo must never be written back to state.
+	if state.PublicKeyWOVersion.IsNull() && sa.PublicKey != state.PublicKey.ValueString() {
 		state.PublicKey = types.StringValue(sa.PublicKey)
 	}
 	if sa.CredentialLifetime != state.CredentialLifetime.ValueInt32() {
@@ -248,6 +415,12 @@ func (r *serviceAccountResource) Read(ctx context.Context, req resource.ReadRequ
 	if sa.Subject != state.Subject.ValueString() {
 		state.Subject = types.StringValue(sa.Subject)
 	}
+	if !sameStringSet(sa.Applications, stringsFromValues(state.Applications)) {
+		state.Applications = nil
+		if len(sa.Applications) > 0 {
+			state.Applications = valuesFromStrings(sa.Applications)
+		}
+	}
 
 	scopes := []types.String{}
 	for _, v := range sa.Scopes {
@@ -257,9 +430,12 @@ func (r *serviceAccountResource) Read(ctx context.Context, req resource.ReadRequ
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 }
 
 func (r *serviceAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan, state serviceAccountResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -272,6 +448,11 @@ func (r *serviceAccountResource) Update(ctx context.Context, req resource.Update
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	diags = req.Config.GetAttribute(ctx, path.Root("public_key_wo"), &plan.PublicKeyWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	scopes := []string{}
 	for _, v := range plan.Scopes {
 		scopes = append(scopes, v.ValueString())
@@ -286,8 +467,12 @@ func (r *serviceAccountResource) Update(ctx context.Context, req resource.Update
 
 	configured := false
 	// Agent type
-	if plan.PublicKey.ValueString() != "" || plan.CredentialLifetime.ValueInt32() > 0 {
+	if plan.PublicKey.ValueString() != "" || plan.PublicKeyWO.ValueString() != "" || plan.CredentialLifetime.ValueInt32() > 0 {
 		serviceAccount.PublicKey = plan.PublicKey.ValueString()
+		// The write-only key is only sent when its version changes, as the prior value isn't available to compare.
+		if plan.PublicKeyWO.ValueString() != "" && !plan.PublicKeyWOVersion.Equal(state.PublicKeyWOVersion) {
+			serviceAccount.PublicKey = plan.PublicKeyWO.ValueString()
+		}
 		serviceAccount.CredentialLifetime = plan.CredentialLifetime.ValueInt32()
 		serviceAccount.AuthenticationType = "rsaKey"
 		configured = true
@@ -336,11 +521,15 @@ func (r *serviceAccountResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 	plan.ID = state.ID
+	plan.PublicKeyWO = types.StringNull()
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *serviceAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state serviceAccountResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -360,6 +549,6 @@ func (r *serviceAccountResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *serviceAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -0,0 +1,294 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &serviceAccountWIFResource{}
+	_ resource.ResourceWithConfigure   = &serviceAccountWIFResource{}
+	_ resource.ResourceWithImportState = &serviceAccountWIFResource{}
+	_ resource.ResourceWithIdentity    = &serviceAccountWIFResource{}
+	_ resource.ResourceWithModifyPlan  = &serviceAccountWIFResource{}
+)
+
+type serviceAccountWIFResource struct {
+	client tlspc.API
+}
+
+func NewServiceAccountWIFResource() resource.Resource {
+	return &serviceAccountWIFResource{}
+}
+
+func (r *serviceAccountWIFResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account_wif"
+}
+
+func (r *serviceAccountWIFResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage a service account which authenticates with tokens from an external identity provider, using Workload Identity Federation (WIF)",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the service account",
+			},
+			"owner": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the team that owns this service account",
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+			},
+			"scopes": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: `
+A list of scopes that this service account is authorised for, which are checked against the scopes available from the API when planning. Available options include:
+    * certificate-issuance
+    * kubernetes-discovery
+`,
+			},
+			"jwks_uri": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The URI of the JSON Web Key Set used to verify tokens. Changing it, or the keys served from it, updates the service account in place. To rotate keys without downtime, serve both the old and new keys from it, e.g. using the `jwks` function, until every workload signs tokens with the new key",
+			},
+			"issuer_url": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The issuer of the tokens",
+			},
+			"audience": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The audience the tokens must be issued for",
+			},
+			"subject": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The subject of the tokens",
+			},
+			"applications": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of Applications which this service account is authorised for",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(validators.Uuid()),
+				},
+			},
+		},
+	}
+}
+
+func (r *serviceAccountWIFResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
+func (r *serviceAccountWIFResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type serviceAccountWIFResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	Owner        types.String   `tfsdk:"owner"`
+	Scopes       []types.String `tfsdk:"scopes"`
+	JwksURI      types.String   `tfsdk:"jwks_uri"`
+	IssuerURL    types.String   `tfsdk:"issuer_url"`
+	Audience     types.String   `tfsdk:"audience"`
+	Subject      types.String   `tfsdk:"subject"`
+	Applications []types.String `tfsdk:"applications"`
+}
+
+// serviceAccount returns the service account described by the model. If
+// prior is given, the issuer URL and subject are only included if they've
+// changed from it, as the API rejects updates which repeat them.
+func (m serviceAccountWIFResourceModel) serviceAccount(prior *serviceAccountWIFResourceModel) tlspc.ServiceAccount {
+	sa := tlspc.ServiceAccount{
+		ID:                 m.ID.ValueString(),
+		Name:               m.Name.ValueString(),
+		Owner:              m.Owner.ValueString(),
+		Scopes:             stringsFromValues(m.Scopes),
+		JwksURI:            m.JwksURI.ValueString(),
+		IssuerURL:          m.IssuerURL.ValueString(),
+		Audience:           m.Audience.ValueString(),
+		Subject:            m.Subject.ValueString(),
+		Applications:       stringsFromValues(m.Applications),
+		AuthenticationType: "rsaKeyFederated",
+	}
+	if prior != nil {
+		if m.IssuerURL.Equal(prior.IssuerURL) {
+			sa.IssuerURL = ""
+		}
+		if m.Subject.Equal(prior.Subject) {
+			sa.Subject = ""
+		}
+	}
+	return sa
+}
+
+// refresh updates the model from sa.
+func (m *serviceAccountWIFResourceModel) refresh(sa *tlspc.ServiceAccount) {
+	m.ID = types.StringValue(sa.ID)
+	m.Name = types.StringValue(sa.Name)
+	m.Owner = types.StringValue(sa.Owner)
+	m.Scopes = valuesFromStrings(sa.Scopes)
+	m.JwksURI = types.StringValue(sa.JwksURI)
+	m.IssuerURL = types.StringValue(sa.IssuerURL)
+	m.Audience = types.StringValue(sa.Audience)
+	m.Subject = types.StringValue(sa.Subject)
+	if !sameStringSet(sa.Applications, stringsFromValues(m.Applications)) {
+		m.Applications = nil
+		if len(sa.Applications) > 0 {
+			m.Applications = valuesFromStrings(sa.Applications)
+		}
+	}
+}
+
+func (r *serviceAccountWIFResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkScopes(ctx, r.client, req.Plan, knownServiceAccountScopes)...)
+}
+
+func (r *serviceAccountWIFResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan serviceAccountWIFResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateServiceAccount(plan.serviceAccount(nil))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating serviceAccount",
+			"Could not create serviceAccount, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *serviceAccountWIFResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state serviceAccountWIFResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sa, err := r.client.GetServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Service Account",
+			"Could not read service account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	state.refresh(sa)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
+}
+
+func (r *serviceAccountWIFResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state serviceAccountWIFResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	err := r.client.UpdateServiceAccount(plan.serviceAccount(&state))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating serviceAccount",
+			"Could not update serviceAccount, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
+}
+
+func (r *serviceAccountWIFResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state serviceAccountWIFResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteServiceAccount(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Service Account",
+			"Could not delete Service Account ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *serviceAccountWIFResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
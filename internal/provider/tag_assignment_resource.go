@@ -0,0 +1,261 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &tagAssignmentResource{}
+	_ resource.ResourceWithConfigure   = &tagAssignmentResource{}
+	_ resource.ResourceWithImportState = &tagAssignmentResource{}
+)
+
+type tagAssignmentResource struct {
+	client tlspc.API
+}
+
+func NewTagAssignmentResource() resource.Resource {
+	return &tagAssignmentResource{}
+}
+
+func (r *tagAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag_assignment"
+}
+
+func (r *tagAssignmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assign tag values to a certificate or application. Tags assigned outside of this resource are left untouched. Existing assignments can be imported using an ID of the form `entity_type/entity_id`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"entity_type": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("CERTIFICATE", "APPLICATION"),
+				},
+				MarkdownDescription: `The type of entity to tag, valid options include:
+	* CERTIFICATE
+	* APPLICATION
+`,
+			},
+			"entity_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the certificate or application",
+			},
+			"tags": schema.MapAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "A map of tag name to the value to assign",
+			},
+		},
+	}
+}
+
+func (r *tagAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type tagAssignmentResourceModel struct {
+	ID         types.String            `tfsdk:"id"`
+	EntityType types.String            `tfsdk:"entity_type"`
+	EntityID   types.String            `tfsdk:"entity_id"`
+	Tags       map[string]types.String `tfsdk:"tags"`
+}
+
+// tagAssignments converts a map of tag names to values into the "name:value"
+// form used by the API.
+func tagAssignments(tags map[string]types.String) []string {
+	out := []string{}
+	for k, v := range tags {
+		out = append(out, k+":"+v.ValueString())
+	}
+	return out
+}
+
+func (r *tagAssignmentResource) assign(action string, model tagAssignmentResourceModel, tags []string) error {
+	return r.client.AssignTags(tlspc.TagAssignment{
+		Action:       action,
+		EntityIDs:    []string{model.EntityID.ValueString()},
+		EntityType:   model.EntityType.ValueString(),
+		TargetedTags: tags,
+	})
+}
+
+func (r *tagAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan tagAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.assign("ADD", plan, tagAssignments(plan.Tags))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Tag Assignment",
+			"Could not create Tag Assignment, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(plan.EntityType.ValueString() + "/" + plan.EntityID.ValueString())
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *tagAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state tagAssignmentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assigned, err := r.client.GetAssignedTags(state.EntityType.ValueString(), state.EntityID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Tag Assignment",
+			"Could not read Tag Assignment ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Only track the tags managed by this resource, or all of them when importing
+	importing := state.Tags == nil
+	tags := map[string]types.String{}
+	for _, v := range assigned {
+		name, value, ok := strings.Cut(v, ":")
+		if !ok {
+			continue
+		}
+		if _, managed := state.Tags[name]; managed || importing {
+			tags[name] = types.StringValue(value)
+		}
+	}
+	state.Tags = tags
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *tagAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state tagAssignmentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removed := map[string]types.String{}
+	for k, v := range state.Tags {
+		if pv, ok := plan.Tags[k]; !ok || !pv.Equal(v) {
+			removed[k] = v
+		}
+	}
+	if len(removed) > 0 {
+		err := r.assign("REMOVE", state, tagAssignments(removed))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating Tag Assignment",
+				"Could not remove Tags, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	err := r.assign("ADD", plan, tagAssignments(plan.Tags))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating Tag Assignment",
+			"Could not add Tags, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = state.ID
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *tagAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state tagAssignmentResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.assign("REMOVE", state, tagAssignments(state.Tags))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Tag Assignment",
+			"Could not delete Tag Assignment ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *tagAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID is entity_type/entity_id
+	importStateComposite(ctx, req, resp, "entity_type", "entity_id")
+}
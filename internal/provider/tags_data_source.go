@@ -0,0 +1,139 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &tagsDataSource{}
+	_ datasource.DataSourceWithConfigure = &tagsDataSource{}
+)
+
+// NewTagsDataSource is a helper function to simplify the provider implementation.
+func NewTagsDataSource() datasource.DataSource {
+	return &tagsDataSource{}
+}
+
+// tagsDataSource is the data source implementation.
+type tagsDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *tagsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *tagsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tags"
+}
+
+// Schema defines the schema for the data source.
+func (d *tagsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List the tags defined in the tenant and their allowed values, e.g. to validate tag assignments when planning.",
+		Attributes: map[string]schema.Attribute{
+			"tags": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The tags, in order of name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the tag",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the tag",
+						},
+						"values": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The allowed values of the tag. Empty if any value is allowed",
+						},
+					},
+				},
+			},
+			"values": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "The allowed values of each tag, keyed by tag name",
+			},
+		},
+	}
+}
+
+type tagsDataSourceModel struct {
+	Tags   []tagsDataSourceTag       `tfsdk:"tags"`
+	Values map[string][]types.String `tfsdk:"values"`
+}
+
+type tagsDataSourceTag struct {
+	ID     types.String   `tfsdk:"id"`
+	Name   types.String   `tfsdk:"name"`
+	Values []types.String `tfsdk:"values"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *tagsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state tagsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags, err := d.client.GetTags()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving tags",
+			fmt.Sprintf("Error retrieving tags: %s", err.Error()),
+		)
+		return
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	state.Tags = []tagsDataSourceTag{}
+	state.Values = map[string][]types.String{}
+	for _, t := range tags {
+		values := valuesFromStrings(t.Values)
+		state.Tags = append(state.Tags, tagsDataSourceTag{
+			ID:     types.StringValue(t.ID),
+			Name:   types.StringValue(t.Name),
+			Values: values,
+		})
+		state.Values[t.Name] = values
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -31,7 +31,7 @@ func NewTeamDataSource() datasource.DataSource {
 
 // teamDataSource is the data source implementation.
 type teamDataSource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 // Configure adds the provider configured client to the data source.
@@ -42,11 +42,11 @@ func (d *teamDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -0,0 +1,181 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &teamMembersDataSource{}
+	_ datasource.DataSourceWithConfigure = &teamMembersDataSource{}
+)
+
+// NewTeamMembersDataSource is a helper function to simplify the provider implementation.
+func NewTeamMembersDataSource() datasource.DataSource {
+	return &teamMembersDataSource{}
+}
+
+// teamMembersDataSource is the data source implementation.
+type teamMembersDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *teamMembersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *teamMembersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_members"
+}
+
+// Schema defines the schema for the data source.
+func (d *teamMembersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	teamUser := schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user",
+			},
+			"username": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The username of the user",
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up the members and owners of a team by ID or name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Team ID, exactly one of `id` or `name` must be set",
+				Validators: []validator.String{
+					validators.Uuid(),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Team name, exactly one of `id` or `name` must be set",
+			},
+			"owners": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The owners of the team",
+				NestedObject:        teamUser,
+			},
+			"members": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The members of the team",
+				NestedObject:        teamUser,
+			},
+		},
+	}
+}
+
+type teamMembersDataSourceModel struct {
+	ID      types.String    `tfsdk:"id"`
+	Name    types.String    `tfsdk:"name"`
+	Owners  []teamUserModel `tfsdk:"owners"`
+	Members []teamUserModel `tfsdk:"members"`
+}
+
+type teamUserModel struct {
+	ID       types.String `tfsdk:"id"`
+	Username types.String `tfsdk:"username"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *teamMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state teamMembersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var team *tlspc.Team
+	var err error
+	if !state.ID.IsNull() {
+		team, err = d.client.GetTeam(state.ID.ValueString())
+	} else {
+		team, err = d.client.GetTeamByName(state.Name.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving team",
+			fmt.Sprintf("Error retrieving team: %s", err.Error()),
+		)
+		return
+	}
+	state.ID = types.StringValue(team.ID)
+	state.Name = types.StringValue(team.Name)
+
+	// Owners are usually members too, so only look each user up once.
+	users := map[string]teamUserModel{}
+	lookup := func(ids []string) ([]teamUserModel, error) {
+		out := []teamUserModel{}
+		for _, id := range ids {
+			if _, ok := users[id]; !ok {
+				user, err := d.client.GetUserByID(id)
+				if err != nil {
+					return nil, err
+				}
+				users[id] = teamUserModel{
+					ID:       types.StringValue(user.ID),
+					Username: types.StringValue(user.Username),
+				}
+			}
+			out = append(out, users[id])
+		}
+		return out, nil
+	}
+
+	state.Owners, err = lookup(team.Owners)
+	if err == nil {
+		state.Members, err = lookup(team.Members)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving team members",
+			fmt.Sprintf("Error retrieving team members: %s", err.Error()),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
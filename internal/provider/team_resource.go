@@ -6,7 +6,9 @@ package provider
 import (
 	"context"
 	"fmt"
-	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 
 	"terraform-provider-tlspc/internal/tlspc"
 	"terraform-provider-tlspc/internal/validators"
@@ -16,20 +18,33 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Limits on user_matching_rules, checked at plan time rather than leaving
+// the API to reject them part way through an apply.
+const (
+	maxUserMatchingRules     = 100
+	maxUserMatchingClaimName = 256
+	maxUserMatchingRuleValue = 1024
+)
+
+// trimmedRegex matches strings without leading or trailing whitespace.
+var trimmedRegex = regexp.MustCompile(`^\S(.*\S)?$`)
+
 var (
 	_ resource.Resource                = &teamResource{}
 	_ resource.ResourceWithConfigure   = &teamResource{}
 	_ resource.ResourceWithImportState = &teamResource{}
+	_ resource.ResourceWithIdentity    = &teamResource{}
 )
 
 type teamResource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 func NewTeamResource() resource.Resource {
@@ -55,6 +70,9 @@ func (r *teamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"role": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("SYSTEM_ADMIN", "PKI_ADMIN", "PLATFORM_ADMIN", "RESOURCE_OWNER", "GUEST"),
+				},
 				MarkdownDescription: `Role of team, valid options include:
     * SYSTEM_ADMIN
     * PKI_ADMIN
@@ -65,19 +83,33 @@ func (r *teamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"owners": schema.SetAttribute{
 				Required:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "List of user ids",
+				MarkdownDescription: "List of user ids. A team must have at least one owner",
 				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
 					setvalidator.ValueStringsAre(validators.Uuid()),
 				},
 			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "When destroying the team, first delete the service accounts it owns, and remove it from the owners of applications, deleting those it's the only owner of. Otherwise a team which owns any can't be destroyed. Defaults to false",
+			},
 			"user_matching_rules": schema.SetNestedAttribute{
 				Optional:            true,
 				MarkdownDescription: "List of rules to add members via SSO claims. Please refer to the [documentation](https://docs.venafi.cloud/vcs-platform/r-team-membership-rule-guidelines/) for detailed rule configuration.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtMost(maxUserMatchingRules),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"claim_name": schema.StringAttribute{
 							Required:            true,
 							MarkdownDescription: "The SSO property that this rule acts on",
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, maxUserMatchingClaimName),
+								stringvalidator.RegexMatches(trimmedRegex, "must not start or end with whitespace"),
+							},
 						},
 						"operator": schema.StringAttribute{
 							Required: true,
@@ -95,6 +127,10 @@ func (r *teamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						"value": schema.StringAttribute{
 							Required:            true,
 							MarkdownDescription: "The value to check for",
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, maxUserMatchingRuleValue),
+								stringvalidator.RegexMatches(trimmedRegex, "must not start or end with whitespace"),
+							},
 						},
 					},
 				},
@@ -103,17 +139,21 @@ func (r *teamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	}
 }
 
+func (r *teamResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema
+}
+
 func (r *teamResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -127,6 +167,7 @@ type teamResourceModel struct {
 	Name              types.String       `tfsdk:"name"`
 	Role              types.String       `tfsdk:"role"`
 	Owners            []types.String     `tfsdk:"owners"`
+	ForceDestroy      types.Bool         `tfsdk:"force_destroy"`
 	UserMatchingRules []userMatchingRule `tfsdk:"user_matching_rules"`
 }
 
@@ -136,7 +177,65 @@ type userMatchingRule struct {
 	Value     types.String `tfsdk:"value"`
 }
 
+// userMatchingRules returns the user matching rules to send to the API.
+func (m teamResourceModel) userMatchingRules() []tlspc.UserMatchingRule {
+	umr := []tlspc.UserMatchingRule{}
+	for _, v := range m.UserMatchingRules {
+		umr = append(umr, tlspc.UserMatchingRule{
+			ClaimName: v.ClaimName.ValueString(),
+			Operator:  v.Operator.ValueString(),
+			Value:     v.Value.ValueString(),
+		})
+	}
+	return umr
+}
+
+// sameUserMatchingRules reports whether a and b contain the same rules,
+// ignoring order and duplicates.
+func sameUserMatchingRules(a, b []tlspc.UserMatchingRule) bool {
+	for _, v := range b {
+		if !slices.Contains(a, v) {
+			return false
+		}
+	}
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// refresh updates the model from team. The API may list owners and rules
+// more than once, so they're deduplicated to fit into sets, and rules are
+// left unset if there are none, as they are in configuration.
+func (m *teamResourceModel) refresh(team *tlspc.Team) {
+	m.ID = types.StringValue(team.ID)
+	m.Name = types.StringValue(team.Name)
+	m.Role = types.StringValue(team.Role)
+
+	owners := slices.Clone(team.Owners)
+	slices.Sort(owners)
+	m.Owners = valuesFromStrings(slices.Compact(owners))
+
+	m.UserMatchingRules = nil
+	seen := map[tlspc.UserMatchingRule]bool{}
+	for _, v := range team.UserMatchingRules {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		m.UserMatchingRules = append(m.UserMatchingRules, userMatchingRule{
+			ClaimName: types.StringValue(v.ClaimName),
+			Operator:  types.StringValue(v.Operator),
+			Value:     types.StringValue(v.Value),
+		})
+	}
+}
+
 func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var plan teamResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -149,21 +248,12 @@ func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, r
 		owners = append(owners, v.ValueString())
 	}
 
-	umr := []tlspc.UserMatchingRule{}
-	for _, v := range plan.UserMatchingRules {
-		umr = append(umr, tlspc.UserMatchingRule{
-			ClaimName: v.ClaimName.ValueString(),
-			Operator:  v.Operator.ValueString(),
-			Value:     v.Value.ValueString(),
-		})
-	}
-
 	team := tlspc.Team{
 		Name:              plan.Name.ValueString(),
 		Role:              plan.Role.ValueString(),
 		Owners:            owners,
 		Members:           []string{},
-		UserMatchingRules: umr,
+		UserMatchingRules: plan.userMatchingRules(),
 	}
 
 	created, err := r.client.CreateTeam(team)
@@ -177,9 +267,12 @@ func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.ID = types.StringValue(created.ID)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state teamResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -197,34 +290,19 @@ func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	state.ID = types.StringValue(team.ID)
-	state.Name = types.StringValue(team.Name)
-	state.Role = types.StringValue(team.Role)
-
-	owners := []types.String{}
-	for _, v := range team.Owners {
-		owners = append(owners, types.StringValue(v))
-	}
-	state.Owners = owners
-
-	umr := []userMatchingRule{}
-	for _, v := range team.UserMatchingRules {
-		umr = append(umr, userMatchingRule{
-			ClaimName: types.StringValue(v.ClaimName),
-			Operator:  types.StringValue(v.Operator),
-			Value:     types.StringValue(v.Value),
-		})
-	}
-
-	if len(umr) > 0 {
-		state.UserMatchingRules = umr
+	state.refresh(team)
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
 	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, state.ID)...)
 }
 
 func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state, plan teamResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -238,15 +316,8 @@ func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	if state.Name != plan.Name || state.Role != plan.Role || !reflect.DeepEqual(state.UserMatchingRules, plan.UserMatchingRules) {
-		umr := []tlspc.UserMatchingRule{}
-		for _, v := range plan.UserMatchingRules {
-			umr = append(umr, tlspc.UserMatchingRule{
-				ClaimName: v.ClaimName.ValueString(),
-				Operator:  v.Operator.ValueString(),
-				Value:     v.Value.ValueString(),
-			})
-		}
+	umr := plan.userMatchingRules()
+	if state.Name != plan.Name || state.Role != plan.Role || !sameUserMatchingRules(state.userMatchingRules(), umr) {
 		team := tlspc.Team{
 			ID:                state.ID.ValueString(),
 			Name:              plan.Name.ValueString(),
@@ -262,42 +333,12 @@ func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			return
 		}
 	}
-	stateOwners := map[string]bool{}
-	planOwners := map[string]bool{}
-	for _, v := range state.Owners {
-		stateOwners[v.ValueString()] = true
-	}
-	for _, v := range plan.Owners {
-		planOwners[v.ValueString()] = true
-	}
-	addOwners := []string{}
-	removeOwners := []string{}
-	for k := range stateOwners {
-		if _, exists := planOwners[k]; !exists {
-			removeOwners = append(removeOwners, k)
-		}
-	}
-	for k := range planOwners {
-		if _, exists := stateOwners[k]; !exists {
-			addOwners = append(addOwners, k)
-		}
-	}
-	if len(addOwners) > 0 {
-		_, err := r.client.AddTeamOwners(state.ID.ValueString(), addOwners)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Updating Team",
-				"Could not update team ID "+state.ID.ValueString()+": "+err.Error(),
-			)
-			return
-		}
-	}
-	if len(removeOwners) > 0 {
-		_, err := r.client.RemoveTeamOwners(state.ID.ValueString(), removeOwners)
+	if !sameStringSet(stringsFromValues(state.Owners), stringsFromValues(plan.Owners)) {
+		_, err := r.client.SetTeamOwners(state.ID.ValueString(), stringsFromValues(plan.Owners))
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating Team",
-				"Could not update team ID "+state.ID.ValueString()+": "+err.Error(),
+				"Could not update owners of team ID "+state.ID.ValueString()+": "+err.Error(),
 			)
 			return
 		}
@@ -306,9 +347,12 @@ func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.ID = state.ID
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setIDIdentity(ctx, resp.Identity, plan.ID)...)
 }
 
 func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
 	var state teamResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -317,7 +361,32 @@ func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DeleteTeam(state.ID.ValueString())
+	deps, err := r.client.GetTeamDependents(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Team",
+			"Could not check what team ID "+state.ID.ValueString()+" owns: "+err.Error(),
+		)
+		return
+	}
+	if !deps.Empty() {
+		if !state.ForceDestroy.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Team owns other objects",
+				fmt.Sprintf("Team ID %s can't be deleted while it owns:\n%s\nChange their owner, or set force_destroy to remove them when the team is destroyed.", state.ID.ValueString(), describeTeamDependents(deps)),
+			)
+			return
+		}
+		if err := r.removeDependents(state.ID.ValueString(), deps); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Deleting Team",
+				"Could not remove the objects owned by team ID "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	err = r.client.DeleteTeam(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Team",
@@ -327,7 +396,71 @@ func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// describeTeamDependents lists the objects owned by a team, one per line.
+func describeTeamDependents(deps *tlspc.TeamDependents) string {
+	var b strings.Builder
+	for _, sa := range deps.ServiceAccounts {
+		fmt.Fprintf(&b, "  - service account %q (%s)\n", sa.Name, sa.ID)
+	}
+	for _, app := range deps.Applications {
+		fmt.Fprintf(&b, "  - application %q (%s)\n", app.Name, app.ID)
+	}
+	return b.String()
+}
+
+// removeDependents deletes the service accounts owned by team id, and removes
+// it from the owners of applications, deleting those it's the only owner of.
+func (r *teamResource) removeDependents(id string, deps *tlspc.TeamDependents) error {
+	for _, sa := range deps.ServiceAccounts {
+		if err := r.client.DeleteServiceAccount(sa.ID); err != nil {
+			return fmt.Errorf("deleting service account %s: %w", sa.ID, err)
+		}
+	}
+	for _, dep := range deps.Applications {
+		app, err := r.client.GetApplication(dep.ID)
+		if err != nil {
+			return fmt.Errorf("reading application %s: %w", dep.ID, err)
+		}
+		owners := slices.DeleteFunc(app.Owners, func(o tlspc.OwnerAndType) bool { return o.ID == id })
+		if len(owners) == 0 {
+			if err := r.client.DeleteApplication(app.ID); err != nil {
+				return fmt.Errorf("deleting application %s: %w", app.ID, err)
+			}
+			continue
+		}
+		app.Owners = owners
+		if _, err := r.client.UpdateApplication(*app); err != nil {
+			return fmt.Errorf("updating owners of application %s: %w", app.ID, err)
+		}
+	}
+	return nil
+}
+
 func (r *teamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read the whole team, so that an unknown ID fails the import and the
+	// state matches what a configuration for it would plan
+	var id types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	team, err := r.client.GetTeam(id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing Team",
+			"Could not read team ID "+id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	state := teamResourceModel{ForceDestroy: types.BoolValue(false)}
+	state.refresh(team)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
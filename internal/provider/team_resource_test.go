@@ -0,0 +1,62 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testTeamID = "2d3f0b4e-6a3c-4c8e-9f1a-7b5d6e8c9a01"
+
+func TestTeamDelete(t *testing.T) {
+	cases := []struct {
+		name       string
+		dependents *tlspc.TeamDependents
+		wantError  bool
+	}{
+		{name: "no dependents"},
+		{
+			name: "owns service account",
+			dependents: &tlspc.TeamDependents{
+				ServiceAccounts: []tlspc.ServiceAccount{{ID: "sa-id", Name: "agent"}},
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakeAPI{dependents: map[string]*tlspc.TeamDependents{}}
+			if tc.dependents != nil {
+				client.dependents[testTeamID] = tc.dependents
+			}
+			r := &teamResource{client: client}
+
+			req := resource.DeleteRequest{
+				State: newState(t, r, teamResourceModel{
+					ID:           types.StringValue(testTeamID),
+					Name:         types.StringValue("team"),
+					Role:         types.StringValue("PLATFORM_ADMIN"),
+					Owners:       []types.String{},
+					ForceDestroy: types.BoolValue(false),
+				}),
+			}
+			var resp resource.DeleteResponse
+			r.Delete(context.Background(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tc.wantError {
+				t.Fatalf("unexpected error state %t: %v", got, resp.Diagnostics)
+			}
+			if deleted := len(client.deletedTeams) > 0; deleted == tc.wantError {
+				t.Errorf("unexpected team deletion %t", deleted)
+			}
+		})
+	}
+}
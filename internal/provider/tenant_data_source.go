@@ -24,7 +24,7 @@ func NewTenantDataSource() datasource.DataSource {
 }
 
 type TenantDataSource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 // Configure adds the provider configured client to the data source.
@@ -35,11 +35,11 @@ func (d *TenantDataSource) Configure(_ context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -75,15 +75,80 @@ func (d *TenantDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Computed:            true,
 				MarkdownDescription: "The domain list associated with the TLS Protect Cloud Tenant",
 			},
+			"company_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the TLS Protect Cloud Tenant",
+			},
+			"active": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the TLS Protect Cloud Tenant is active",
+			},
+			"region": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The region of the TLS Protect Cloud Tenant, e.g. `US` or `EU`, based on the API endpoint. Empty if the endpoint isn't a known regional endpoint",
+			},
+			"products": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "The labels of the products the TLS Protect Cloud Tenant is entitled to",
+			},
+			"entitlements": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The product entitlements of the TLS Protect Cloud Tenant",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"label": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The label of the product",
+						},
+						"capabilities": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The capabilities of the product",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The name of the capability",
+									},
+									"expiry_date": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The date the capability expires",
+									},
+									"is_trial": schema.BoolAttribute{
+										Computed:            true,
+										MarkdownDescription: "Whether the capability is a trial",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 type tenantDataSourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	URLPrefix types.String `tfsdk:"url_prefix"`
-	Domains   types.List   `tfsdk:"domains"`
+	ID           types.String       `tfsdk:"id"`
+	Name         types.String       `tfsdk:"name"`
+	URLPrefix    types.String       `tfsdk:"url_prefix"`
+	Domains      types.List         `tfsdk:"domains"`
+	CompanyType  types.String       `tfsdk:"company_type"`
+	Active       types.Bool         `tfsdk:"active"`
+	Region       types.String       `tfsdk:"region"`
+	Products     []types.String     `tfsdk:"products"`
+	Entitlements []entitlementModel `tfsdk:"entitlements"`
+}
+
+type entitlementModel struct {
+	Label        types.String      `tfsdk:"label"`
+	Capabilities []capabilityModel `tfsdk:"capabilities"`
+}
+
+type capabilityModel struct {
+	Name       types.String `tfsdk:"name"`
+	ExpiryDate types.String `tfsdk:"expiry_date"`
+	IsTrial    types.Bool   `tfsdk:"is_trial"`
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -112,6 +177,27 @@ func (d *TenantDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	model.CompanyType = types.StringValue(userAccount.Company.CompanyType)
+	model.Active = types.BoolValue(userAccount.Company.Active)
+	model.Region = types.StringValue(d.client.Region())
+
+	model.Products = []types.String{}
+	model.Entitlements = []entitlementModel{}
+	for _, e := range userAccount.Company.ProductEntitlements {
+		capabilities := []capabilityModel{}
+		for _, c := range e.Capabilities {
+			capabilities = append(capabilities, capabilityModel{
+				Name:       types.StringValue(c.Name),
+				ExpiryDate: types.StringValue(c.ProductExpiryDate),
+				IsTrial:    types.BoolValue(c.IsTrial),
+			})
+		}
+		model.Products = append(model.Products, types.StringValue(e.Label))
+		model.Entitlements = append(model.Entitlements, entitlementModel{
+			Label:        types.StringValue(e.Label),
+			Capabilities: capabilities,
+		})
+	}
 
 	diags = resp.State.Set(ctx, &model)
 	resp.Diagnostics.Append(diags...)
@@ -8,9 +8,11 @@ import (
 	"fmt"
 
 	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -27,7 +29,7 @@ func NewUserDataSource() datasource.DataSource {
 
 // userDataSource is the data source implementation.
 type userDataSource struct {
-	client *tlspc.Client
+	client tlspc.API
 }
 
 // Configure adds the provider configured client to the data source.
@@ -38,11 +40,11 @@ func (d *userDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*tlspc.Client)
+	client, ok := req.ProviderData.(tlspc.API)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tlspc.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -59,22 +61,36 @@ func (d *userDataSource) Metadata(_ context.Context, req datasource.MetadataRequ
 // Schema defines the schema for the data source.
 func (d *userDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Look up a user id by email address",
+		MarkdownDescription: "Look up a user id by email address. The email address must match the username exactly; if more than one user has it, set `status` to choose between them.",
 		Attributes: map[string]schema.Attribute{
 			"email": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "User email address",
+				Validators: []validator.String{
+					validators.Email(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Only match a user with this status, e.g. ACTIVE. If unset, this is the status of the matching user",
 			},
 			"id": schema.StringAttribute{
 				Computed: true,
 			},
+			"username": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The username of the matching user",
+			},
 		},
 	}
 }
 
 type userDataSourceModel struct {
-	ID    types.String `tfsdk:"id"`
-	Email types.String `tfsdk:"email"`
+	ID       types.String `tfsdk:"id"`
+	Email    types.String `tfsdk:"email"`
+	Status   types.String `tfsdk:"status"`
+	Username types.String `tfsdk:"username"`
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -86,7 +102,7 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	user, err := d.client.GetUser(model.Email.ValueString())
+	user, err := d.client.GetUser(model.Email.ValueString(), model.Status.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error retrieving user",
@@ -95,6 +111,8 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 	model.ID = types.StringValue(user.ID)
+	model.Status = types.StringValue(user.Status)
+	model.Username = types.StringValue(user.Username)
 	diags = resp.State.Set(ctx, &model)
 	resp.Diagnostics.Append(diags...)
 }
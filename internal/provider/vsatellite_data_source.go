@@ -0,0 +1,115 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &vsatelliteDataSource{}
+	_ datasource.DataSourceWithConfigure = &vsatelliteDataSource{}
+)
+
+// NewVSatelliteDataSource is a helper function to simplify the provider implementation.
+func NewVSatelliteDataSource() datasource.DataSource {
+	return &vsatelliteDataSource{}
+}
+
+// vsatelliteDataSource is the data source implementation.
+type vsatelliteDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vsatelliteDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *vsatelliteDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vsatellite"
+}
+
+// Schema defines the schema for the data source.
+func (d *vsatelliteDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up a VSatellite by name",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the VSatellite",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the VSatellite",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the VSatellite",
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version of the VSatellite software",
+			},
+		},
+	}
+}
+
+type vsatelliteDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Status  types.String `tfsdk:"status"`
+	Version types.String `tfsdk:"version"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *vsatelliteDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model vsatelliteDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vs, err := d.client.GetVSatelliteByName(model.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving VSatellite",
+			fmt.Sprintf("Error retrieving VSatellite: %s", err.Error()),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(vs.ID)
+	model.Status = types.StringValue(vs.Status)
+	model.Version = types.StringValue(vs.Version)
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,238 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &vsatelliteHealthDataSource{}
+	_ datasource.DataSourceWithConfigure = &vsatelliteHealthDataSource{}
+)
+
+// defaultMaxHeartbeatAge is how recently a VSatellite or worker must have
+// sent a heartbeat to be healthy, if max_heartbeat_age isn't set.
+const defaultMaxHeartbeatAge = "10m"
+
+// NewVSatelliteHealthDataSource is a helper function to simplify the provider implementation.
+func NewVSatelliteHealthDataSource() datasource.DataSource {
+	return &vsatelliteHealthDataSource{}
+}
+
+// vsatelliteHealthDataSource is the data source implementation.
+type vsatelliteHealthDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vsatelliteHealthDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *vsatelliteHealthDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vsatellite_health"
+}
+
+// Schema defines the schema for the data source.
+func (d *vsatelliteHealthDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Check the health of a VSatellite and its workers, e.g. to make sure it's working before creating resources which depend on it. A VSatellite or worker is healthy if it's active and has sent a heartbeat within `max_heartbeat_age`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the VSatellite. Exactly one of `id` and `name` must be set",
+				Validators: []validator.String{
+					validators.Uuid(),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The name of the VSatellite",
+			},
+			"max_heartbeat_age": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How recently the VSatellite and its workers must have sent a heartbeat to be healthy, defaults to `" + defaultMaxHeartbeatAge + "`",
+				Validators: []validator.String{
+					validators.Duration(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the VSatellite",
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version of the VSatellite software",
+			},
+			"last_seen": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the VSatellite last sent a heartbeat",
+			},
+			"healthy": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the VSatellite and all its workers are healthy",
+			},
+			"workers": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The VSatellite's workers",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the worker",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the worker",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The status of the worker",
+						},
+						"last_seen": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the worker last sent a heartbeat",
+						},
+						"healthy": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the worker is healthy",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type vsatelliteHealthDataSourceModel struct {
+	ID              types.String             `tfsdk:"id"`
+	Name            types.String             `tfsdk:"name"`
+	MaxHeartbeatAge types.String             `tfsdk:"max_heartbeat_age"`
+	Status          types.String             `tfsdk:"status"`
+	Version         types.String             `tfsdk:"version"`
+	LastSeen        types.String             `tfsdk:"last_seen"`
+	Healthy         types.Bool               `tfsdk:"healthy"`
+	Workers         []vsatelliteHealthWorker `tfsdk:"workers"`
+}
+
+type vsatelliteHealthWorker struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Status   types.String `tfsdk:"status"`
+	LastSeen types.String `tfsdk:"last_seen"`
+	Healthy  types.Bool   `tfsdk:"healthy"`
+}
+
+// vsatelliteHealthy returns whether a VSatellite or worker with status and
+// lastSeenOn is healthy at now.
+func vsatelliteHealthy(status, lastSeenOn string, maxAge time.Duration, now time.Time) bool {
+	if status != "ACTIVE" {
+		return false
+	}
+	lastSeen, err := time.Parse(time.RFC3339, lastSeenOn)
+	if err != nil {
+		return false
+	}
+	return now.Sub(lastSeen) <= maxAge
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *vsatelliteHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model vsatelliteHealthDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxHeartbeatAge := defaultMaxHeartbeatAge
+	if !model.MaxHeartbeatAge.IsNull() {
+		maxHeartbeatAge = model.MaxHeartbeatAge.ValueString()
+	}
+	maxAge, err := time.ParseDuration(maxHeartbeatAge)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("max_heartbeat_age"), "Invalid Duration", err.Error())
+		return
+	}
+
+	var vs *tlspc.VSatellite
+	if !model.ID.IsNull() {
+		vs, err = d.client.GetVSatellite(model.ID.ValueString())
+	} else {
+		vs, err = d.client.GetVSatelliteByName(model.Name.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving VSatellite",
+			fmt.Sprintf("Error retrieving VSatellite: %s", err.Error()),
+		)
+		return
+	}
+	workers, err := d.client.GetVSatelliteWorkers(vs.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving VSatellite",
+			fmt.Sprintf("Error retrieving VSatellite Workers: %s", err.Error()),
+		)
+		return
+	}
+
+	now := time.Now()
+	healthy := vsatelliteHealthy(vs.Status, vs.LastSeenOn, maxAge, now)
+	model.ID = types.StringValue(vs.ID)
+	model.Name = types.StringValue(vs.Name)
+	model.Status = types.StringValue(vs.Status)
+	model.Version = types.StringValue(vs.Version)
+	model.LastSeen = types.StringValue(vs.LastSeenOn)
+	model.Workers = []vsatelliteHealthWorker{}
+	for _, w := range workers {
+		workerHealthy := vsatelliteHealthy(w.Status, w.LastSeenOn, maxAge, now)
+		healthy = healthy && workerHealthy
+		model.Workers = append(model.Workers, vsatelliteHealthWorker{
+			ID:       types.StringValue(w.ID),
+			Name:     types.StringValue(w.Name),
+			Status:   types.StringValue(w.Status),
+			LastSeen: types.StringValue(w.LastSeenOn),
+			Healthy:  types.BoolValue(workerHealthy),
+		})
+	}
+	model.Healthy = types.BoolValue(healthy)
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &vsatellitePairingCodeEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &vsatellitePairingCodeEphemeralResource{}
+)
+
+type vsatellitePairingCodeEphemeralResource struct {
+	client tlspc.API
+}
+
+func NewVSatellitePairingCodeEphemeralResource() ephemeral.EphemeralResource {
+	return &vsatellitePairingCodeEphemeralResource{}
+}
+
+func (r *vsatellitePairingCodeEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vsatellite_pairing_code"
+}
+
+func (r *vsatellitePairingCodeEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Create a new pairing code for a VSatellite or VSatellite Worker, e.g. to pass to cloud-init or Helm values, without saving it to plans or state (requires Terraform 1.10 or later)",
+		Attributes: map[string]schema.Attribute{
+			"vsatellite_id": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					validators.Uuid(),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("vsatellite_id"), path.MatchRoot("vsatellite_worker_id")),
+				},
+				MarkdownDescription: "The ID of the VSatellite to pair. Exactly one of `vsatellite_id` and `vsatellite_worker_id` must be set",
+			},
+			"vsatellite_worker_id": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the VSatellite Worker to pair",
+			},
+			"pairing_code": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The code used to pair the installation with the registration",
+			},
+			"pairing_code_expiry": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the pairing code expires",
+			},
+		},
+	}
+}
+
+func (r *vsatellitePairingCodeEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type vsatellitePairingCodeEphemeralResourceModel struct {
+	VSatelliteID       types.String `tfsdk:"vsatellite_id"`
+	VSatelliteWorkerID types.String `tfsdk:"vsatellite_worker_id"`
+	PairingCode        types.String `tfsdk:"pairing_code"`
+	PairingCodeExpiry  types.String `tfsdk:"pairing_code_expiry"`
+}
+
+func (r *vsatellitePairingCodeEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var model vsatellitePairingCodeEphemeralResourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var pc *tlspc.PairingCode
+	var err error
+	if !model.VSatelliteWorkerID.IsNull() {
+		pc, err = r.client.CreateVSatelliteWorkerPairingCode(model.VSatelliteWorkerID.ValueString())
+	} else {
+		pc, err = r.client.CreateVSatellitePairingCode(model.VSatelliteID.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Pairing Code",
+			"Could not create pairing code, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	model.PairingCode = types.StringValue(pc.PairingCode)
+	model.PairingCodeExpiry = types.StringValue(pc.ExpiryDate)
+	diags = resp.Result.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
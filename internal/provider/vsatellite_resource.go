@@ -0,0 +1,235 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &vsatelliteResource{}
+	_ resource.ResourceWithConfigure   = &vsatelliteResource{}
+	_ resource.ResourceWithImportState = &vsatelliteResource{}
+)
+
+type vsatelliteResource struct {
+	client tlspc.API
+}
+
+func NewVSatelliteResource() resource.Resource {
+	return &vsatelliteResource{}
+}
+
+func (r *vsatelliteResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vsatellite"
+}
+
+func (r *vsatelliteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Register a VSatellite and obtain the pairing code used to install it",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the VSatellite",
+			},
+			"pairing_code": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The code used to pair the VSatellite installation with this registration",
+			},
+			"pairing_code_expiry": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "When the pairing code expires",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the VSatellite",
+			},
+		},
+	}
+}
+
+func (r *vsatelliteResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type vsatelliteResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	PairingCode       types.String `tfsdk:"pairing_code"`
+	PairingCodeExpiry types.String `tfsdk:"pairing_code_expiry"`
+	Status            types.String `tfsdk:"status"`
+}
+
+func (r *vsatelliteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan vsatelliteResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vs := tlspc.VSatellite{
+		Name: plan.Name.ValueString(),
+	}
+	created, err := r.client.CreateVSatellite(vs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating VSatellite",
+			"Could not create VSatellite, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+	plan.Status = types.StringValue(created.Status)
+
+	// Save the ID before fetching the pairing code so a failure doesn't orphan the registration
+	diags = resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pc, err := r.client.CreateVSatellitePairingCode(created.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating VSatellite pairing code",
+			"Could not create pairing code for VSatellite ID "+created.ID+": "+err.Error(),
+		)
+		return
+	}
+	plan.PairingCode = types.StringValue(pc.PairingCode)
+	plan.PairingCodeExpiry = types.StringValue(pc.ExpiryDate)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *vsatelliteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state vsatelliteResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vs, err := r.client.GetVSatellite(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading VSatellite",
+			"Could not read VSatellite ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(vs.ID)
+	state.Name = types.StringValue(vs.Name)
+	state.Status = types.StringValue(vs.Status)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *vsatelliteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state vsatelliteResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vs := tlspc.VSatellite{
+		ID:   state.ID.ValueString(),
+		Name: plan.Name.ValueString(),
+	}
+	updated, err := r.client.UpdateVSatellite(vs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating VSatellite",
+			"Could not update VSatellite, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Status = types.StringValue(updated.Status)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *vsatelliteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state vsatelliteResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteVSatellite(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting VSatellite",
+			"Could not delete VSatellite ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *vsatelliteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
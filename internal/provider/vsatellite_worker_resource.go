@@ -0,0 +1,250 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tlspc/internal/tlspc"
+	"terraform-provider-tlspc/internal/validators"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &vsatelliteWorkerResource{}
+	_ resource.ResourceWithConfigure   = &vsatelliteWorkerResource{}
+	_ resource.ResourceWithImportState = &vsatelliteWorkerResource{}
+)
+
+type vsatelliteWorkerResource struct {
+	client tlspc.API
+}
+
+func NewVSatelliteWorkerResource() resource.Resource {
+	return &vsatelliteWorkerResource{}
+}
+
+func (r *vsatelliteWorkerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vsatellite_worker"
+}
+
+func (r *vsatelliteWorkerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Register a worker for a VSatellite and obtain the pairing code used to install it",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The ID of this resource",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the VSatellite Worker",
+			},
+			"vsatellite_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.Uuid(),
+				},
+				MarkdownDescription: "The ID of the VSatellite this worker belongs to",
+			},
+			"pairing_code": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "The code used to pair the worker installation with this registration",
+			},
+			"pairing_code_expiry": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				MarkdownDescription: "When the pairing code expires",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the VSatellite Worker",
+			},
+		},
+	}
+}
+
+func (r *vsatelliteWorkerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+type vsatelliteWorkerResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	VSatelliteID      types.String `tfsdk:"vsatellite_id"`
+	PairingCode       types.String `tfsdk:"pairing_code"`
+	PairingCodeExpiry types.String `tfsdk:"pairing_code_expiry"`
+	Status            types.String `tfsdk:"status"`
+}
+
+func (r *vsatelliteWorkerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan vsatelliteWorkerResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	w := tlspc.VSatelliteWorker{
+		Name:         plan.Name.ValueString(),
+		VSatelliteID: plan.VSatelliteID.ValueString(),
+	}
+	created, err := r.client.CreateVSatelliteWorker(w)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating VSatellite Worker",
+			"Could not create VSatellite Worker, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(created.ID)
+	plan.Status = types.StringValue(created.Status)
+
+	// Save the ID before fetching the pairing code so a failure doesn't orphan the worker
+	diags = resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pc, err := r.client.CreateVSatelliteWorkerPairingCode(created.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating VSatellite Worker pairing code",
+			"Could not create pairing code for VSatellite Worker ID "+created.ID+": "+err.Error(),
+		)
+		return
+	}
+	plan.PairingCode = types.StringValue(pc.PairingCode)
+	plan.PairingCodeExpiry = types.StringValue(pc.ExpiryDate)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *vsatelliteWorkerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state vsatelliteWorkerResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	w, err := r.client.GetVSatelliteWorker(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading VSatellite Worker",
+			"Could not read VSatellite Worker ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(w.ID)
+	state.Name = types.StringValue(w.Name)
+	state.VSatelliteID = types.StringValue(w.VSatelliteID)
+	state.Status = types.StringValue(w.Status)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *vsatelliteWorkerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var plan, state vsatelliteWorkerResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	w := tlspc.VSatelliteWorker{
+		ID:   state.ID.ValueString(),
+		Name: plan.Name.ValueString(),
+	}
+	updated, err := r.client.UpdateVSatelliteWorker(w)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating VSatellite Worker",
+			"Could not update VSatellite Worker, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	plan.Status = types.StringValue(updated.Status)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *vsatelliteWorkerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logRequestStats(ctx, r.client, &resp.Diagnostics)
+
+	var state vsatelliteWorkerResourceModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteVSatelliteWorker(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting VSatellite Worker",
+			"Could not delete VSatellite Worker ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *vsatelliteWorkerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-tlspc/internal/tlspc"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultExpiryWarningDays is how long before the API key expires that a
+// warning is raised, unless expiry_warning_days is set.
+const defaultExpiryWarningDays = 30
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &whoamiDataSource{}
+	_ datasource.DataSourceWithConfigure = &whoamiDataSource{}
+)
+
+// NewWhoamiDataSource is a helper function to simplify the provider implementation.
+func NewWhoamiDataSource() datasource.DataSource {
+	return &whoamiDataSource{}
+}
+
+// whoamiDataSource is the data source implementation.
+type whoamiDataSource struct {
+	client tlspc.API
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *whoamiDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(tlspc.API)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected tlspc.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Metadata returns the data source type name.
+func (d *whoamiDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_whoami"
+}
+
+// Schema defines the schema for the data source.
+func (d *whoamiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up the identity behind the API key used to authenticate to the provider. A warning is raised when the API key is close to expiry.",
+		Attributes: map[string]schema.Attribute{
+			"expiry_warning_days": schema.Int32Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Warn when the API key expires within this many days (default %d)", defaultExpiryWarningDays),
+				Validators: []validator.Int32{
+					int32validator.AtLeast(0),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user",
+			},
+			"username": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The username of the user",
+			},
+			"user_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the user",
+			},
+			"company_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the TLS Protect Cloud Tenant the user belongs to",
+			},
+			"system_roles": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The system roles of the user",
+			},
+			"api_key_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the API key",
+			},
+			"api_key_expiry": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date the API key expires",
+			},
+		},
+	}
+}
+
+type whoamiDataSourceModel struct {
+	ExpiryWarningDays types.Int32  `tfsdk:"expiry_warning_days"`
+	ID                types.String `tfsdk:"id"`
+	Username          types.String `tfsdk:"username"`
+	UserType          types.String `tfsdk:"user_type"`
+	CompanyID         types.String `tfsdk:"company_id"`
+	SystemRoles       types.List   `tfsdk:"system_roles"`
+	APIKeyStatus      types.String `tfsdk:"api_key_status"`
+	APIKeyExpiry      types.String `tfsdk:"api_key_expiry"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *whoamiDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model whoamiDataSourceModel
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userAccount, err := d.client.GetUserAccounts()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error retrieving user account",
+			fmt.Sprintf("Error retrieving user account: %s", err.Error()),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(userAccount.User.ID)
+	model.Username = types.StringValue(userAccount.User.Username)
+	model.UserType = types.StringValue(userAccount.User.UserType)
+	model.CompanyID = types.StringValue(userAccount.Company.ID)
+	model.APIKeyStatus = types.StringValue(userAccount.APIKey.APIKeyStatus)
+	model.APIKeyExpiry = types.StringValue(userAccount.APIKey.ValidityEndDate)
+	model.SystemRoles, diags = types.ListValueFrom(ctx, types.StringType, userAccount.User.SystemRoles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	warningDays := int32(defaultExpiryWarningDays)
+	if !model.ExpiryWarningDays.IsNull() {
+		warningDays = model.ExpiryWarningDays.ValueInt32()
+	}
+	if expiry, err := time.Parse(time.RFC3339, userAccount.APIKey.ValidityEndDate); err == nil {
+		if remaining := time.Until(expiry); remaining < time.Duration(warningDays)*24*time.Hour {
+			resp.Diagnostics.AddWarning(
+				"API key expires soon",
+				fmt.Sprintf("The API key for %s expires on %s; replace it before then to avoid failures.", userAccount.User.Username, userAccount.APIKey.ValidityEndDate),
+			)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import "context"
+
+// API is the part of the Client used by the provider's resources and data
+// sources. It's made up of an interface per area of the API, so that code
+// which only uses one area can depend on just that, and so that fakes can be
+// used in tests instead of an HTTP server.
+type API interface {
+	InfoAPI
+	UsersAPI
+	TeamsAPI
+	ServiceAccountsAPI
+	PluginsAPI
+	CertificateAuthoritiesAPI
+	CertificateTemplatesAPI
+	ApplicationsAPI
+	FireflyAPI
+	CloudProvidersAPI
+	VSatellitesAPI
+	TagsAPI
+	MachinesAPI
+	CertificatesAPI
+	ActivityLogAPI
+	ApprovalWorkflowsAPI
+
+	Poll(ctx context.Context, opts PollOptions, check func(context.Context) (bool, error)) error
+}
+
+var _ API = &Client{}
+
+// InfoAPI describes the API a Client is using, and its use of it.
+type InfoAPI interface {
+	Endpoint() string
+	Region() string
+	Stats() Stats
+	LowRateLimitQuota() (RateLimitQuota, bool)
+}
+
+type UsersAPI interface {
+	GetUser(email, status string) (*User, error)
+	GetUsers(email string) ([]User, error)
+	GetUserByID(id string) (*User, error)
+	GetUserAccounts() (*UserAccountResponse, error)
+}
+
+type TeamsAPI interface {
+	CreateTeam(team Team) (*Team, error)
+	GetTeam(id string) (*Team, error)
+	GetTeamByName(name string) (*Team, error)
+	UpdateTeam(team Team) (*Team, error)
+	AddTeamOwners(id string, owners []string) (*Team, error)
+	RemoveTeamOwners(id string, owners []string) (*Team, error)
+	SetTeamOwners(id string, owners []string) (*Team, error)
+	GetTeamDependents(id string) (*TeamDependents, error)
+	DeleteTeam(id string) error
+}
+
+type ServiceAccountsAPI interface {
+	CreateServiceAccount(sa ServiceAccount) (*ServiceAccount, error)
+	GetServiceAccount(id string) (*ServiceAccount, error)
+	GetServiceAccounts() ([]ServiceAccount, error)
+	UpdateServiceAccount(sa ServiceAccount) error
+	RotateServiceAccountCredentials(id string) (*ServiceAccount, error)
+	DeleteServiceAccount(id string) error
+	GetServiceAccountScopes() ([]string, error)
+}
+
+type PluginsAPI interface {
+	CreatePlugin(p Plugin) (*Plugin, error)
+	GetPlugin(id string) (*Plugin, error)
+	GetPlugins(pluginType string) ([]Plugin, error)
+	GetPluginByName(pluginType, name string) (*Plugin, error)
+	UpdatePlugin(p Plugin) error
+	DeletePlugin(id string) error
+}
+
+type CertificateAuthoritiesAPI interface {
+	GetCAProductOption(kind, name, option string) (*CAProductOption, *CAAccount, error)
+	GetCAProductOptionByID(kind, option_id string) (*CAProductOption, error)
+}
+
+type CertificateTemplatesAPI interface {
+	CreateCertificateTemplate(ct CertificateTemplate) (*CertificateTemplate, error)
+	GetCertificateTemplate(id string) (*CertificateTemplate, error)
+	GetCertTemplates() ([]CertificateTemplate, error)
+	UpdateCertificateTemplate(ct CertificateTemplate) (*CertificateTemplate, error)
+	DeleteCertificateTemplate(id string) error
+}
+
+type ApplicationsAPI interface {
+	CreateApplication(app Application) (*Application, error)
+	GetApplication(id string) (*Application, error)
+	GetApplicationByName(name string) (*Application, error)
+	GetApplications() ([]Application, error)
+	UpdateApplication(app Application) (*Application, error)
+	ModifyApplication(id string, update func(*Application)) (*Application, error)
+	DeleteApplication(id string) error
+}
+
+type FireflyAPI interface {
+	CreateFireflyConfig(ff FireflyConfig) (*FireflyConfig, error)
+	GetFireflyConfig(id string) (*FireflyConfig, error)
+	UpdateFireflyConfig(ff FireflyConfig) (*FireflyConfig, error)
+	DeleteFireflyConfig(id string) error
+
+	CreateFireflySubCAProvider(ff FireflySubCAProvider) (*FireflySubCAProvider, error)
+	GetFireflySubCAProvider(id string) (*FireflySubCAProvider, error)
+	UpdateFireflySubCAProvider(ff FireflySubCAProvider) (*FireflySubCAProvider, error)
+	DeleteFireflySubCAProvider(id string) error
+
+	CreateFireflyPolicy(ff FireflyPolicy) (*FireflyPolicy, error)
+	GetFireflyPolicy(id string) (*FireflyPolicy, error)
+	GetFireflyPolicyByName(name string) (*FireflyPolicy, error)
+	UpdateFireflyPolicy(ff FireflyPolicy) (*FireflyPolicy, error)
+	DeleteFireflyPolicy(id string) error
+}
+
+type CloudProvidersAPI interface {
+	CreateCloudProviderGCP(ctx context.Context, p CloudProviderGCP) (*CloudProviderGCP, error)
+	GetCloudProviderGCP(ctx context.Context, id string) (*CloudProviderGCP, error)
+	UpdateCloudProviderGCP(ctx context.Context, p CloudProviderGCP) (*CloudProviderGCP, error)
+	DeleteCloudProviderGCP(ctx context.Context, id string) error
+	GetCloudProviderGCPValidation(ctx context.Context, id string) (bool, error)
+	ValidateCloudProviderGCP(ctx context.Context, id string) (bool, error)
+}
+
+type VSatellitesAPI interface {
+	CreateVSatellite(vs VSatellite) (*VSatellite, error)
+	GetVSatellite(id string) (*VSatellite, error)
+	GetVSatelliteByName(name string) (*VSatellite, error)
+	UpdateVSatellite(vs VSatellite) (*VSatellite, error)
+	DeleteVSatellite(id string) error
+	CreateVSatellitePairingCode(id string) (*PairingCode, error)
+
+	CreateVSatelliteWorker(w VSatelliteWorker) (*VSatelliteWorker, error)
+	GetVSatelliteWorker(id string) (*VSatelliteWorker, error)
+	GetVSatelliteWorkers(vsatelliteID string) ([]VSatelliteWorker, error)
+	UpdateVSatelliteWorker(w VSatelliteWorker) (*VSatelliteWorker, error)
+	DeleteVSatelliteWorker(id string) error
+	CreateVSatelliteWorkerPairingCode(id string) (*PairingCode, error)
+}
+
+type TagsAPI interface {
+	CreateTag(tag Tag) (*Tag, error)
+	GetTag(id string) (*Tag, error)
+	GetTags() ([]Tag, error)
+	UpdateTag(tag Tag) (*Tag, error)
+	DeleteTag(id string) error
+	AssignTags(ta TagAssignment) error
+	GetAssignedTags(entityType, entityID string) ([]string, error)
+}
+
+type MachinesAPI interface {
+	CreateMachine(m Machine) (*Machine, error)
+	GetMachine(id string) (*Machine, error)
+	GetMachineByName(name string) (*Machine, error)
+	UpdateMachine(m Machine) (*Machine, error)
+	DeleteMachine(id string) error
+	GetMachineIdentities(certificateID, machineID string) ([]MachineIdentity, error)
+}
+
+type CertificatesAPI interface {
+	GetCertificate(id string) (*Certificate, error)
+	GetCertificateContents(id string) (string, error)
+	SearchCertificates(filter CertificateFilter) ([]Certificate, error)
+	UpdateCertificateApplications(id string, update func([]string) []string) error
+	CreateCertificateRequest(cr CertificateRequest) (*CertificateRequest, error)
+	GetCertificateRequest(id string) (*CertificateRequest, error)
+	SearchCertificateRequests(filter CertificateRequestFilter) ([]CertificateRequest, error)
+	RetireCertificates(ids []string) error
+	RevokeCertificate(id, reason, comments string) error
+}
+
+type ActivityLogAPI interface {
+	SearchActivityLog(filter ActivityLogFilter) ([]ActivityLogEntry, error)
+}
+
+type ApprovalWorkflowsAPI interface {
+	CreateApprovalWorkflow(w ApprovalWorkflow) (*ApprovalWorkflow, error)
+	GetApprovalWorkflow(id string) (*ApprovalWorkflow, error)
+	GetApprovalWorkflowByName(name string) (*ApprovalWorkflow, error)
+	UpdateApprovalWorkflow(w ApprovalWorkflow) (*ApprovalWorkflow, error)
+	DeleteApprovalWorkflow(id string) error
+}
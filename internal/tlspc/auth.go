@@ -0,0 +1,231 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// assertionLifetime is how long the JWTs exchanged for service account
+// access tokens are valid for.
+var assertionLifetime = 5 * time.Minute
+
+// tokenRenewBefore is how long before an access token expires it's renewed,
+// so that it doesn't expire while a request is in flight.
+var tokenRenewBefore = time.Minute
+
+// serviceAccountToken gets and renews access tokens for a service account,
+// by exchanging JWTs signed with its private key.
+type serviceAccountToken struct {
+	clientID string
+	key      crypto.Signer
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+type accessToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// parsePrivateKey parses a PEM encoded PKCS #8, PKCS #1 or SEC 1 private key.
+func parsePrivateKey(s string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("unsupported private key format")
+}
+
+// sign returns a JWT with the given claims, signed with key.
+func sign(key crypto.Signer, claims map[string]any) (string, error) {
+	var alg string
+	var hash crypto.Hash
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		alg, hash = "RS256", crypto.SHA256
+	case *ecdsa.PrivateKey:
+		if k.Curve.Params().BitSize != 256 {
+			return "", fmt.Errorf("unsupported EC curve %s, only P-256 is supported", k.Curve.Params().Name)
+		}
+		alg, hash = "ES256", crypto.SHA256
+	case ed25519.PrivateKey:
+		alg = "EdDSA"
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := []byte(signed)
+	if hash != 0 {
+		sum := sha256.Sum256(digest)
+		digest = sum[:]
+	}
+	sig, err := key.Sign(rand.Reader, digest, hash)
+	if err != nil {
+		return "", err
+	}
+	// JWS uses the fixed size concatenation of r and s rather than ASN.1.
+	if k, ok := key.(*ecdsa.PrivateKey); ok {
+		var rs struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(sig, &rs); err != nil {
+			return "", err
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = make([]byte, 2*size)
+		rs.R.FillBytes(sig[:size])
+		rs.S.FillBytes(sig[size:])
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// get returns a valid access token, getting a new one if needed using rt.
+func (t *serviceAccountToken) get(c *Client, rt http.RoundTripper) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" && time.Now().Add(tokenRenewBefore).Before(t.expiry) {
+		return t.token, nil
+	}
+
+	path := c.Path(`%s/v1/oauth/token/serviceaccount`)
+	now := time.Now()
+	assertion, err := sign(t.key, map[string]any{
+		"iss": t.clientID,
+		"sub": t.clientID,
+		"aud": path,
+		"iat": now.Unix(),
+		"exp": now.Add(assertionLifetime).Unix(),
+		"jti": uuid.NewString(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error signing service account assertion: %s", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+	form.Set("client_assertion", assertion)
+	req, err := http.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", c.userAgent())
+
+	client := http.Client{Transport: rt}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Error getting service account access token: %s", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Error reading response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", newAPIError(resp, "Failed to get service account access token", respBody)
+	}
+	var got accessToken
+	if err := json.Unmarshal(respBody, &got); err != nil || got.AccessToken == "" {
+		return "", newAPIError(resp, "Didn't get a service account access token", respBody)
+	}
+
+	t.token = got.AccessToken
+	t.expiry = now.Add(time.Duration(got.ExpiresIn) * time.Second)
+	return t.token, nil
+}
+
+// reset discards the access token, so that a new one is got for the next
+// request.
+func (t *serviceAccountToken) reset(token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token == token {
+		t.token = ""
+	}
+}
+
+// serviceAccountTransport authenticates requests with a service account
+// access token rather than the API key.
+type serviceAccountTransport struct {
+	c  *Client
+	rt http.RoundTripper
+}
+
+func (t serviceAccountTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.c.serviceAccount.get(t.c, t.rt)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Del("tppl-api-key")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := t.rt.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		t.c.serviceAccount.reset(token)
+	}
+	return resp, err
+}
+
+// SetServiceAccount makes the Client authenticate as a service account,
+// using short-lived access tokens got with its private key, instead of the
+// API key. Its scopes then limit what the Client can do. It should be called
+// before the Client is used.
+func (c *Client) SetServiceAccount(clientID, privateKeyPEM string) error {
+	key, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return err
+	}
+	c.serviceAccount = &serviceAccountToken{clientID: clientID, key: key}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const testClientID = "11111111-2222-3333-4444-555555555555"
+
+func pkcs8PEM(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// verifyAssertion checks the JWT was signed by key and returns its claims.
+func verifyAssertion(t *testing.T, key crypto.Signer, jwt string) map[string]any {
+	t.Helper()
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("malformed assertion %q", jwt)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+	digest := sha256.Sum256(signed)
+
+	var valid bool
+	switch pub := key.Public().(type) {
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil
+	case *ecdsa.PublicKey:
+		r := new(big.Int).SetBytes(sig[:len(sig)/2])
+		s := new(big.Int).SetBytes(sig[len(sig)/2:])
+		valid = len(sig) == 64 && ecdsa.Verify(pub, digest[:], r, s)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(pub, signed, sig)
+	}
+	if !valid {
+		t.Fatalf("invalid assertion signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+	return claims
+}
+
+func TestServiceAccount(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name string
+		key  crypto.Signer
+	}{
+		{"rsa", rsaKey},
+		{"ec", ecKey},
+		{"ed25519", edKey},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var exchanges atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /v1/oauth/token/serviceaccount", func(w http.ResponseWriter, r *http.Request) {
+				exchanges.Add(1)
+				if err := r.ParseForm(); err != nil {
+					t.Fatal(err)
+				}
+				if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+					t.Errorf("unexpected grant_type %q", got)
+				}
+				claims := verifyAssertion(t, tc.key, r.PostForm.Get("client_assertion"))
+				if claims["iss"] != testClientID || claims["sub"] != testClientID {
+					t.Errorf("unexpected claims %v", claims)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+			})
+			mux.HandleFunc("GET /v1/teams/{id}", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "Bearer token" {
+					t.Errorf("unexpected Authorization header %q", got)
+				}
+				if got := r.Header.Get("tppl-api-key"); got != "" {
+					t.Errorf("unexpected api key %q", got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(idBody))
+			})
+			srv := httptest.NewServer(mux)
+			t.Cleanup(srv.Close)
+
+			c := newTestClient(t, srv)
+			if err := c.SetServiceAccount(testClientID, pkcs8PEM(t, tc.key)); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for range 2 {
+				if _, err := c.GetTeam(testID); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+			if got := exchanges.Load(); got != 1 {
+				t.Errorf("expected 1 token exchange, got %d", got)
+			}
+		})
+	}
+}
+
+func TestServiceAccountExchangeFails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errors":[{"code":10001,"message":"invalid assertion"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newTestClient(t, srv)
+	if err := c.SetServiceAccount(testClientID, pkcs8PEM(t, key)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := c.GetTeam(testID); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSetServiceAccountInvalidKey(t *testing.T) {
+	c, err := NewClient(testAPIKey, "", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetServiceAccount(testClientID, "not a key"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// caAccountsTTL is how long CA accounts and their product options are cached
+// for. They rarely change, but are looked up by every certificate template.
+var caAccountsTTL = 5 * time.Minute
+
+// scopesTTL is how long the catalog of service account scopes is cached for,
+// as it's checked when planning every service account.
+var scopesTTL = 5 * time.Minute
+
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// ttlCache memoizes the results of lookups for a fixed time. Lookups are
+// serialized, so concurrent callers asking for the same key share one request.
+// Errors aren't cached.
+type ttlCache[V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]ttlEntry[V]
+}
+
+func newTTLCache[V any](ttl time.Duration) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:     ttl,
+		entries: map[string]ttlEntry[V]{},
+	}
+}
+
+// get returns the cached value for key, calling fetch if there isn't one or
+// it has expired.
+func (c *ttlCache[V]) get(key string, fetch func() (V, error)) (V, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok && time.Now().Before(e.expires) {
+		return e.value, nil
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+	c.entries[key] = ttlEntry[V]{value: v, expires: time.Now().Add(c.ttl)}
+
+	return v, nil
+}
+
+// responseCache holds successful GET responses for a fixed time, so that
+// identical reads within a run, e.g. by many data sources, share a request.
+// Unlike ttlCache, fetches aren't serialized, so that reads of different
+// paths can proceed in parallel.
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]ttlEntry[cachedResponse]
+	// generation counts clears, so that a response fetched before a clear
+	// isn't cached after it.
+	generation int
+}
+
+type cachedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: map[string]ttlEntry[cachedResponse]{},
+	}
+}
+
+// get returns the cached response for path, calling fetch if there isn't one
+// or it has expired. Only 200 OK responses are cached.
+func (c *responseCache) get(path string, fetch func() (*http.Response, error)) (*http.Response, error) {
+	c.mu.Lock()
+	e, ok := c.entries[path]
+	generation := c.generation
+	c.mu.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		return e.value.response(), nil
+	}
+
+	resp, err := fetch()
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	cached := cachedResponse{statusCode: resp.StatusCode, header: resp.Header, body: body}
+
+	c.mu.Lock()
+	if c.generation == generation {
+		c.entries[path] = ttlEntry[cachedResponse]{value: cached, expires: time.Now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+
+	return cached.response(), nil
+}
+
+// clear empties the cache. It's called whenever a request which may change
+// something is made, so that reads never return what it replaced.
+func (c *responseCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]ttlEntry[cachedResponse]{}
+	c.generation++
+}
+
+func (r cachedResponse) response() *http.Response {
+	return &http.Response{
+		StatusCode: r.statusCode,
+		Header:     r.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(r.body)),
+	}
+}
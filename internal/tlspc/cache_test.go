@@ -0,0 +1,129 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTTLCache(t *testing.T) {
+	c := newTTLCache[int](time.Hour)
+
+	fetches := 0
+	fetch := func() (int, error) {
+		fetches++
+		return fetches, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		if v, _ := c.get("a", fetch); v != 1 {
+			t.Errorf("expected cached value 1, got %d", v)
+		}
+	}
+	if v, _ := c.get("b", fetch); v != 2 {
+		t.Errorf("expected a separate value for another key, got %d", v)
+	}
+
+	// Errors aren't cached.
+	_, err := c.get("c", func() (int, error) { return 0, errors.New("failed") })
+	if err == nil {
+		t.Error("expected an error")
+	}
+	if v, _ := c.get("c", fetch); v != 3 {
+		t.Errorf("expected a fetch after an error, got %d", v)
+	}
+
+	// Expired entries are fetched again.
+	c.ttl = 0
+	c.entries = map[string]ttlEntry[int]{}
+	c.get("a", fetch)
+	if v, _ := c.get("a", fetch); v != 5 {
+		t.Errorf("expected an expired entry to be fetched, got %d", v)
+	}
+}
+
+func TestCAAccountsCached(t *testing.T) {
+	accounts := `{"accounts":[{"account":{"id":"` + testID + `","key":"account"},"productOptions":[{"id":"` + testID + `","productName":"option"}]}]}`
+
+	requests := map[string]*atomic.Int32{"DIGICERT": {}, "BUILTIN": {}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/certificateauthorities/{kind}/accounts", func(w http.ResponseWriter, r *http.Request) {
+		requests[r.PathValue("kind")].Add(1)
+		_, _ = w.Write([]byte(accounts))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c := newTestClient(t, srv)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetCAProductOptionByID("DIGICERT", testID); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, _, err := c.GetCAProductOption("DIGICERT", "account", "option"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if _, err := c.GetCAProductOptionByID("BUILTIN", testID); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	for kind, n := range requests {
+		if got := n.Load(); got != 1 {
+			t.Errorf("expected 1 request for %s accounts, got %d", kind, got)
+		}
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"id":"` + testID + `","name":"team"}`))
+			return
+		}
+		_, _ = w.Write([]byte(idBody))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := newTestClient(t, srv)
+	c.SetResponseCacheTTL(time.Hour)
+
+	for i := 0; i < 3; i++ {
+		team, err := c.GetTeam(testID)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if team.Name != "team" {
+			t.Errorf("expected a cached copy of the response, got %+v", team)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+
+	// Changes clear the cache.
+	if _, err := c.CreateTeam(Team{Name: "other"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := c.GetTeam(testID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected the read after a change to be fetched, got %d requests", got)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+const testAPIKey = "test-api-key"
+
+// errorBody is a typical error response from the API.
+const errorBody = `{"errors":[{"code":10051,"message":"Unable to find the requested resource","args":[]}]}`
+
+// malformedBody is returned to check that decoding errors are surfaced.
+const malformedBody = `{"id": "unterminated`
+
+func TestMain(m *testing.M) {
+	// Don't sleep between read-after-write attempts.
+	readAfterWriteDelay = 0
+
+	os.Exit(m.Run())
+}
+
+// fixture is a canned response for a single endpoint.
+type fixture struct {
+	status int
+	body   string
+}
+
+// clientCase describes a client method and the endpoints it calls.
+//
+// fixtures is keyed by http.ServeMux pattern, e.g. "GET /v1/teams/{id}", or by
+// "graphql <OperationName>" for GraphQL operations.
+type clientCase struct {
+	name     string
+	fixtures map[string]fixture
+	call     func(c *Client) error
+	// noBody is set for methods which don't decode the response body, and so
+	// can't fail on malformed JSON.
+	noBody bool
+}
+
+// newTestServer serves the given fixtures, passing each through override
+// before it is written.
+func newTestServer(t *testing.T, fixtures map[string]fixture, override func(fixture) fixture) *httptest.Server {
+	t.Helper()
+
+	write := func(w http.ResponseWriter, r *http.Request, f fixture) {
+		if got := r.Header.Get("tppl-api-key"); got != testAPIKey {
+			t.Errorf("%s %s: unexpected api key %q", r.Method, r.URL.Path, got)
+		}
+		f = override(f)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(f.status)
+		_, _ = w.Write([]byte(f.body))
+	}
+
+	mux := http.NewServeMux()
+	operations := map[string]fixture{}
+	for pattern, f := range fixtures {
+		if op, ok := strings.CutPrefix(pattern, "graphql "); ok {
+			operations[op] = f
+			continue
+		}
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			write(w, r, f)
+		})
+	}
+	if len(operations) > 0 {
+		mux.HandleFunc("POST /graphql", func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				OperationName string `json:"operationName"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("decoding graphql request: %s", err)
+			}
+			f, ok := operations[req.OperationName]
+			if !ok {
+				t.Errorf("unexpected graphql operation %q", req.OperationName)
+				http.NotFound(w, r)
+				return
+			}
+			write(w, r, f)
+		})
+	}
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server) *Client {
+	t.Helper()
+
+	c, err := NewClient(testAPIKey, srv.URL, "test")
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+
+	return c
+}
+
+// runClientCases runs each case against a server returning its fixtures, a
+// server returning 4xx errors, and a server returning malformed JSON.
+func runClientCases(t *testing.T, cases []clientCase) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("success", func(t *testing.T) {
+				srv := newTestServer(t, tc.fixtures, func(f fixture) fixture { return f })
+				if err := tc.call(newTestClient(t, srv)); err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			})
+
+			t.Run("4xx", func(t *testing.T) {
+				srv := newTestServer(t, tc.fixtures, func(fixture) fixture {
+					return fixture{status: http.StatusNotFound, body: errorBody}
+				})
+				if err := tc.call(newTestClient(t, srv)); err == nil {
+					t.Error("expected an error")
+				}
+			})
+
+			if tc.noBody {
+				return
+			}
+			t.Run("malformed", func(t *testing.T) {
+				srv := newTestServer(t, tc.fixtures, func(f fixture) fixture {
+					return fixture{status: f.status, body: malformedBody}
+				})
+				if err := tc.call(newTestClient(t, srv)); err == nil {
+					t.Error("expected an error")
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+)
+
+// compressMinSize is the smallest request body which is compressed, when
+// request compression is enabled. Smaller bodies aren't worth it.
+var compressMinSize = 8 << 10
+
+// compressionTransport asks for gzip compressed responses and decompresses
+// them, and if compressRequests is set, gzip compresses large request bodies.
+type compressionTransport struct {
+	compressRequests bool
+	rt               http.RoundTripper
+}
+
+func (t compressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.compressRequests && req.ContentLength >= int64(compressMinSize) && req.GetBody != nil && req.Header.Get("Content-Encoding") == "" {
+		compressed, err := compressRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		req = compressed
+	}
+
+	// Setting Accept-Encoding stops the standard transport decompressing the
+	// response itself, so it's only done if nothing else has.
+	if req.Header.Get("Accept-Encoding") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil || resp.Header.Get("Content-Encoding") != "gzip" {
+		return resp, err
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body = gzipBody{Reader: gz, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return resp, nil
+}
+
+// compressRequest returns a copy of req with its body gzip compressed.
+func compressRequest(req *http.Request) (*http.Request, error) {
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := io.Copy(gz, body); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	compressed := buf.Bytes()
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(compressed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	req.ContentLength = int64(len(compressed))
+	req.Header.Set("Content-Encoding", "gzip")
+
+	return req, nil
+}
+
+// gzipBody decompresses a response body, closing the underlying body when
+// it's closed.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCompressedResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			_, _ = w.Write([]byte(idBody))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(gzipped(t, idBody))
+	}))
+	t.Cleanup(srv.Close)
+
+	team, err := newTestClient(t, srv).GetTeam(testID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if team.ID != testID {
+		t.Errorf("expected team %s, got %s", testID, team.ID)
+	}
+}
+
+func TestCompressedRequests(t *testing.T) {
+	prev := compressMinSize
+	compressMinSize = 64
+	t.Cleanup(func() { compressMinSize = prev })
+
+	name := strings.Repeat("a", compressMinSize)
+
+	cases := []struct {
+		name       string
+		compress   bool
+		teamName   string
+		compressed bool
+	}{
+		{name: "disabled", compress: false, teamName: name, compressed: false},
+		{name: "small body", compress: true, teamName: "a", compressed: false},
+		{name: "large body", compress: true, teamName: name, compressed: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body io.Reader = r.Body
+				compressed := r.Header.Get("Content-Encoding") == "gzip"
+				if compressed {
+					gz, err := gzip.NewReader(r.Body)
+					if err != nil {
+						t.Errorf("unexpected error: %s", err)
+						return
+					}
+					body = gz
+				}
+				if compressed != tc.compressed {
+					t.Errorf("expected compressed %t, got %t", tc.compressed, compressed)
+				}
+				b, _ := io.ReadAll(body)
+				if !strings.Contains(string(b), tc.teamName) {
+					t.Errorf("expected body to contain the team name, got %s", string(b))
+				}
+				_, _ = w.Write([]byte(idBody))
+			}))
+			t.Cleanup(srv.Close)
+
+			c := newTestClient(t, srv)
+			c.SetCompressRequests(tc.compress)
+			if _, err := c.CreateTeam(Team{Name: tc.teamName}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
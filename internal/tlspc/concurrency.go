@@ -0,0 +1,38 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"net/http"
+)
+
+// concurrencyTransport limits the number of requests in flight at once to the
+// capacity of sem. It's below rateLimitTransport, so that a request waiting
+// out a rate limit pause doesn't hold a slot.
+type concurrencyTransport struct {
+	sem chan struct{}
+	rt  http.RoundTripper
+}
+
+func (t concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.sem }()
+
+	return t.rt.RoundTrip(req)
+}
+
+// SetMaxConcurrentRequests limits the number of requests to the API, REST and
+// GraphQL, in flight at once across every use of the Client. A limit of 0
+// removes it. It should be called before the Client is used.
+func (c *Client) SetMaxConcurrentRequests(n int) {
+	if n <= 0 {
+		c.concurrency = nil
+		return
+	}
+	c.concurrency = make(chan struct{}, n)
+}
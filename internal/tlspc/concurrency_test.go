@@ -0,0 +1,63 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	cases := []struct {
+		name  string
+		limit int
+		// peak is whether the most requests in flight at once is allowed
+		peak func(int32) bool
+	}{
+		{name: "limited", limit: 2, peak: func(n int32) bool { return n <= 2 }},
+		{name: "unlimited", limit: 0, peak: func(n int32) bool { return n > 2 }},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var inFlight, peak atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(50 * time.Millisecond)
+				_, _ = w.Write([]byte(idBody))
+			}))
+			t.Cleanup(srv.Close)
+
+			c := newTestClient(t, srv)
+			c.SetMaxConcurrentRequests(tc.limit)
+
+			var wg sync.WaitGroup
+			for range 6 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := c.GetTeam(testID); err != nil {
+						t.Errorf("unexpected error: %s", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := peak.Load(); !tc.peak(got) {
+				t.Errorf("unexpected peak of %d requests in flight with a limit of %d", got, tc.limit)
+			}
+		})
+	}
+}
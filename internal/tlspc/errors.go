@@ -0,0 +1,95 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	gql "github.com/Khan/genqlient/graphql"
+)
+
+// requestIDHeaders are the response headers which may carry an ID for the
+// request, in order of preference. Quoting the ID lets Venafi support find
+// the request in their logs.
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Correlation-Id",
+	"X-Trace-Id",
+	"X-B3-TraceId",
+}
+
+// requestID returns the ID of the request from the response headers h, or
+// an empty string if there isn't one.
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// APIError is returned when the API doesn't respond as expected.
+type APIError struct {
+	// Message describes what failed, e.g. "Failed to update Team".
+	Message    string
+	StatusCode int
+	Body       string
+	// RequestID is empty if the response didn't include one.
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s; response was: %s", e.Message, e.Body)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID: %s)", e.RequestID)
+	}
+	return msg
+}
+
+// newAPIError returns an APIError for resp, whose body has already been read
+// into body.
+func newAPIError(resp *http.Response, msg string, body []byte) *APIError {
+	return &APIError{
+		Message:    msg,
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		RequestID:  requestID(resp.Header),
+	}
+}
+
+// requestIDKey is the context key for the *string that requestIDTransport
+// records the request ID in.
+type requestIDKey struct{}
+
+// requestIDTransport records the ID of each request in the *string stored in
+// its context under requestIDKey, if there is one.
+type requestIDTransport struct {
+	rt http.RoundTripper
+}
+
+func (t requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.rt.RoundTrip(req)
+	if id, ok := req.Context().Value(requestIDKey{}).(*string); ok && resp != nil {
+		*id = requestID(resp.Header)
+	}
+	return resp, err
+}
+
+// requestIDClient adds the request ID to the errors returned by a GraphQL
+// client, since they don't include the response headers.
+type requestIDClient struct {
+	gql.Client
+}
+
+func (c requestIDClient) MakeRequest(ctx context.Context, req *gql.Request, resp *gql.Response) error {
+	var id string
+	err := c.Client.MakeRequest(context.WithValue(ctx, requestIDKey{}, &id), req, resp)
+	if err != nil && id != "" {
+		return fmt.Errorf("%w (request ID: %s)", err, id)
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// errorServer responds to every request with status and body, and the
+// request ID header set to "req-123".
+func errorServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestAPIErrorRequestID(t *testing.T) {
+	srv := errorServer(t, http.StatusNotFound, `{"errors":[{"code":10051}]}`)
+
+	_, err := newTestClient(t, srv).GetTeam(testID)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if apiErr.RequestID != "req-123" || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected APIError: %+v", apiErr)
+	}
+	if !strings.Contains(err.Error(), "(request ID: req-123)") {
+		t.Errorf("expected the request ID in %q", err)
+	}
+}
+
+func TestGraphQLErrorRequestID(t *testing.T) {
+	srv := errorServer(t, http.StatusOK, `{"errors":[{"message":"not allowed"}]}`)
+
+	_, err := newTestClient(t, srv).GetCloudProviderGCP(context.Background(), testID)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "(request ID: req-123)") {
+		t.Errorf("expected the request ID in %q", err)
+	}
+}
@@ -15,16 +15,19 @@ import (
 )
 
 func (c *Client) GetGraphQLClient() gql.Client {
-	httpClient := http.DefaultClient
-	rt := WithHeader(httpClient.Transport)
+	httpClient := &http.Client{}
+	rt := WithHeader(requestIDTransport{rt: c.transport()})
 	rt.Set("tppl-api-key", c.apikey)
-	rt.Set("User-Agent", "terraform-provider-tlspc/"+c.version)
+	rt.Set("User-Agent", c.userAgent())
+	if c.apiVersion != "" {
+		rt.Set(APIVersionHeader, c.apiVersion)
+	}
 	httpClient.Transport = rt
 
 	path := c.Path(`%s/graphql`)
 	client := gql.NewClient(path, httpClient)
 
-	return client
+	return requestIDClient{client}
 }
 
 type withHeader struct {
@@ -62,6 +65,8 @@ type CloudProviderGCP struct {
 	ProjectNumber                  int64
 	WorkloadIdentityPoolId         string
 	WorkloadIdentityPoolProviderId string
+	Status                         string
+	StatusDetails                  string
 }
 
 func (c *Client) CreateCloudProviderGCP(ctx context.Context, p CloudProviderGCP) (*CloudProviderGCP, error) {
@@ -106,8 +111,17 @@ func (c *Client) CreateCloudProviderGCP(ctx context.Context, p CloudProviderGCP)
 		ServiceAccountEmail:            cfg.ServiceAccountEmail,
 		WorkloadIdentityPoolId:         cfg.WorkloadIdentityPoolId,
 		WorkloadIdentityPoolProviderId: cfg.WorkloadIdentityPoolProviderId,
+		Status:                         string(resp.CreateCloudProvider.Status),
+		StatusDetails:                  resp.CreateCloudProvider.StatusDetails,
 	}
 
+	// New cloud providers take a moment to appear in the listing used by
+	// GetCloudProviderGCP; wait for them on a best effort basis.
+	_ = c.waitUntilReadable(func() error {
+		_, err := c.GetCloudProviderGCP(ctx, created.ID)
+		return err
+	})
+
 	return &created, nil
 }
 
@@ -152,6 +166,8 @@ func (c *Client) GetCloudProviderGCP(ctx context.Context, id string) (*CloudProv
 		ServiceAccountEmail:            cfg.ServiceAccountEmail,
 		WorkloadIdentityPoolId:         cfg.WorkloadIdentityPoolId,
 		WorkloadIdentityPoolProviderId: cfg.WorkloadIdentityPoolProviderId,
+		Status:                         string(found.Status),
+		StatusDetails:                  found.StatusDetails,
 	}
 
 	return &p, nil
@@ -202,6 +218,8 @@ func (c *Client) UpdateCloudProviderGCP(ctx context.Context, p CloudProviderGCP)
 		ServiceAccountEmail:            cfg.ServiceAccountEmail,
 		WorkloadIdentityPoolId:         cfg.WorkloadIdentityPoolId,
 		WorkloadIdentityPoolProviderId: cfg.WorkloadIdentityPoolProviderId,
+		Status:                         string(resp.UpdateCloudProvider.Status),
+		StatusDetails:                  resp.UpdateCloudProvider.StatusDetails,
 	}
 
 	return &updated, nil
@@ -79,6 +79,8 @@ type GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProvider struct
 	Type            CloudProviderType                                                                        `json:"type"`
 	Team            GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProviderTeam                  `json:"team"`
 	AuthorizedTeams []GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProviderAuthorizedTeamsTeam `json:"authorizedTeams"`
+	Status          CloudProviderStatus                                                                      `json:"status"`
+	StatusDetails   string                                                                                   `json:"statusDetails"`
 	Configuration   GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProviderConfiguration         `json:"-"`
 }
 
@@ -107,6 +109,16 @@ func (v *GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProvider) Ge
 	return v.AuthorizedTeams
 }
 
+// GetStatus returns GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProvider.Status, and is useful for accessing the field via an interface.
+func (v *GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProvider) GetStatus() CloudProviderStatus {
+	return v.Status
+}
+
+// GetStatusDetails returns GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProvider.StatusDetails, and is useful for accessing the field via an interface.
+func (v *GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProvider) GetStatusDetails() string {
+	return v.StatusDetails
+}
+
 // GetConfiguration returns GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProvider.Configuration, and is useful for accessing the field via an interface.
 func (v *GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProvider) GetConfiguration() GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProviderConfiguration {
 	return v.Configuration
@@ -156,6 +168,10 @@ type __premarshalGCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProv
 
 	AuthorizedTeams []GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProviderAuthorizedTeamsTeam `json:"authorizedTeams"`
 
+	Status CloudProviderStatus `json:"status"`
+
+	StatusDetails string `json:"statusDetails"`
+
 	Configuration json.RawMessage `json:"configuration"`
 }
 
@@ -175,6 +191,8 @@ func (v *GCPProvidersCloudProvidersCloudProviderConnectionNodesCloudProvider) __
 	retval.Type = v.Type
 	retval.Team = v.Team
 	retval.AuthorizedTeams = v.AuthorizedTeams
+	retval.Status = v.Status
+	retval.StatusDetails = v.StatusDetails
 	{
 
 		dst := &retval.Configuration
@@ -607,6 +625,8 @@ type NewGCPProviderCreateCloudProvider struct {
 	Id            uuid.UUID                                      `json:"id"`
 	Name          string                                         `json:"name"`
 	Team          NewGCPProviderCreateCloudProviderTeam          `json:"team"`
+	Status        CloudProviderStatus                            `json:"status"`
+	StatusDetails string                                         `json:"statusDetails"`
 	Configuration NewGCPProviderCreateCloudProviderConfiguration `json:"-"`
 }
 
@@ -621,6 +641,12 @@ func (v *NewGCPProviderCreateCloudProvider) GetTeam() NewGCPProviderCreateCloudP
 	return v.Team
 }
 
+// GetStatus returns NewGCPProviderCreateCloudProvider.Status, and is useful for accessing the field via an interface.
+func (v *NewGCPProviderCreateCloudProvider) GetStatus() CloudProviderStatus { return v.Status }
+
+// GetStatusDetails returns NewGCPProviderCreateCloudProvider.StatusDetails, and is useful for accessing the field via an interface.
+func (v *NewGCPProviderCreateCloudProvider) GetStatusDetails() string { return v.StatusDetails }
+
 // GetConfiguration returns NewGCPProviderCreateCloudProvider.Configuration, and is useful for accessing the field via an interface.
 func (v *NewGCPProviderCreateCloudProvider) GetConfiguration() NewGCPProviderCreateCloudProviderConfiguration {
 	return v.Configuration
@@ -666,6 +692,10 @@ type __premarshalNewGCPProviderCreateCloudProvider struct {
 
 	Team NewGCPProviderCreateCloudProviderTeam `json:"team"`
 
+	Status CloudProviderStatus `json:"status"`
+
+	StatusDetails string `json:"statusDetails"`
+
 	Configuration json.RawMessage `json:"configuration"`
 }
 
@@ -683,6 +713,8 @@ func (v *NewGCPProviderCreateCloudProvider) __premarshalJSON() (*__premarshalNew
 	retval.Id = v.Id
 	retval.Name = v.Name
 	retval.Team = v.Team
+	retval.Status = v.Status
+	retval.StatusDetails = v.StatusDetails
 	{
 
 		dst := &retval.Configuration
@@ -884,6 +916,8 @@ type UpdateGCPProviderUpdateCloudProvider struct {
 	Id            uuid.UUID                                         `json:"id"`
 	Name          string                                            `json:"name"`
 	Team          UpdateGCPProviderUpdateCloudProviderTeam          `json:"team"`
+	Status        CloudProviderStatus                               `json:"status"`
+	StatusDetails string                                            `json:"statusDetails"`
 	Configuration UpdateGCPProviderUpdateCloudProviderConfiguration `json:"-"`
 }
 
@@ -898,6 +932,12 @@ func (v *UpdateGCPProviderUpdateCloudProvider) GetTeam() UpdateGCPProviderUpdate
 	return v.Team
 }
 
+// GetStatus returns UpdateGCPProviderUpdateCloudProvider.Status, and is useful for accessing the field via an interface.
+func (v *UpdateGCPProviderUpdateCloudProvider) GetStatus() CloudProviderStatus { return v.Status }
+
+// GetStatusDetails returns UpdateGCPProviderUpdateCloudProvider.StatusDetails, and is useful for accessing the field via an interface.
+func (v *UpdateGCPProviderUpdateCloudProvider) GetStatusDetails() string { return v.StatusDetails }
+
 // GetConfiguration returns UpdateGCPProviderUpdateCloudProvider.Configuration, and is useful for accessing the field via an interface.
 func (v *UpdateGCPProviderUpdateCloudProvider) GetConfiguration() UpdateGCPProviderUpdateCloudProviderConfiguration {
 	return v.Configuration
@@ -943,6 +983,10 @@ type __premarshalUpdateGCPProviderUpdateCloudProvider struct {
 
 	Team UpdateGCPProviderUpdateCloudProviderTeam `json:"team"`
 
+	Status CloudProviderStatus `json:"status"`
+
+	StatusDetails string `json:"statusDetails"`
+
 	Configuration json.RawMessage `json:"configuration"`
 }
 
@@ -960,6 +1004,8 @@ func (v *UpdateGCPProviderUpdateCloudProvider) __premarshalJSON() (*__premarshal
 	retval.Id = v.Id
 	retval.Name = v.Name
 	retval.Team = v.Team
+	retval.Status = v.Status
+	retval.StatusDetails = v.StatusDetails
 	{
 
 		dst := &retval.Configuration
@@ -1288,6 +1334,8 @@ query GCPProviders {
 			authorizedTeams {
 				id
 			}
+			status
+			statusDetails
 			configuration {
 				__typename
 				... on CloudProviderGCPConfiguration {
@@ -1373,6 +1421,8 @@ mutation NewGCPProvider ($Name: String!, $Team: UUID!, $Email: String!, $Project
 		team {
 			id
 		}
+		status
+		statusDetails
 		configuration {
 			__typename
 			... on CloudProviderGCPConfiguration {
@@ -1431,6 +1481,8 @@ mutation UpdateGCPProvider ($Id: UUID!, $Name: String!, $Team: UUID!, $Project:
 		team {
 			id
 		}
+		status
+		statusDetails
 		configuration {
 			__typename
 			... on CloudProviderGCPConfiguration {
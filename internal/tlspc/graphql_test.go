@@ -0,0 +1,126 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"context"
+	"testing"
+)
+
+const gcpConfiguration = `{
+	"__typename": "CloudProviderGCPConfiguration",
+	"serviceAccountEmail": "sa@project.iam.gserviceaccount.com",
+	"projectNumber": "123456789",
+	"workloadIdentityPoolId": "pool",
+	"workloadIdentityPoolProviderId": "provider",
+	"issuerUrl": "https://issuer.example.com"
+}`
+
+const gcpProvider = `{"id":"` + testID + `","name":"test","team":{"id":"` + testID + `"},"status":"NOT_VALIDATED","statusDetails":"Workload identity pool not found","configuration":` + gcpConfiguration + `}`
+
+func testCloudProviderGCP() CloudProviderGCP {
+	return CloudProviderGCP{
+		ID:                             testID,
+		Name:                           "test",
+		Team:                           testID,
+		ServiceAccountEmail:            "sa@project.iam.gserviceaccount.com",
+		ProjectNumber:                  123456789,
+		WorkloadIdentityPoolId:         "pool",
+		WorkloadIdentityPoolProviderId: "provider",
+	}
+}
+
+func TestCloudProviderGCP(t *testing.T) {
+	ctx := context.Background()
+	providers := ok(`{"data":{"cloudProviders":{"totalCount":1,"nodes":[` + gcpProvider + `]}}}`)
+
+	runClientCases(t, []clientCase{
+		{
+			name: "CreateCloudProviderGCP",
+			fixtures: map[string]fixture{
+				"graphql NewGCPProvider": ok(`{"data":{"createCloudProvider":` + gcpProvider + `}}`),
+				"graphql GCPProviders":   providers,
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateCloudProviderGCP(ctx, testCloudProviderGCP())
+				return err
+			},
+		},
+		{
+			name: "GetCloudProviderGCP",
+			fixtures: map[string]fixture{
+				"graphql GCPProviders": providers,
+			},
+			call: func(c *Client) error {
+				_, err := c.GetCloudProviderGCP(ctx, testID)
+				return err
+			},
+		},
+		{
+			name: "UpdateCloudProviderGCP",
+			fixtures: map[string]fixture{
+				"graphql UpdateGCPProvider": ok(`{"data":{"updateCloudProvider":` + gcpProvider + `}}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateCloudProviderGCP(ctx, testCloudProviderGCP())
+				return err
+			},
+		},
+		{
+			name: "DeleteCloudProviderGCP",
+			fixtures: map[string]fixture{
+				"graphql DeleteGCPProvider": ok(`{"data":{"deleteCloudProvider":true}}`),
+			},
+			call: func(c *Client) error {
+				return c.DeleteCloudProviderGCP(ctx, testID)
+			},
+		},
+		{
+			name: "GetCloudProviderGCPValidation",
+			fixtures: map[string]fixture{
+				"graphql GetGCPProviderDetails": ok(`{"data":{"cloudProviderDetails":{"__typename":"GCPProviderDetails","cloudProvider":{"id":"` + testID + `","status":"VALIDATED"}}}}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetCloudProviderGCPValidation(ctx, testID)
+				return err
+			},
+		},
+		{
+			name: "ValidateCloudProviderGCP",
+			fixtures: map[string]fixture{
+				"graphql ValidateGCPProvider": ok(`{"data":{"validateCloudProvider":{"result":"VALIDATED","details":""}}}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.ValidateCloudProviderGCP(ctx, testID)
+				return err
+			},
+		},
+	})
+}
+
+// GraphQL errors are returned with a 200 status code.
+func TestCloudProviderGCPStatus(t *testing.T) {
+	srv := newTestServer(t, map[string]fixture{
+		"graphql GCPProviders": ok(`{"data":{"cloudProviders":{"totalCount":1,"nodes":[` + gcpProvider + `]}}}`),
+	}, func(f fixture) fixture { return f })
+
+	p, err := newTestClient(t, srv).GetCloudProviderGCP(context.Background(), testID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if p.Status != "NOT_VALIDATED" || p.StatusDetails != "Workload identity pool not found" {
+		t.Errorf("unexpected status %q, details %q", p.Status, p.StatusDetails)
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	srv := newTestServer(t, map[string]fixture{
+		"graphql GCPProviders": ok(`{"data":null,"errors":[{"message":"Unauthorized"}]}`),
+	}, func(f fixture) fixture { return f })
+
+	_, err := newTestClient(t, srv).GetCloudProviderGCP(context.Background(), testID)
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
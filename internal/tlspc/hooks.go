@@ -0,0 +1,85 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// RequestInfo describes an attempt at a request to the API.
+type RequestInfo struct {
+	Method string
+	// Path is the URL path, without the query.
+	Path string
+	// Attempt is 0 for the first attempt, and counts up for each retry.
+	Attempt int
+}
+
+// RequestResult describes the outcome of an attempt at a request.
+type RequestResult struct {
+	// StatusCode is 0 if no response was received.
+	StatusCode int
+	Latency    time.Duration
+	Err        error
+}
+
+// Hooks are notified of every request a Client makes, so that the provider's
+// use of the API can be observed.
+type Hooks interface {
+	RequestStarted(RequestInfo)
+	RequestFinished(RequestInfo, RequestResult)
+}
+
+// Stats are running totals of the requests made by a Client.
+type Stats struct {
+	// Requests counts every attempt, including retries.
+	Requests int64
+	Retries  int64
+	// Errors counts attempts which failed or had a 4xx or 5xx status code.
+	Errors int64
+}
+
+// requestStats are the Hooks which keep a Client's Stats.
+type requestStats struct {
+	requests atomic.Int64
+	retries  atomic.Int64
+	errors   atomic.Int64
+}
+
+func (s *requestStats) RequestStarted(info RequestInfo) {
+	s.requests.Add(1)
+	if info.Attempt > 0 {
+		s.retries.Add(1)
+	}
+}
+
+func (s *requestStats) RequestFinished(_ RequestInfo, result RequestResult) {
+	if result.Err != nil || result.StatusCode >= 400 {
+		s.errors.Add(1)
+	}
+}
+
+// SetHooks sets the Hooks notified of requests, replacing any set before.
+// It should be called before the Client is used.
+func (c *Client) SetHooks(h Hooks) {
+	c.hooks = h
+}
+
+// Stats returns the totals of the requests made so far.
+func (c *Client) Stats() Stats {
+	return Stats{
+		Requests: c.stats.requests.Load(),
+		Retries:  c.stats.retries.Load(),
+		Errors:   c.stats.errors.Load(),
+	}
+}
+
+// requestHooks returns the Hooks to notify for each request.
+func (c *Client) requestHooks() []Hooks {
+	if c.hooks == nil {
+		return []Hooks{c.stats}
+	}
+	return []Hooks{c.stats, c.hooks}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+)
+
+type recordingHooks struct {
+	mu       sync.Mutex
+	started  []RequestInfo
+	finished []RequestResult
+}
+
+func (h *recordingHooks) RequestStarted(info RequestInfo) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.started = append(h.started, info)
+}
+
+func (h *recordingHooks) RequestFinished(_ RequestInfo, result RequestResult) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.finished = append(h.finished, result)
+}
+
+func TestHooks(t *testing.T) {
+	srv, _ := rateLimitedServer(t, 2, idBody)
+
+	c := newTestClient(t, srv)
+	hooks := &recordingHooks{}
+	c.SetHooks(hooks)
+
+	if _, err := c.CreateTeam(Team{Name: "test"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(hooks.started) != 3 || len(hooks.finished) != 3 {
+		t.Fatalf("expected 3 started and finished requests, got %d and %d", len(hooks.started), len(hooks.finished))
+	}
+	for i, info := range hooks.started {
+		if info.Method != http.MethodPost || info.Path != "/v1/teams" || info.Attempt != i {
+			t.Errorf("unexpected request %d: %+v", i, info)
+		}
+	}
+	statuses := []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK}
+	for i, result := range hooks.finished {
+		if result.StatusCode != statuses[i] || result.Err != nil {
+			t.Errorf("unexpected result %d: %+v", i, result)
+		}
+	}
+
+	want := Stats{Requests: 3, Retries: 2, Errors: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("expected stats %+v, got %+v", want, got)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// idempotencyKeyHeader carries a key which is unique to each logical create,
+// and is the same for every attempt at it, so that a retried create can be
+// recognised by the API rather than creating a duplicate.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// uncertainCreateError is returned by postCreate when a create may have taken
+// effect despite failing, because no response or a server error was
+// received.
+type uncertainCreateError struct {
+	err error
+}
+
+func (e *uncertainCreateError) Error() string {
+	return e.err.Error()
+}
+
+func (e *uncertainCreateError) Unwrap() error {
+	return e.err
+}
+
+// createUncertain reports whether err leaves it unknown if a create took
+// effect. Callers which can look up the object by name should do so before
+// reporting err, so that an object created by a failed request is adopted
+// rather than duplicated by a later apply.
+func createUncertain(err error) bool {
+	var uncertain *uncertainCreateError
+	return errors.As(err, &uncertain)
+}
+
+// postCreate posts a create request with a new idempotency key.
+func (c *Client) postCreate(path string, body []byte) (*http.Response, error) {
+	req, err := c.newRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(idempotencyKeyHeader, uuid.NewString())
+
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, &uncertainCreateError{err: err}
+	}
+	if resp.StatusCode >= 500 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &uncertainCreateError{err: newAPIError(resp, fmt.Sprintf("server error (%d)", resp.StatusCode), respBody)}
+	}
+
+	return resp, nil
+}
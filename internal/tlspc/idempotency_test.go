@@ -0,0 +1,69 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestIdempotencyKeyKeptAcrossRetries(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		n := len(keys)
+		mu.Unlock()
+
+		if n == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(idBody))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := newTestClient(t, srv)
+	for i := 0; i < 2; i++ {
+		if _, err := c.CreateTeam(Team{Name: "test"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if len(keys) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected the retry to reuse the idempotency key, got %q and %q", keys[0], keys[1])
+	}
+	if keys[2] == keys[0] {
+		t.Errorf("expected a new idempotency key for a new create, got %q again", keys[2])
+	}
+}
+
+func TestCreateUncertainAdoptsByName(t *testing.T) {
+	fixtures := map[string]fixture{
+		"POST /v1/teams": {status: http.StatusBadGateway, body: "bad gateway"},
+		"GET /v1/teams":  ok(`{"teams":[{"id":"` + testID + `","name":"test"}]}`),
+	}
+
+	srv := newTestServer(t, fixtures, func(f fixture) fixture { return f })
+	team, err := newTestClient(t, srv).CreateTeam(Team{Name: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if team.ID != testID {
+		t.Errorf("expected team %s, got %s", testID, team.ID)
+	}
+
+	fixtures["GET /v1/teams"] = ok(`{"teams":[]}`)
+	srv = newTestServer(t, fixtures, func(f fixture) fixture { return f })
+	if _, err := newTestClient(t, srv).CreateTeam(Team{Name: "test"}); err == nil {
+		t.Error("expected an error when the team wasn't created")
+	}
+}
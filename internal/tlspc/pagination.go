@@ -0,0 +1,108 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// listPageSize is the number of objects requested per page from list
+// endpoints.
+var listPageSize = 100
+
+// getAllPages fetches every page of a list endpoint, using decode to extract
+// the objects from each page. what describes the objects in error messages.
+//
+// Pages are requested with the pageNumber (from 0) and pageSize query
+// parameters. Paging stops at the first short page; endpoints which don't
+// support paging return everything in one response, which is detected by it
+// being longer than a page or being repeated.
+func getAllPages[T any](c *Client, path string, what string, decode func([]byte) ([]T, error)) ([]T, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing path: %s", err)
+	}
+	query := u.Query()
+
+	var all []T
+	var prev []byte
+	for page := 0; ; page++ {
+		query.Set("pageNumber", strconv.Itoa(page))
+		query.Set("pageSize", strconv.Itoa(listPageSize))
+		u.RawQuery = query.Encode()
+
+		resp, err := c.Get(u.String())
+		if err != nil {
+			return nil, fmt.Errorf("Error getting %s: %s", what, err)
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading response body: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, newAPIError(resp, "Failed to get "+what, respBody)
+		}
+		if prev != nil && bytes.Equal(respBody, prev) {
+			break
+		}
+		items, err := decode(respBody)
+		if err != nil {
+			return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+		}
+		all = append(all, items...)
+
+		if len(items) != listPageSize {
+			break
+		}
+		prev = respBody
+	}
+
+	return all, nil
+}
+
+// searchAllPages fetches every page of results of a search endpoint, up to
+// limit results if it's positive, using decode to extract the objects from
+// each page. what describes the search in error messages.
+func searchAllPages[T any](c *Client, path string, what string, search searchRequest, limit int, decode func([]byte) ([]T, error)) ([]T, error) {
+	search.Paging = searchPaging{PageSize: listPageSize}
+
+	all := []T{}
+	for {
+		body, err := json.Marshal(search)
+		if err != nil {
+			return nil, fmt.Errorf("Error encoding request: %s", err)
+		}
+
+		resp, err := c.Post(path, body)
+		if err != nil {
+			return nil, fmt.Errorf("Error posting request: %s", err)
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading response body: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, newAPIError(resp, "Failed to search "+what, respBody)
+		}
+		items, err := decode(respBody)
+		if err != nil {
+			return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+		}
+		all = append(all, items...)
+
+		if limit > 0 && len(all) >= limit {
+			return all[:limit], nil
+		}
+		if len(items) < search.Paging.PageSize {
+			return all, nil
+		}
+		search.Paging.PageNumber++
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func setListPageSize(t *testing.T, n int) {
+	t.Helper()
+
+	prev := listPageSize
+	listPageSize = n
+	t.Cleanup(func() { listPageSize = prev })
+}
+
+// tagServer serves count tags from /v1/tags, paging them if paged is set.
+func tagServer(t *testing.T, count int, paged bool) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var tags []Tag
+	for i := 0; i < count; i++ {
+		tags = append(tags, Tag{ID: strconv.Itoa(i), Name: fmt.Sprintf("tag-%d", i)})
+	}
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		page := tags
+		if paged {
+			number, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+			size, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+			start := min(number*size, len(tags))
+			page = tags[start:min(start+size, len(tags))]
+		}
+		_ = json.NewEncoder(w).Encode(Tags{Tags: page})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestGetAllPages(t *testing.T) {
+	setListPageSize(t, 2)
+
+	cases := []struct {
+		name     string
+		count    int
+		paged    bool
+		requests int32
+	}{
+		{name: "empty", count: 0, paged: true, requests: 1},
+		{name: "partial last page", count: 5, paged: true, requests: 3},
+		{name: "full last page", count: 4, paged: true, requests: 3},
+		{name: "unpaged longer than a page", count: 5, paged: false, requests: 1},
+		{name: "unpaged exactly a page", count: 2, paged: false, requests: 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, requests := tagServer(t, tc.count, tc.paged)
+
+			tags, err := newTestClient(t, srv).GetTags()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(tags) != tc.count {
+				t.Errorf("expected %d tags, got %d", tc.count, len(tags))
+			}
+			for i, tag := range tags {
+				if tag.ID != strconv.Itoa(i) {
+					t.Errorf("expected tag %d, got %s", i, tag.ID)
+				}
+			}
+			if got := requests.Load(); got != tc.requests {
+				t.Errorf("expected %d requests, got %d", tc.requests, got)
+			}
+		})
+	}
+}
+
+func TestGetAllPagesKeepsQuery(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("entityId"); got != testID {
+			t.Errorf("expected entityId %s, got %q", testID, got)
+		}
+		if got := r.URL.Query().Get("pageNumber"); got != "0" {
+			t.Errorf("expected pageNumber 0, got %q", got)
+		}
+		_, _ = w.Write([]byte(`{"tags":["env:prod"]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	if _, err := newTestClient(t, srv).GetAssignedTags("CERTIFICATE", testID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// PinMismatchError is returned when none of the certificates presented by
+// the API match a pinned SPKI hash.
+type PinMismatchError struct {
+	// Got are the SPKI hashes of the certificates presented.
+	Got []string
+}
+
+func (e *PinMismatchError) Error() string {
+	return fmt.Sprintf("The certificate chain presented by the API doesn't match any pinned SPKI hash, got %s. If the endpoint's certificate has changed, check the new hashes are expected before pinning them", strings.Join(e.Got, ", "))
+}
+
+// spkiHash returns the base64 encoded SHA-256 hash of a certificate's
+// SubjectPublicKeyInfo.
+func spkiHash(rawSPKI []byte) string {
+	sum := sha256.Sum256(rawSPKI)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// pinnedTransport returns a copy of base which only completes TLS handshakes
+// with servers whose verified certificate chain includes a certificate with
+// one of the pinned SPKI hashes.
+func pinnedTransport(base *http.Transport, pins map[string]bool) *http.Transport {
+	t := base.Clone()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+	t.TLSClientConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		got := []string{}
+		for _, chain := range cs.VerifiedChains {
+			for _, cert := range chain {
+				hash := spkiHash(cert.RawSubjectPublicKeyInfo)
+				if pins[hash] {
+					return nil
+				}
+				got = append(got, hash)
+			}
+		}
+		return &PinMismatchError{Got: got}
+	}
+	return t
+}
+
+// SetPinnedSPKIHashes pins the certificates of the API to those whose
+// SubjectPublicKeyInfo has one of hashes, each a base64 encoded SHA-256 hash.
+// Requests to a server without one in its certificate chain fail with a
+// PinMismatchError. It should be called before the Client is used.
+func (c *Client) SetPinnedSPKIHashes(hashes []string) error {
+	if len(hashes) == 0 {
+		c.base = nil
+		return nil
+	}
+
+	pins := map[string]bool{}
+	for _, h := range hashes {
+		sum, err := base64.StdEncoding.DecodeString(h)
+		if err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("Invalid SPKI hash %q, expected a base64 encoded SHA-256 hash", h)
+		}
+		pins[h] = true
+	}
+
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("Can't pin certificates with the default transport %T", http.DefaultTransport)
+	}
+	c.base = pinnedTransport(base, pins)
+	return nil
+}
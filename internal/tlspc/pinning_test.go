@@ -0,0 +1,69 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPinnedSPKIHashes(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(idBody))
+	}))
+	t.Cleanup(srv.Close)
+
+	serverPin := spkiHash(srv.Certificate().RawSubjectPublicKeyInfo)
+	otherPin := spkiHash([]byte("other"))
+
+	cases := []struct {
+		name    string
+		pins    []string
+		wantErr string
+	}{
+		{name: "matching", pins: []string{otherPin, serverPin}},
+		{name: "mismatched", pins: []string{otherPin}, wantErr: "doesn't match any pinned SPKI hash, got " + serverPin},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pins := map[string]bool{}
+			for _, p := range tc.pins {
+				pins[p] = true
+			}
+			c := newTestClient(t, srv)
+			c.base = pinnedTransport(srv.Client().Transport.(*http.Transport), pins)
+
+			_, err := c.GetTeam(testID)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSetPinnedSPKIHashes(t *testing.T) {
+	c, _ := NewClient(testAPIKey, "", "test")
+
+	for _, h := range []string{"not base64!", "c2hvcnQ="} {
+		if err := c.SetPinnedSPKIHashes([]string{h}); err == nil {
+			t.Errorf("expected error for %q", h)
+		}
+	}
+
+	if err := c.SetPinnedSPKIHashes([]string{spkiHash([]byte("key"))}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if c.base == nil {
+		t.Error("expected pinned transport to be set")
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrPollTimeout is returned by Poll when an operation doesn't complete in
+// time.
+var ErrPollTimeout = errors.New("Timed out waiting for operation to complete")
+
+// PollOptions control how often and for how long Poll checks an operation.
+type PollOptions struct {
+	// Interval is the delay between checks.
+	Interval time.Duration
+	// MaxInterval, if greater than Interval, makes the delay double after
+	// each check, up to MaxInterval.
+	MaxInterval time.Duration
+	// Timeout, if set, limits how long Poll waits in total.
+	Timeout time.Duration
+	// Attempts, if set, limits how many times the operation is checked.
+	Attempts int
+}
+
+// Poll calls check until it reports that an asynchronous operation is done,
+// returns an error, or the limits set by opts or ctx are reached. ctx is
+// passed to check, and has the timeout applied.
+func (c *Client) Poll(ctx context.Context, opts PollOptions, check func(context.Context) (bool, error)) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	interval := opts.Interval
+	for attempt := 1; ; attempt++ {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+		if opts.Attempts > 0 && attempt >= opts.Attempts {
+			return ErrPollTimeout
+		}
+
+		t := time.NewTimer(interval)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			if opts.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ErrPollTimeout
+			}
+			return ctx.Err()
+		}
+
+		if opts.MaxInterval > interval {
+			interval = min(interval*2, opts.MaxInterval)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoll(t *testing.T) {
+	c := &Client{}
+	errCheck := errors.New("check failed")
+
+	cases := []struct {
+		name   string
+		opts   PollOptions
+		doneAt int
+		err    error
+		calls  int
+		want   error
+	}{
+		{name: "done immediately", doneAt: 1, calls: 1},
+		{name: "done after retries", opts: PollOptions{Interval: time.Millisecond, MaxInterval: 4 * time.Millisecond}, doneAt: 4, calls: 4},
+		{name: "check error", err: errCheck, calls: 1, want: errCheck},
+		{name: "attempts exhausted", opts: PollOptions{Attempts: 3}, calls: 3, want: ErrPollTimeout},
+		{name: "timeout", opts: PollOptions{Interval: 20 * time.Millisecond, Timeout: 30 * time.Millisecond}, calls: 2, want: ErrPollTimeout},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			err := c.Poll(context.Background(), tc.opts, func(context.Context) (bool, error) {
+				calls++
+				return calls == tc.doneAt, tc.err
+			})
+			if !errors.Is(err, tc.want) {
+				t.Errorf("expected error %v, got %v", tc.want, err)
+			}
+			if calls != tc.calls {
+				t.Errorf("expected %d calls, got %d", tc.calls, calls)
+			}
+		})
+	}
+}
+
+func TestPollCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := (&Client{}).Poll(ctx, PollOptions{Interval: time.Hour}, func(context.Context) (bool, error) {
+		return false, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// When the API returns 429 Too Many Requests, requests are paused for the
+// period given by the Retry-After header (or defaultRetryAfter if there isn't
+// one) and then retried, up to maxRateLimitRetries times.
+var (
+	maxRateLimitRetries = 5
+	defaultRetryAfter   = 5 * time.Second
+)
+
+// JitterStrategy is how requests held back by a pause are spread out when it
+// ends, so that they don't all resume at once and cause another one.
+type JitterStrategy string
+
+const (
+	// JitterNone resumes every request as soon as the pause ends.
+	JitterNone JitterStrategy = "none"
+	// JitterEqual resumes each request at a random time within half the
+	// length of the pause after it ends.
+	JitterEqual JitterStrategy = "equal"
+	// JitterFull resumes each request at a random time within the length of
+	// the pause after it ends.
+	JitterFull JitterStrategy = "full"
+)
+
+// JitterStrategies are the valid JitterStrategy values.
+var JitterStrategies = []string{string(JitterNone), string(JitterEqual), string(JitterFull)}
+
+// delay returns a random delay to add to a pause of length d.
+func (s JitterStrategy) delay(d time.Duration) time.Duration {
+	switch s {
+	case JitterEqual:
+		d /= 2
+	case JitterFull:
+	default:
+		return 0
+	}
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
+
+// rateLimiter is shared by all requests made by a Client, so that once one
+// request is rate limited the others wait too rather than adding to the load.
+type rateLimiter struct {
+	mu     sync.Mutex
+	until  time.Time
+	length time.Duration
+	jitter JitterStrategy
+
+	// budget is how many more rate limited requests may be retried, if
+	// limited is set.
+	budget  int
+	limited bool
+
+	quota RateLimitQuota
+	// lowQuota is set while the quota is low, and warnLowQuota when that
+	// hasn't been reported yet.
+	lowQuota     bool
+	warnLowQuota bool
+}
+
+// pause holds back requests for at least d.
+func (l *rateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.until) {
+		l.until = until
+		l.length = d
+	}
+}
+
+// retry reports whether a rate limited request may be retried, using up
+// some of the retry budget if it may.
+func (l *rateLimiter) retry() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.limited {
+		return true
+	}
+	if l.budget <= 0 {
+		return false
+	}
+	l.budget--
+	return true
+}
+
+// wait blocks until requests are no longer paused, plus any jitter, or the
+// request is cancelled.
+func (l *rateLimiter) wait(req *http.Request) error {
+	l.mu.Lock()
+	d := time.Until(l.until)
+	if d > 0 {
+		d += l.jitter.delay(l.length)
+	}
+	l.mu.Unlock()
+
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// lowQuotaFraction is the fraction of the rate limit quota remaining below
+// which it's reported as low.
+var lowQuotaFraction = 0.1
+
+// RateLimitQuota is the tenant's API rate limit quota, as reported by the
+// latest response which included it.
+type RateLimitQuota struct {
+	Limit     int
+	Remaining int
+	// Reset is when the quota is replenished, or zero if it's not known.
+	Reset time.Time
+}
+
+// rateLimitHeaderPrefixes are the prefixes of the Limit, Remaining and Reset
+// headers the quota may be reported in.
+var rateLimitHeaderPrefixes = []string{"X-RateLimit-", "RateLimit-"}
+
+// parseRateLimitQuota parses the rate limit quota from response headers h.
+// Reset is given as a number of seconds from now.
+func parseRateLimitQuota(h http.Header, now time.Time) (RateLimitQuota, bool) {
+	for _, prefix := range rateLimitHeaderPrefixes {
+		limit, err := strconv.Atoi(h.Get(prefix + "Limit"))
+		if err != nil {
+			continue
+		}
+		remaining, err := strconv.Atoi(h.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+		quota := RateLimitQuota{Limit: limit, Remaining: remaining}
+		if secs, err := strconv.Atoi(h.Get(prefix + "Reset")); err == nil && secs >= 0 {
+			quota.Reset = now.Add(time.Duration(secs) * time.Second)
+		}
+		return quota, true
+	}
+	return RateLimitQuota{}, false
+}
+
+// observe records the rate limit quota reported in response headers h.
+func (l *rateLimiter) observe(h http.Header) {
+	quota, ok := parseRateLimitQuota(h, time.Now())
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.quota = quota
+	low := float64(quota.Remaining) <= float64(quota.Limit)*lowQuotaFraction
+	if low && !l.lowQuota {
+		l.warnLowQuota = true
+	}
+	l.lowQuota = low
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// rateLimitTransport retries requests which are rate limited, pausing all
+// other requests using the same rateLimiter in the meantime.
+type rateLimitTransport struct {
+	limiter *rateLimiter
+	hooks   []Hooks
+	rt      http.RoundTripper
+}
+
+func (t rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt := t.rt
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.wait(req); err != nil {
+			return nil, err
+		}
+
+		if attempt > 0 && req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		info := RequestInfo{Method: req.Method, Path: req.URL.Path, Attempt: attempt}
+		for _, h := range t.hooks {
+			h.RequestStarted(info)
+		}
+		start := time.Now()
+		resp, err := rt.RoundTrip(req)
+		result := RequestResult{Latency: time.Since(start), Err: err}
+		if resp != nil {
+			result.StatusCode = resp.StatusCode
+			t.limiter.observe(resp.Header)
+		}
+		for _, h := range t.hooks {
+			h.RequestFinished(info, result)
+		}
+
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRateLimitRetries || !t.limiter.retry() {
+			return resp, err
+		}
+
+		d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			d = defaultRetryAfter
+		}
+		t.limiter.pause(d)
+
+		// Drain the body so that the connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
+
+// SetRetryJitter sets how requests held back after being rate limited are
+// spread out. It should be called before the Client is used.
+func (c *Client) SetRetryJitter(s JitterStrategy) error {
+	switch s {
+	case JitterNone, JitterEqual, JitterFull:
+	default:
+		return fmt.Errorf("Unknown jitter strategy: %s", s)
+	}
+	c.limiter.jitter = s
+	return nil
+}
+
+// SetRetryBudget limits the total number of times rate limited requests are
+// retried, across every request made by the Client. Once it's used up, rate
+// limited requests fail straight away. It should be called before the Client
+// is used.
+func (c *Client) SetRetryBudget(n int) {
+	c.limiter.budget = n
+	c.limiter.limited = true
+}
+
+// LowRateLimitQuota returns the rate limit quota and true the first time a
+// response reports that little of it remains. It returns false until the
+// quota has recovered and then become low again.
+func (c *Client) LowRateLimitQuota() (RateLimitQuota, bool) {
+	c.limiter.mu.Lock()
+	defer c.limiter.mu.Unlock()
+
+	if !c.limiter.warnLowQuota {
+		return RateLimitQuota{}, false
+	}
+	c.limiter.warnLowQuota = false
+	return c.limiter.quota, true
+}
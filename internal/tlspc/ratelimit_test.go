@@ -0,0 +1,227 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "3", want: 3 * time.Second, ok: true},
+		{value: "0", want: 0, ok: true},
+		{value: "-1", ok: false},
+		{value: "soon", ok: false},
+		{value: now.Add(10 * time.Second).Format(http.TimeFormat), want: 10 * time.Second, ok: true},
+		{value: now.Add(-10 * time.Second).Format(http.TimeFormat), want: 0, ok: true},
+	}
+	for _, tc := range cases {
+		got, ok := parseRetryAfter(tc.value, now)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("parseRetryAfter(%q) = %s, %t; want %s, %t", tc.value, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+// rateLimitedServer responds with 429 to the first limited requests, then
+// with body.
+func rateLimitedServer(t *testing.T, limited int32, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if b, _ := io.ReadAll(r.Body); len(b) == 0 {
+				t.Errorf("request %d was sent without a body", requests.Load()+1)
+			}
+		}
+		if requests.Add(1) <= limited {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestRateLimitRetries(t *testing.T) {
+	srv, requests := rateLimitedServer(t, 2, idBody)
+
+	_, err := newTestClient(t, srv).CreateTeam(Team{Name: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestRateLimitRetriesGraphQL(t *testing.T) {
+	srv, requests := rateLimitedServer(t, 1, `{"data":{"cloudProviders":{"totalCount":1,"nodes":[`+gcpProvider+`]}}}`)
+
+	_, err := newTestClient(t, srv).GetCloudProviderGCP(context.Background(), testID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestRateLimitGivesUp(t *testing.T) {
+	srv, requests := rateLimitedServer(t, 1000, idBody)
+
+	_, err := newTestClient(t, srv).GetTeam(testID)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if got, want := requests.Load(), int32(maxRateLimitRetries+1); got != want {
+		t.Errorf("expected %d requests, got %d", want, got)
+	}
+}
+
+func TestRateLimiterPausesAllRequests(t *testing.T) {
+	l := &rateLimiter{}
+	l.pause(50 * time.Millisecond)
+	// A shorter pause mustn't cut the existing one short.
+	l.pause(time.Millisecond)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	start := time.Now()
+	if err := l.wait(req); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("expected to wait for the pause, waited %s", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l.pause(time.Hour)
+	if err := l.wait(req.WithContext(ctx)); err == nil {
+		t.Error("expected cancelled wait to return an error")
+	}
+}
+
+func TestJitterDelay(t *testing.T) {
+	d := time.Second
+	cases := []struct {
+		strategy JitterStrategy
+		max      time.Duration
+	}{
+		{strategy: JitterNone, max: 0},
+		{strategy: JitterEqual, max: d / 2},
+		{strategy: JitterFull, max: d},
+	}
+	for _, tc := range cases {
+		for i := 0; i < 100; i++ {
+			got := tc.strategy.delay(d)
+			if got < 0 || got > tc.max {
+				t.Fatalf("%s jitter for %s = %s, want at most %s", tc.strategy, d, got, tc.max)
+			}
+		}
+	}
+	if got := JitterFull.delay(0); got != 0 {
+		t.Errorf("expected no jitter without a pause, got %s", got)
+	}
+
+	if err := (&Client{limiter: &rateLimiter{}}).SetRetryJitter("sometimes"); err == nil {
+		t.Error("expected an unknown strategy to be rejected")
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	srv, requests := rateLimitedServer(t, 1000, idBody)
+
+	c := newTestClient(t, srv)
+	c.SetRetryBudget(3)
+
+	// The first request uses up the budget, so the second isn't retried.
+	for _, want := range []int32{4, 5} {
+		if _, err := c.GetTeam(testID); err == nil {
+			t.Fatal("expected an error")
+		}
+		if got := requests.Load(); got != want {
+			t.Errorf("expected %d requests, got %d", want, got)
+		}
+	}
+}
+
+func TestParseRateLimitQuota(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		headers map[string]string
+		want    RateLimitQuota
+		ok      bool
+	}{
+		{name: "none", ok: false},
+		{name: "x-ratelimit", headers: map[string]string{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "7", "X-RateLimit-Reset": "30"}, want: RateLimitQuota{Limit: 100, Remaining: 7, Reset: now.Add(30 * time.Second)}, ok: true},
+		{name: "ratelimit without reset", headers: map[string]string{"RateLimit-Limit": "100", "RateLimit-Remaining": "50"}, want: RateLimitQuota{Limit: 100, Remaining: 50}, ok: true},
+		{name: "missing remaining", headers: map[string]string{"X-RateLimit-Limit": "100"}, ok: false},
+	}
+	for _, tc := range cases {
+		h := http.Header{}
+		for k, v := range tc.headers {
+			h.Set(k, v)
+		}
+		got, ok := parseRateLimitQuota(h, now)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("%s: got %+v, %t; want %+v, %t", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestLowRateLimitQuota(t *testing.T) {
+	var remaining atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(remaining.Load())))
+		_, _ = w.Write([]byte(idBody))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := newTestClient(t, srv)
+	for _, tc := range []struct {
+		remaining int32
+		low       bool
+	}{
+		{remaining: 50, low: false},
+		{remaining: 5, low: true},
+		// Only reported once while it stays low.
+		{remaining: 4, low: false},
+		{remaining: 80, low: false},
+		{remaining: 10, low: true},
+	} {
+		remaining.Store(tc.remaining)
+		if _, err := c.GetTeam(testID); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		quota, low := c.LowRateLimitQuota()
+		if low != tc.low {
+			t.Errorf("with %d remaining, expected low %t", tc.remaining, tc.low)
+		}
+		if low && quota.Remaining != int(tc.remaining) {
+			t.Errorf("expected %d remaining, got %d", tc.remaining, quota.Remaining)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"context"
+	"time"
+)
+
+// Some objects aren't immediately readable after they have been created.
+// These control how long waitUntilReadable will wait for them.
+var (
+	readAfterWriteAttempts = 5
+	readAfterWriteDelay    = time.Second
+)
+
+// waitUntilReadable calls read until it succeeds, backing off between
+// attempts, and returns the last error if it never does.
+func (c *Client) waitUntilReadable(read func() error) error {
+	var err error
+	opts := PollOptions{
+		Interval:    readAfterWriteDelay,
+		MaxInterval: readAfterWriteDelay << (readAfterWriteAttempts - 1),
+		Attempts:    readAfterWriteAttempts,
+	}
+	_ = c.Poll(context.Background(), opts, func(context.Context) (bool, error) {
+		err = read()
+		return err == nil, nil
+	})
+
+	return err
+}
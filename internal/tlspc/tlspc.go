@@ -6,19 +6,53 @@ package tlspc
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 const DefaultEndpoint = "https://api.venafi.cloud"
 
+// APIVersionHeader is the request header used to pin the API version.
+const APIVersionHeader = "tppl-api-version"
+
+// regions maps the hostnames of the API in each region to the region.
+var regions = map[string]string{
+	"api.venafi.cloud":    "US",
+	"api.venafi.eu":       "EU",
+	"api.au.venafi.cloud": "AU",
+	"api.uk.venafi.cloud": "UK",
+	"api.sg.venafi.cloud": "SG",
+	"api.ca.venafi.cloud": "CA",
+}
+
 type Client struct {
-	apikey   string
-	endpoint string
-	version  string
+	apikey           string
+	endpoint         string
+	version          string
+	userAgentSuffix  string
+	apiVersion       string
+	compressRequests bool
+	limiter          *rateLimiter
+	concurrency      chan struct{}
+	base             http.RoundTripper
+	hooks            Hooks
+	stats            *requestStats
+	responses        *responseCache
+
+	caAccounts *ttlCache[[]caAccount]
+	scopes     *ttlCache[[]string]
+
+	serviceAccount *serviceAccountToken
+
+	certificateApplicationsMu sync.Mutex
+	applicationsMu            sync.Mutex
 }
 
 func NewClient(apikey, endpoint, version string) (*Client, error) {
@@ -30,27 +64,131 @@ func NewClient(apikey, endpoint, version string) (*Client, error) {
 		apikey:   apikey,
 		endpoint: endpoint,
 		version:  version,
+		limiter:  &rateLimiter{jitter: JitterEqual},
+		stats:    &requestStats{},
+
+		caAccounts: newTTLCache[[]caAccount](caAccountsTTL),
+		scopes:     newTTLCache[[]string](scopesTTL),
 	}, nil
 }
 
 func (c *Client) doRequest(method, path string, body []byte) (*http.Response, error) {
+	req, err := c.newRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.send(req)
+}
+
+// newRequest returns a request to the API with the headers common to all
+// requests set.
+func (c *Client) newRequest(method, path string, body []byte) (*http.Request, error) {
 	req, err := http.NewRequest(method, path, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("tppl-api-key", c.apikey)
-	req.Header.Set("User-Agent", "terraform-provider-tlspc/"+c.version)
+	req.Header.Set("User-Agent", c.userAgent())
+	if c.apiVersion != "" {
+		req.Header.Set(APIVersionHeader, c.apiVersion)
+	}
+
+	return req, nil
+}
 
-	client := http.Client{}
+// SetUserAgentSuffix sets a suffix to append to the User-Agent sent with
+// requests, e.g. "my-platform/2.3".
+func (c *Client) SetUserAgentSuffix(suffix string) {
+	c.userAgentSuffix = suffix
+}
+
+// SetAPIVersion pins the version of the API requested, so that responses
+// keep the same shape when the default version changes. An empty version
+// leaves it to the API.
+func (c *Client) SetAPIVersion(version string) {
+	c.apiVersion = version
+}
+
+// SetCompressRequests sets whether large request bodies are gzip compressed.
+// Responses are always requested compressed.
+func (c *Client) SetCompressRequests(compress bool) {
+	c.compressRequests = compress
+}
+
+// SetResponseCacheTTL enables caching successful GET responses for ttl, so
+// that repeated reads of the same path share a request. Any other request
+// clears the cache. A ttl of 0 disables caching.
+func (c *Client) SetResponseCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		c.responses = nil
+		return
+	}
+	c.responses = newResponseCache(ttl)
+}
+
+func (c *Client) userAgent() string {
+	ua := "terraform-provider-tlspc/" + c.version
+	if c.userAgentSuffix != "" {
+		ua += " " + c.userAgentSuffix
+	}
+	return ua
+}
+
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.responses != nil && req.Method != http.MethodGet {
+		c.responses.clear()
+	}
+
+	client := http.Client{Transport: c.transport()}
 	return client.Do(req)
 }
 
+// transport returns the http.RoundTripper used for all requests to the API.
+func (c *Client) transport() http.RoundTripper {
+	var base http.RoundTripper = http.DefaultTransport
+	if c.base != nil {
+		base = c.base
+	}
+	if c.concurrency != nil {
+		base = concurrencyTransport{sem: c.concurrency, rt: base}
+	}
+	var rt http.RoundTripper = compressionTransport{
+		compressRequests: c.compressRequests,
+		rt:               rateLimitTransport{limiter: c.limiter, hooks: c.requestHooks(), rt: base},
+	}
+	if c.serviceAccount != nil {
+		rt = serviceAccountTransport{c: c, rt: rt}
+	}
+	return rt
+}
+
+// Region returns the region of the API endpoint, or an empty string if the
+// endpoint isn't one of the regional endpoints.
+func (c *Client) Region() string {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return ""
+	}
+	return regions[u.Hostname()]
+}
+
+// Endpoint returns the URL of the API.
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
 func (c *Client) Path(tmpl string) string {
 	return fmt.Sprintf(tmpl, c.endpoint)
 }
 
 func (c *Client) Get(path string) (*http.Response, error) {
+	if c.responses != nil {
+		return c.responses.get(path, func() (*http.Response, error) {
+			return c.doRequest("GET", path, nil)
+		})
+	}
 	return c.doRequest("GET", path, nil)
 }
 
@@ -73,14 +211,52 @@ func (c *Client) Delete(path string, body []byte) (*http.Response, error) {
 type User struct {
 	Username string `json:"username"`
 	ID       string `json:"id"`
+	Status   string `json:"userStatus"`
 }
 
 type Users struct {
 	Users []User `json:"users"`
 }
 
-func (c *Client) GetUser(email string) (*User, error) {
-	// Return a single user based on email matching.
+// GetUser returns the single user whose username is exactly email. The API
+// matches usernames by prefix, so other users may be returned too. If status
+// isn't empty, only users in that status are considered.
+func (c *Client) GetUser(email, status string) (*User, error) {
+	users, err := c.GetUsers(email)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []User
+	for _, u := range users {
+		if !strings.EqualFold(u.Username, email) {
+			continue
+		}
+		if status != "" && u.Status != status {
+			continue
+		}
+		matches = append(matches, u)
+	}
+
+	if len(matches) == 0 {
+		if status != "" {
+			return nil, fmt.Errorf("User not found: %s with status %s", email, status)
+		}
+		return nil, fmt.Errorf("User not found: %s", email)
+	}
+	if len(matches) > 1 {
+		statuses := []string{}
+		for _, u := range matches {
+			statuses = append(statuses, u.Status)
+		}
+		return nil, fmt.Errorf("Unexpected number of users returned (%d) for %s, with statuses: %s", len(matches), email, strings.Join(statuses, ", "))
+	}
+
+	return &matches[0], nil
+}
+
+// GetUsers returns the users whose username starts with email.
+func (c *Client) GetUsers(email string) ([]User, error) {
 	// Ignore deleted users which the API will return.
 	// Disabled users are returned but other API calls will error this state.
 	// Refer to https://developer.venafi.com/tlsprotectcloud/reference/get-v1-users for details of the API behaviour.
@@ -91,24 +267,35 @@ func (c *Client) GetUser(email string) (*User, error) {
 	queryParams.Set("username", email)
 	path = path + "?" + queryParams.Encode()
 
+	return getAllPages(c, path, "users", func(body []byte) ([]User, error) {
+		var users Users
+		err := json.Unmarshal(body, &users)
+		return users.Users, err
+	})
+}
+
+func (c *Client) GetUserByID(id string) (*User, error) {
+	path := c.Path(`%s/v1/users/` + id)
+
 	resp, err := c.Get(path)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting user: %s", err)
 	}
-	body, err := io.ReadAll(resp.Body)
+
+	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("Error reading response body: %s", err)
 	}
-	var users Users
-	err = json.Unmarshal(body, &users)
+	var user User
+	err = json.Unmarshal(respBody, &user)
 	if err != nil {
-		return nil, fmt.Errorf("Error decoding response: %s", string(body))
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
-	if len(users.Users) != 1 {
-		return nil, fmt.Errorf("Unexpected number of users returned (%d)", len(users.Users))
+	if user.ID == "" {
+		return nil, newAPIError(resp, "Didn't find a user", respBody)
 	}
 
-	return &users.Users[0], nil
+	return &user, nil
 }
 
 type Team struct {
@@ -133,23 +320,18 @@ type Teams struct {
 func (c *Client) GetTeamByName(name string) (*Team, error) {
 	path := c.Path(`%s/v1/teams`)
 
-	resp, err := c.Get(path)
-	if err != nil {
-		return nil, fmt.Errorf("Error getting teams: %s", err)
-	}
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("Error reading response body: %s", err)
-	}
-	var teams Teams
-	err = json.Unmarshal(body, &teams)
+	teams, err := getAllPages(c, path, "teams", func(body []byte) ([]Team, error) {
+		var teams Teams
+		err := json.Unmarshal(body, &teams)
+		return teams.Teams, err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("Error decoding response: %s", string(body))
+		return nil, err
 	}
 
 	var teamsByName []Team
 	// Loop through all teams and append only those with matching name to teamsByName.
-	for _, t := range teams.Teams {
+	for _, t := range teams {
 		if t.Name == name {
 			teamsByName = append(teamsByName, t)
 		}
@@ -172,7 +354,12 @@ func (c *Client) CreateTeam(team Team) (*Team, error) {
 		return nil, fmt.Errorf("Error encoding request: %s", err)
 	}
 
-	resp, err := c.Post(path, body)
+	resp, err := c.postCreate(path, body)
+	if createUncertain(err) {
+		if existing, findErr := c.GetTeamByName(team.Name); findErr == nil {
+			return existing, nil
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Error posting request: %s", err)
 	}
@@ -187,7 +374,7 @@ func (c *Client) CreateTeam(team Team) (*Team, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if created.ID == "" {
-		return nil, fmt.Errorf("Didn't create a team; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't create a team", respBody)
 	}
 
 	return &created, nil
@@ -211,7 +398,7 @@ func (c *Client) GetTeam(id string) (*Team, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if team.ID == "" {
-		return nil, fmt.Errorf("Didn't find a Team; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't find a Team", respBody)
 	}
 
 	return &team, nil
@@ -250,7 +437,7 @@ func (c *Client) UpdateTeam(team Team) (*Team, error) {
 		return nil, fmt.Errorf("Error reading response body: %s", err)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Failed to update Team; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Failed to update Team", respBody)
 	}
 	var updated Team
 	err = json.Unmarshal(respBody, &updated)
@@ -258,7 +445,7 @@ func (c *Client) UpdateTeam(team Team) (*Team, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if updated.ID == "" {
-		return nil, fmt.Errorf("Didn't get a Team ID; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't get a Team ID", respBody)
 	}
 
 	return &updated, nil
@@ -294,7 +481,7 @@ func (c *Client) AddTeamOwners(id string, owners []string) (*Team, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if updated.ID == "" {
-		return nil, fmt.Errorf("Didn't get a Team ID; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't get a Team ID", respBody)
 	}
 
 	return &updated, nil
@@ -326,12 +513,128 @@ func (c *Client) RemoveTeamOwners(id string, owners []string) (*Team, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if updated.ID == "" {
-		return nil, fmt.Errorf("Didn't get a Team ID; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't get a Team ID", respBody)
 	}
 
 	return &updated, nil
 }
 
+// TeamDependents are the objects owned by a team, which prevent it from being
+// deleted.
+type TeamDependents struct {
+	ServiceAccounts []ServiceAccount
+	Applications    []Application
+}
+
+// Empty reports whether there are no dependents.
+func (d TeamDependents) Empty() bool {
+	return len(d.ServiceAccounts) == 0 && len(d.Applications) == 0
+}
+
+// GetTeamDependents returns the service accounts and applications owned by a
+// team.
+func (c *Client) GetTeamDependents(id string) (*TeamDependents, error) {
+	sas, err := c.GetServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+	apps, err := c.GetApplications()
+	if err != nil {
+		return nil, err
+	}
+
+	var deps TeamDependents
+	for _, sa := range sas {
+		if sa.Owner == id {
+			deps.ServiceAccounts = append(deps.ServiceAccounts, sa)
+		}
+	}
+	for _, app := range apps {
+		for _, o := range app.Owners {
+			if o.ID == id {
+				deps.Applications = append(deps.Applications, app)
+				break
+			}
+		}
+	}
+
+	return &deps, nil
+}
+
+// SetTeamOwners changes the owners of a team to owners. New owners are added
+// before old ones are removed, so that the team is never left without an
+// owner, and the changes are rolled back if the removals fail or the owners
+// aren't as expected afterwards.
+func (c *Client) SetTeamOwners(id string, owners []string) (*Team, error) {
+	if len(owners) == 0 {
+		return nil, errors.New("A team must have at least one owner")
+	}
+
+	team, err := c.GetTeam(id)
+	if err != nil {
+		return nil, err
+	}
+	current := map[string]bool{}
+	for _, o := range team.Owners {
+		current[o] = true
+	}
+	wanted := map[string]bool{}
+	for _, o := range owners {
+		wanted[o] = true
+	}
+	var add, remove []string
+	for _, o := range owners {
+		if !current[o] {
+			add = append(add, o)
+		}
+	}
+	for _, o := range team.Owners {
+		if !wanted[o] {
+			remove = append(remove, o)
+		}
+	}
+
+	if len(add) > 0 {
+		team, err = c.AddTeamOwners(id, add)
+		if err != nil {
+			return nil, fmt.Errorf("Error adding owners: %s", err)
+		}
+	}
+	if len(remove) > 0 {
+		team, err = c.RemoveTeamOwners(id, remove)
+		if err != nil {
+			return nil, c.rollBackTeamOwners(id, add, nil, fmt.Errorf("Error removing owners: %s", err))
+		}
+	}
+
+	unexpected := len(team.Owners) != len(wanted)
+	for _, o := range team.Owners {
+		unexpected = unexpected || !wanted[o]
+	}
+	if unexpected {
+		return nil, c.rollBackTeamOwners(id, add, remove, fmt.Errorf("Team owners are %v after updating, expected %v", team.Owners, owners))
+	}
+
+	return team, nil
+}
+
+// rollBackTeamOwners undoes SetTeamOwners adding and removing owners of team
+// id after it failed with cause. Removed owners are restored first, so that
+// the team is never left without an owner.
+func (c *Client) rollBackTeamOwners(id string, added, removed []string, cause error) error {
+	if len(removed) > 0 {
+		if _, err := c.AddTeamOwners(id, removed); err != nil {
+			return fmt.Errorf("%s; rolling back the removed owners also failed: %s", cause, err)
+		}
+	}
+	if len(added) > 0 {
+		if _, err := c.RemoveTeamOwners(id, added); err != nil {
+			return fmt.Errorf("%s; rolling back the added owners also failed: %s", cause, err)
+		}
+	}
+	return cause
+}
+
 func (c *Client) DeleteTeam(id string) error {
 	path := c.Path(`%s/v1/teams/` + id)
 
@@ -344,7 +647,7 @@ func (c *Client) DeleteTeam(id string) error {
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to delete team; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to delete team", respBody)
 	}
 
 	return nil
@@ -360,6 +663,7 @@ type ServiceAccount struct {
 	AuthenticationType string   `json:"authenticationType,omitempty"`
 	OciAccountName     string   `json:"ociAccountName,omitempty"`
 	OciRegistryToken   string   `json:"ociRegistryToken,omitempty"`
+	CredentialsExpiry  string   `json:"credentialsExpiringOn,omitempty"`
 	JwksURI            string   `json:"jwksURI,omitempty"`
 	IssuerURL          string   `json:"issuerURL,omitempty"`
 	Audience           string   `json:"audience,omitempty"`
@@ -375,7 +679,7 @@ func (c *Client) CreateServiceAccount(sa ServiceAccount) (*ServiceAccount, error
 		return nil, fmt.Errorf("Error encoding request: %s", err)
 	}
 
-	resp, err := c.Post(path, body)
+	resp, err := c.postCreate(path, body)
 	if err != nil {
 		return nil, fmt.Errorf("Error posting request: %s", err)
 	}
@@ -390,12 +694,30 @@ func (c *Client) CreateServiceAccount(sa ServiceAccount) (*ServiceAccount, error
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if created.ID == "" {
-		return nil, fmt.Errorf("Didn't create a service account; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't create a service account", respBody)
 	}
 
+	// Wait for the new service account to become readable so that the refresh
+	// following an apply doesn't fail. This is best effort: the account exists,
+	// so its ID must still be returned.
+	_ = c.waitUntilReadable(func() error {
+		_, err := c.GetServiceAccount(created.ID)
+		return err
+	})
+
 	return &created, nil
 }
 
+func (c *Client) GetServiceAccounts() ([]ServiceAccount, error) {
+	path := c.Path(`%s/v1/serviceaccounts`)
+
+	return getAllPages(c, path, "service accounts", func(body []byte) ([]ServiceAccount, error) {
+		var sas []ServiceAccount
+		err := json.Unmarshal(body, &sas)
+		return sas, err
+	})
+}
+
 func (c *Client) GetServiceAccount(id string) (*ServiceAccount, error) {
 	path := c.Path(`%s/v1/serviceaccounts/` + id)
 
@@ -414,7 +736,7 @@ func (c *Client) GetServiceAccount(id string) (*ServiceAccount, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if sa.ID == "" {
-		return nil, fmt.Errorf("Didn't find a Service Account; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't find a Service Account", respBody)
 	}
 
 	return &sa, nil
@@ -440,12 +762,37 @@ func (c *Client) UpdateServiceAccount(sa ServiceAccount) error {
 	if resp.StatusCode != http.StatusNoContent {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to update Service Account; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to update Service Account", respBody)
 	}
 
 	return nil
 }
 
+func (c *Client) RotateServiceAccountCredentials(id string) (*ServiceAccount, error) {
+	// Regenerates the credentials of an existing service account, the account itself (and its ID) is retained.
+	path := c.Path(`%s/v1/serviceaccounts/` + id + `/credentials`)
+
+	resp, err := c.Post(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error posting request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp, "Failed to rotate Service Account credentials", respBody)
+	}
+	var rotated ServiceAccount
+	err = json.Unmarshal(respBody, &rotated)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+
+	return &rotated, nil
+}
+
 func (c *Client) DeleteServiceAccount(id string) error {
 	path := c.Path(`%s/v1/serviceaccounts/` + id)
 
@@ -456,18 +803,72 @@ func (c *Client) DeleteServiceAccount(id string) error {
 	if resp.StatusCode != http.StatusNoContent {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to delete Service Account; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to delete Service Account", respBody)
 	}
 
 	return nil
 }
 
+type ServiceAccountScope struct {
+	ID string `json:"id"`
+}
+
+type ServiceAccountScopes struct {
+	Scopes []ServiceAccountScope `json:"scopes"`
+}
+
+// GetServiceAccountScopes returns the IDs of the scopes which service
+// accounts can be granted, which are cached.
+func (c *Client) GetServiceAccountScopes() ([]string, error) {
+	return c.scopes.get("", func() ([]string, error) {
+		path := c.Path(`%s/v1/serviceaccounts/scopes`)
+
+		resp, err := c.Get(path)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting Service Account scopes: %s", err)
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading response body: %s", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, newAPIError(resp, "Failed to get Service Account scopes", respBody)
+		}
+		var got ServiceAccountScopes
+		err = json.Unmarshal(respBody, &got)
+		if err != nil {
+			return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+		}
+
+		ids := []string{}
+		for _, s := range got.Scopes {
+			ids = append(ids, s.ID)
+		}
+		return ids, nil
+	})
+}
+
 type Plugin struct {
 	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
 	Type     string `json:"pluginType"`
 	Manifest any    `json:"manifest"`
 }
 
+// PluginName returns the name of a plugin, which comes from its manifest.
+func (p Plugin) PluginName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	if m, ok := p.Manifest.(map[string]any); ok {
+		if name, ok := m["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
 type plugins struct {
 	Plugins []Plugin `json:"plugins"`
 }
@@ -480,7 +881,12 @@ func (c *Client) CreatePlugin(p Plugin) (*Plugin, error) {
 		return nil, fmt.Errorf("Error encoding request: %s", err)
 	}
 
-	resp, err := c.Post(path, body)
+	resp, err := c.postCreate(path, body)
+	if createUncertain(err) {
+		if existing, findErr := c.GetPluginByName(p.Type, p.PluginName()); findErr == nil {
+			return existing, nil
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Error posting request: %s", err)
 	}
@@ -498,7 +904,7 @@ func (c *Client) CreatePlugin(p Plugin) (*Plugin, error) {
 		return nil, fmt.Errorf("Unexpected number of plugins returned (%d): %s", len(created.Plugins), string(respBody))
 	}
 	if created.Plugins[0].ID == "" {
-		return nil, fmt.Errorf("Didn't create a plugin; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't create a plugin", respBody)
 	}
 
 	return &created.Plugins[0], nil
@@ -522,12 +928,50 @@ func (c *Client) GetPlugin(id string) (*Plugin, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if plugin.ID == "" {
-		return nil, fmt.Errorf("Didn't find a Plugin; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't find a Plugin", respBody)
 	}
 
 	return &plugin, nil
 }
 
+// GetPlugins returns the installed plugins, optionally only those of
+// pluginType.
+func (c *Client) GetPlugins(pluginType string) ([]Plugin, error) {
+	path := c.Path(`%s/v1/plugins`)
+	if pluginType != "" {
+		queryParams := url.Values{}
+		queryParams.Set("pluginType", pluginType)
+		path = path + "?" + queryParams.Encode()
+	}
+
+	return getAllPages(c, path, "plugins", func(body []byte) ([]Plugin, error) {
+		var plugins plugins
+		err := json.Unmarshal(body, &plugins)
+		return plugins.Plugins, err
+	})
+}
+
+func (c *Client) GetPluginByName(pluginType, name string) (*Plugin, error) {
+	plugins, err := c.GetPlugins(pluginType)
+	if err != nil {
+		return nil, err
+	}
+
+	var byName []Plugin
+	for _, p := range plugins {
+		if p.PluginName() == name {
+			byName = append(byName, p)
+		}
+	}
+	if len(byName) > 1 {
+		return nil, fmt.Errorf("Unexpected number of plugins returned (%d)", len(byName))
+	}
+	if len(byName) == 0 {
+		return nil, fmt.Errorf("Plugin not found: %s", name)
+	}
+	return &byName[0], nil
+}
+
 func (c *Client) UpdatePlugin(p Plugin) error {
 	id := p.ID
 	if id == "" {
@@ -548,7 +992,7 @@ func (c *Client) UpdatePlugin(p Plugin) error {
 	if resp.StatusCode != http.StatusOK {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to update Plugin; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to update Plugin", respBody)
 	}
 
 	return nil
@@ -564,7 +1008,7 @@ func (c *Client) DeletePlugin(id string) error {
 	if resp.StatusCode != http.StatusNoContent {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to delete Plugin; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to delete Plugin", respBody)
 	}
 
 	return nil
@@ -601,23 +1045,26 @@ type caAccount struct {
 	ProductOptions []CAProductOption `json:"productOptions"`
 }
 
-func (c *Client) GetCAProductOption(kind, name, option string) (*CAProductOption, *CAAccount, error) {
-	path := c.Path(`%s/v1/certificateauthorities/` + kind + "/accounts")
+// getCAAccounts returns the accounts for a kind of CA, which are cached as
+// every certificate template needs them.
+func (c *Client) getCAAccounts(kind string) ([]caAccount, error) {
+	return c.caAccounts.get(kind, func() ([]caAccount, error) {
+		path := c.Path(`%s/v1/certificateauthorities/` + kind + "/accounts")
 
-	resp, err := c.Get(path)
-	if err != nil {
-		return nil, nil, fmt.Errorf("Error getting ca product: %s", err)
-	}
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, nil, fmt.Errorf("Error reading response body: %s", err)
-	}
-	var accounts caAccounts
-	err = json.Unmarshal(body, &accounts)
+		return getAllPages(c, path, "ca accounts", func(body []byte) ([]caAccount, error) {
+			var accounts caAccounts
+			err := json.Unmarshal(body, &accounts)
+			return accounts.Accounts, err
+		})
+	})
+}
+
+func (c *Client) GetCAProductOption(kind, name, option string) (*CAProductOption, *CAAccount, error) {
+	accounts, err := c.getCAAccounts(kind)
 	if err != nil {
-		return nil, nil, fmt.Errorf("Error decoding response: %s", string(body))
+		return nil, nil, err
 	}
-	for _, acc := range accounts.Accounts {
+	for _, acc := range accounts {
 		acct := acc.Account
 		if acct.Name != name {
 			continue
@@ -633,22 +1080,11 @@ func (c *Client) GetCAProductOption(kind, name, option string) (*CAProductOption
 }
 
 func (c *Client) GetCAProductOptionByID(kind, option_id string) (*CAProductOption, error) {
-	path := c.Path(`%s/v1/certificateauthorities/` + kind + "/accounts")
-
-	resp, err := c.Get(path)
-	if err != nil {
-		return nil, fmt.Errorf("Error getting ca product: %s", err)
-	}
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("Error reading response body: %s", err)
-	}
-	var accounts caAccounts
-	err = json.Unmarshal(body, &accounts)
+	accounts, err := c.getCAAccounts(kind)
 	if err != nil {
-		return nil, fmt.Errorf("Error decoding response: %s", string(body))
+		return nil, err
 	}
-	for _, acc := range accounts.Accounts {
+	for _, acc := range accounts {
 		for _, opt := range acc.ProductOptions {
 			if opt.ID == option_id {
 				return &opt, nil
@@ -694,7 +1130,7 @@ func (c *Client) CreateCertificateTemplate(ct CertificateTemplate) (*Certificate
 		return nil, fmt.Errorf("Error encoding request: %s", err)
 	}
 
-	resp, err := c.Post(path, body)
+	resp, err := c.postCreate(path, body)
 	if err != nil {
 		return nil, fmt.Errorf("Error posting request: %s", err)
 	}
@@ -712,7 +1148,7 @@ func (c *Client) CreateCertificateTemplate(ct CertificateTemplate) (*Certificate
 		return nil, fmt.Errorf("Unexpected number of templates returned (%d): %s %s", len(created.Templates), string(respBody), string(body))
 	}
 	if created.Templates[0].ID == "" {
-		return nil, fmt.Errorf("Didn't create a template; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't create a template", respBody)
 	}
 
 	return &created.Templates[0], nil
@@ -736,7 +1172,7 @@ func (c *Client) GetCertificateTemplate(id string) (*CertificateTemplate, error)
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if ct.ID == "" {
-		return nil, fmt.Errorf("Didn't find a Certificate Template; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't find a Certificate Template", respBody)
 	}
 
 	return &ct, nil
@@ -764,7 +1200,7 @@ func (c *Client) UpdateCertificateTemplate(ct CertificateTemplate) (*Certificate
 		return nil, fmt.Errorf("Error reading response body: %s", err)
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return nil, fmt.Errorf("Failed to update certificate template; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Failed to update certificate template", respBody)
 	}
 
 	var updated CertificateTemplate
@@ -786,7 +1222,7 @@ func (c *Client) DeleteCertificateTemplate(id string) error {
 	if resp.StatusCode != http.StatusNoContent {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to delete certificate template; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to delete certificate template", respBody)
 	}
 
 	return nil
@@ -820,7 +1256,12 @@ func (c *Client) CreateApplication(app Application) (*Application, error) {
 		return nil, fmt.Errorf("Error encoding request: %s", err)
 	}
 
-	resp, err := c.Post(path, body)
+	resp, err := c.postCreate(path, body)
+	if createUncertain(err) {
+		if existing, findErr := c.GetApplicationByName(app.Name); findErr == nil {
+			return existing, nil
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Error posting request: %s", err)
 	}
@@ -838,36 +1279,34 @@ func (c *Client) CreateApplication(app Application) (*Application, error) {
 		return nil, fmt.Errorf("Unexpected number of applications returned (%d): %s %s", len(created.Applications), string(respBody), string(body))
 	}
 	if created.Applications[0].ID == "" {
-		return nil, fmt.Errorf("Didn't create a application; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't create a application", respBody)
 	}
 
 	return &created.Applications[0], nil
 }
 
-func (c *Client) GetApplicationByName(name string) (*Application, error) {
+func (c *Client) GetApplications() ([]Application, error) {
 	path := c.Path(`%s/outagedetection/v1/applications`)
 	queryParams := url.Values{}
 	queryParams.Set("ownerDetails", "true")
 	queryParams.Set("ownershipCheck", "true")
 	path = path + "?" + queryParams.Encode()
 
-	resp, err := c.Get(path)
-	if err != nil {
-		return nil, fmt.Errorf("Error getting applications: %s", err)
-	}
+	return getAllPages(c, path, "applications", func(body []byte) ([]Application, error) {
+		var apps applications
+		err := json.Unmarshal(body, &apps)
+		return apps.Applications, err
+	})
+}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("Error reading response body: %s", err)
-	}
-	var apps applications
-	err = json.Unmarshal(respBody, &apps)
+func (c *Client) GetApplicationByName(name string) (*Application, error) {
+	apps, err := c.GetApplications()
 	if err != nil {
-		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+		return nil, err
 	}
 	var appsByName []Application
 	// Loop through all applications and append only those with matching name to appsByName.
-	for _, a := range apps.Applications {
+	for _, a := range apps {
 		if a.Name == name {
 			appsByName = append(appsByName, a)
 		}
@@ -901,7 +1340,7 @@ func (c *Client) GetApplication(id string) (*Application, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if app.ID == "" {
-		return nil, fmt.Errorf("Didn't find a Application; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't find a Application", respBody)
 	}
 
 	return &app, nil
@@ -929,7 +1368,7 @@ func (c *Client) UpdateApplication(app Application) (*Application, error) {
 		return nil, fmt.Errorf("Error reading response body: %s", err)
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return nil, fmt.Errorf("Failed to update application; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Failed to update application", respBody)
 	}
 
 	var updated Application
@@ -941,6 +1380,28 @@ func (c *Client) UpdateApplication(app Application) (*Application, error) {
 	return &updated, nil
 }
 
+// ModifyApplication updates an application with the result of update, which
+// is passed the current application. Different attributes of an application
+// are managed by different resources, so updates are serialised to avoid
+// them losing each other's changes.
+func (c *Client) ModifyApplication(id string, update func(*Application)) (*Application, error) {
+	if id == "" {
+		return nil, errors.New("Empty ID")
+	}
+
+	c.applicationsMu.Lock()
+	defer c.applicationsMu.Unlock()
+
+	app, err := c.GetApplication(id)
+	if err != nil {
+		return nil, err
+	}
+	update(app)
+	app.ID = id
+
+	return c.UpdateApplication(*app)
+}
+
 func (c *Client) DeleteApplication(id string) error {
 	path := c.Path(`%s/outagedetection/v1/applications/` + id)
 
@@ -951,7 +1412,7 @@ func (c *Client) DeleteApplication(id string) error {
 	if resp.StatusCode != http.StatusOK {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to delete certificate template; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to delete certificate template", respBody)
 	}
 
 	return nil
@@ -964,41 +1425,74 @@ type CertificateTemplates struct {
 func (c *Client) GetCertTemplates() ([]CertificateTemplate, error) {
 	path := c.Path(`%s/v1/certificateissuingtemplates/`)
 
-	resp, err := c.Get(path)
-	if err != nil {
-		return nil, fmt.Errorf("Error getting certificate template: %s", err)
-	}
+	return getAllPages(c, path, "certificate templates", func(body []byte) ([]CertificateTemplate, error) {
+		var ct CertificateTemplates
+		err := json.Unmarshal(body, &ct)
+		return ct.Templates, err
+	})
+}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("Error reading response body: %s", err)
-	}
-	var ct CertificateTemplates
-	err = json.Unmarshal(respBody, &ct)
-	if err != nil {
-		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
-	}
+type FireflyConfig struct {
+	ID                   string                   `json:"id,omitempty"`
+	Name                 string                   `json:"name"`
+	PolicyIds            []string                 `json:"policyIds"`
+	Policies             []FireflyPolicy          `json:"policies,omitempty"`
+	ServiceAccountIds    []string                 `json:"serviceAccountIds"`
+	SubCAProviderId      string                   `json:"subCaProviderId"`
+	MinTLSVersion        string                   `json:"minTlsVersion"`
+	ClientAuthentication *ClientAuthentication    `json:"clientAuthentication,omitempty"`
+	CloudProviders       CloudProviders           `json:"cloudProviders"`
+	AdvancedSettings     *FireflyAdvancedSettings `json:"advancedSettings,omitempty"`
+}
+
+// Firefly key storage types
+const (
+	FireflyKeyStorageInMemory  = "IN_MEMORY"
+	FireflyKeyStoragePersisted = "PERSISTED"
+)
 
-	return ct.Templates, nil
+type FireflyAdvancedSettings struct {
+	KeyStorage                   string `json:"keyStorage,omitempty"`
+	MinHeartbeatIntervalSeconds  int64  `json:"minHeartbeatIntervalSeconds,omitempty"`
+	EnableIssuanceAuditLog       bool   `json:"enableIssuanceAuditLog"`
+	IncludeRawCertDataInAuditLog bool   `json:"includeRawCertDataInAuditLog"`
+	RequireFIPSCompliantBuild    bool   `json:"requireFIPSCompliantBuild"`
+}
 
+type CloudProviders struct {
+	AWS   *CloudProvidersAWS   `json:"aws,omitempty"`
+	Azure *CloudProvidersAzure `json:"azure,omitempty"`
+	GCP   *CloudProvidersGCP   `json:"gcp,omitempty"`
 }
 
-type FireflyConfig struct {
-	ID                string          `json:"id,omitempty"`
-	Name              string          `json:"name"`
-	PolicyIds         []string        `json:"policyIds"`
-	Policies          []FireflyPolicy `json:"policies,omitempty"`
-	ServiceAccountIds []string        `json:"serviceAccountIds"`
-	SubCAProviderId   string          `json:"subCaProviderId"`
-	MinTLSVersion     string          `json:"minTlsVersion"`
-	//ClientAuthentication ClientAuthentication `json:"clientAuthentication,omitempty"`
-	CloudProviders CloudProviders `json:"cloudProviders"`
+type CloudProvidersAWS struct {
+	AccountIDs []string `json:"accountIds"`
+	Regions    []string `json:"regions"`
+}
+
+type CloudProvidersAzure struct {
+	SubscriptionIDs []string `json:"subscriptionIds"`
+}
+
+type CloudProvidersGCP struct {
+	ProjectIdentifiers []string `json:"projectIdentifiers"`
+	Regions            []string `json:"regions"`
 }
 
-type CloudProviders struct{}
+// Firefly client authentication types other than the JWT ones
+const (
+	// ClientAuthenticationNone clears a previously configured type.
+	ClientAuthenticationNone = "NONE"
+	ClientAuthenticationMTLS = "MTLS"
+)
 
 type ClientAuthentication struct {
-	Type string `json:"type,omitempty"`
+	Type         string   `json:"type,omitempty"`
+	URLs         []string `json:"urls,omitempty"`
+	Audience     string   `json:"audience,omitempty"`
+	Issuers      []string `json:"issuers,omitempty"`
+	ClientIDs    []string `json:"clientIds,omitempty"`
+	TrustAnchors []string `json:"trustAnchors,omitempty"`
 }
 
 func (c *Client) CreateFireflyConfig(ff FireflyConfig) (*FireflyConfig, error) {
@@ -1009,7 +1503,7 @@ func (c *Client) CreateFireflyConfig(ff FireflyConfig) (*FireflyConfig, error) {
 		return nil, fmt.Errorf("Error encoding request: %s", err)
 	}
 
-	resp, err := c.Post(path, body)
+	resp, err := c.postCreate(path, body)
 	if err != nil {
 		return nil, fmt.Errorf("Error posting request: %s", err)
 	}
@@ -1024,7 +1518,7 @@ func (c *Client) CreateFireflyConfig(ff FireflyConfig) (*FireflyConfig, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if created.ID == "" {
-		return nil, fmt.Errorf("Didn't create a Firefly Config; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't create a Firefly Config", respBody)
 	}
 
 	return &created, nil
@@ -1048,7 +1542,7 @@ func (c *Client) GetFireflyConfig(id string) (*FireflyConfig, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if got.ID == "" {
-		return nil, fmt.Errorf("Didn't find a Firefly Config; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't find a Firefly Config", respBody)
 	}
 
 	return &got, nil
@@ -1076,7 +1570,7 @@ func (c *Client) UpdateFireflyConfig(ff FireflyConfig) (*FireflyConfig, error) {
 		return nil, fmt.Errorf("Error reading response body: %s", err)
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return nil, fmt.Errorf("Failed to update Firefly Config; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Failed to update Firefly Config", respBody)
 	}
 
 	var updated FireflyConfig
@@ -1098,7 +1592,7 @@ func (c *Client) DeleteFireflyConfig(id string) error {
 	if resp.StatusCode != http.StatusOK {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to delete Firefly Config; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to delete Firefly Config", respBody)
 	}
 
 	return nil
@@ -1109,10 +1603,27 @@ type FireflySubCAProvider struct {
 	Name              string `json:"name"`
 	CAType            string `json:"caType,omitempty"`
 	CAAccountID       string `json:"caAccountId,omitempty"`
-	CAProductOptionID string `json:"caProductOptionId"`
+	CAProductOptionID string `json:"caProductOptionId,omitempty"`
 	CommonName        string `json:"commonName"`
 	KeyAlgorithm      string `json:"keyAlgorithm"`
 	ValidityPeriod    string `json:"validityPeriod"`
+	// PKCS11 protects the Sub CA's keys in an HSM, rather than in memory.
+	PKCS11 *FireflyPKCS11 `json:"pkcs11,omitempty"`
+}
+
+// FireflySelfSignedCAType is the caType of Sub CA Providers which sign their
+// own certificates, rather than having them issued by a CA account.
+const FireflySelfSignedCAType = "SELF_SIGNED"
+
+// FireflyPKCS11 configures the HSM, accessed with PKCS#11, in which a Sub CA
+// Provider's keys are kept.
+type FireflyPKCS11 struct {
+	AllowedClientLibraries []string `json:"allowedClientLibraries"`
+	PartitionLabel         string   `json:"partitionLabel"`
+	PartitionSerialNumber  string   `json:"partitionSerialNumber,omitempty"`
+	// PIN is only sent, and never returned by the API.
+	PIN            string `json:"pin,omitempty"`
+	SigningEnabled bool   `json:"signingEnabled"`
 }
 
 func (c *Client) CreateFireflySubCAProvider(ff FireflySubCAProvider) (*FireflySubCAProvider, error) {
@@ -1123,7 +1634,7 @@ func (c *Client) CreateFireflySubCAProvider(ff FireflySubCAProvider) (*FireflySu
 		return nil, fmt.Errorf("Error encoding request: %s", err)
 	}
 
-	resp, err := c.Post(path, body)
+	resp, err := c.postCreate(path, body)
 	if err != nil {
 		return nil, fmt.Errorf("Error posting request: %s", err)
 	}
@@ -1138,7 +1649,7 @@ func (c *Client) CreateFireflySubCAProvider(ff FireflySubCAProvider) (*FireflySu
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if created.ID == "" {
-		return nil, fmt.Errorf("Didn't create a Firefly SubCAProvider; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't create a Firefly SubCAProvider", respBody)
 	}
 
 	return &created, nil
@@ -1162,7 +1673,7 @@ func (c *Client) GetFireflySubCAProvider(id string) (*FireflySubCAProvider, erro
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if got.ID == "" {
-		return nil, fmt.Errorf("Didn't find a Firefly SubCAProvider; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't find a Firefly SubCAProvider", respBody)
 	}
 
 	return &got, nil
@@ -1192,7 +1703,7 @@ func (c *Client) UpdateFireflySubCAProvider(ff FireflySubCAProvider) (*FireflySu
 		return nil, fmt.Errorf("Error reading response body: %s", err)
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return nil, fmt.Errorf("Failed to update Firefly SubCAProvider; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Failed to update Firefly SubCAProvider", respBody)
 	}
 
 	var updated FireflySubCAProvider
@@ -1214,21 +1725,21 @@ func (c *Client) DeleteFireflySubCAProvider(id string) error {
 	if resp.StatusCode != http.StatusOK {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to delete Firefly SubCAProvider; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to delete Firefly SubCAProvider", respBody)
 	}
 
 	return nil
 }
 
 type FireflyPolicy struct {
-	ID                string               `json:"id,omitempty"`
-	Name              string               `json:"name"`
-	ExtendedKeyUsages []string             `json:"extendedKeyUsages"`
-	KeyAlgorithm      KeyAlgorithm         `json:"keyAlgorithm"`
-	KeyUsages         []string             `json:"keyUsages"`
-	SANs              SANs                 `json:"sans"`
-	Subject           FireflyPolicySubject `json:"subject"`
-	ValidityPeriod    string               `json:"validityPeriod"`
+	ID                string                `json:"id,omitempty"`
+	Name              string                `json:"name"`
+	ExtendedKeyUsages []string              `json:"extendedKeyUsages"`
+	KeyAlgorithm      KeyAlgorithm          `json:"keyAlgorithm"`
+	KeyUsages         []string              `json:"keyUsages"`
+	SANs              *SANs                 `json:"sans,omitempty"`
+	Subject           *FireflyPolicySubject `json:"subject,omitempty"`
+	ValidityPeriod    string                `json:"validityPeriod"`
 }
 
 type KeyAlgorithm struct {
@@ -1268,7 +1779,12 @@ func (c *Client) CreateFireflyPolicy(ff FireflyPolicy) (*FireflyPolicy, error) {
 		return nil, fmt.Errorf("Error encoding request: %s", err)
 	}
 
-	resp, err := c.Post(path, body)
+	resp, err := c.postCreate(path, body)
+	if createUncertain(err) {
+		if existing, findErr := c.GetFireflyPolicyByName(ff.Name); findErr == nil {
+			return existing, nil
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Error posting request: %s", err)
 	}
@@ -1283,7 +1799,7 @@ func (c *Client) CreateFireflyPolicy(ff FireflyPolicy) (*FireflyPolicy, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if created.ID == "" {
-		return nil, fmt.Errorf("Didn't create a Firefly Policy; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't create a Firefly Policy", respBody)
 	}
 
 	return &created, nil
@@ -1307,12 +1823,43 @@ func (c *Client) GetFireflyPolicy(id string) (*FireflyPolicy, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if got.ID == "" {
-		return nil, fmt.Errorf("Didn't find a Firefly Policy; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't find a Firefly Policy", respBody)
 	}
 
 	return &got, nil
 }
 
+type FireflyPolicies struct {
+	Policies []FireflyPolicy `json:"policies"`
+}
+
+func (c *Client) GetFireflyPolicyByName(name string) (*FireflyPolicy, error) {
+	path := c.Path(`%s/v1/distributedissuers/policies`)
+
+	policies, err := getAllPages(c, path, "Firefly Policies", func(body []byte) ([]FireflyPolicy, error) {
+		var policies FireflyPolicies
+		err := json.Unmarshal(body, &policies)
+		return policies.Policies, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var policiesByName []FireflyPolicy
+	for _, p := range policies {
+		if p.Name == name {
+			policiesByName = append(policiesByName, p)
+		}
+	}
+	if len(policiesByName) > 1 {
+		return nil, fmt.Errorf("Unexpected number of Firefly Policies returned (%d)", len(policiesByName))
+	}
+	if len(policiesByName) == 0 {
+		return nil, fmt.Errorf("Firefly Policy not found: %s", name)
+	}
+	return &policiesByName[0], nil
+}
+
 func (c *Client) UpdateFireflyPolicy(ff FireflyPolicy) (*FireflyPolicy, error) {
 	id := ff.ID
 	if id == "" {
@@ -1335,7 +1882,7 @@ func (c *Client) UpdateFireflyPolicy(ff FireflyPolicy) (*FireflyPolicy, error) {
 		return nil, fmt.Errorf("Error reading response body: %s", err)
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return nil, fmt.Errorf("Failed to update Firefly Policy; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Failed to update Firefly Policy", respBody)
 	}
 
 	var updated FireflyPolicy
@@ -1357,7 +1904,7 @@ func (c *Client) DeleteFireflyPolicy(id string) error {
 	if resp.StatusCode != http.StatusOK {
 		// returning an error here anyway, no more information if we couldn't read the body
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Failed to delete Firefly Policy; response was: %s", string(respBody))
+		return newAPIError(resp, "Failed to delete Firefly Policy", respBody)
 	}
 
 	return nil
@@ -1447,8 +1994,1275 @@ func (c *Client) GetUserAccounts() (*UserAccountResponse, error) {
 		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
 	}
 	if userAccount.Company.ID == "" {
-		return nil, fmt.Errorf("Didn't find user account information; response was: %s", string(respBody))
+		return nil, newAPIError(resp, "Didn't find user account information", respBody)
 	}
 
 	return &userAccount, nil
 }
+
+type VSatellite struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Status  string `json:"status,omitempty"`
+	Version string `json:"version,omitempty"`
+	// LastSeenOn is when the VSatellite last sent a heartbeat.
+	LastSeenOn string `json:"lastSeenOn,omitempty"`
+}
+
+type VSatellites struct {
+	VSatellites []VSatellite `json:"edgeInstances"`
+}
+
+type PairingCode struct {
+	PairingCode string `json:"pairingCode"`
+	ExpiryDate  string `json:"expiryDate"`
+}
+
+func (c *Client) CreateVSatellite(vs VSatellite) (*VSatellite, error) {
+	path := c.Path(`%s/v1/edgeinstances`)
+
+	body, err := json.Marshal(vs)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.postCreate(path, body)
+	if createUncertain(err) {
+		if existing, findErr := c.GetVSatelliteByName(vs.Name); findErr == nil {
+			return existing, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error posting request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var created VSatellite
+	err = json.Unmarshal(respBody, &created)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if created.ID == "" {
+		return nil, newAPIError(resp, "Didn't create a VSatellite", respBody)
+	}
+
+	return &created, nil
+}
+
+func (c *Client) GetVSatellite(id string) (*VSatellite, error) {
+	path := c.Path(`%s/v1/edgeinstances/` + id)
+
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting VSatellite: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var got VSatellite
+	err = json.Unmarshal(respBody, &got)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if got.ID == "" {
+		return nil, newAPIError(resp, "Didn't find a VSatellite", respBody)
+	}
+
+	return &got, nil
+}
+
+func (c *Client) GetVSatelliteByName(name string) (*VSatellite, error) {
+	path := c.Path(`%s/v1/edgeinstances`)
+
+	vsatellites, err := getAllPages(c, path, "VSatellites", func(body []byte) ([]VSatellite, error) {
+		var vsatellites VSatellites
+		err := json.Unmarshal(body, &vsatellites)
+		return vsatellites.VSatellites, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var byName []VSatellite
+	for _, v := range vsatellites {
+		if v.Name == name {
+			byName = append(byName, v)
+		}
+	}
+	if len(byName) > 1 {
+		return nil, fmt.Errorf("Unexpected number of VSatellites returned (%d)", len(byName))
+	}
+	if len(byName) == 0 {
+		return nil, fmt.Errorf("VSatellite not found: %s", name)
+	}
+	return &byName[0], nil
+}
+
+func (c *Client) UpdateVSatellite(vs VSatellite) (*VSatellite, error) {
+	id := vs.ID
+	if id == "" {
+		return nil, errors.New("Empty ID")
+	}
+	path := c.Path(`%s/v1/edgeinstances/` + id)
+
+	// Only the name of a VSatellite may be changed
+	body, err := json.Marshal(VSatellite{Name: vs.Name})
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.Patch(path, body)
+	if err != nil {
+		return nil, fmt.Errorf("Error patching request: %s", err)
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "Failed to update VSatellite", respBody)
+	}
+
+	var updated VSatellite
+	err = json.Unmarshal(respBody, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+
+	return &updated, nil
+}
+
+func (c *Client) DeleteVSatellite(id string) error {
+	path := c.Path(`%s/v1/edgeinstances/` + id)
+
+	resp, err := c.Delete(path, nil)
+	if err != nil {
+		return fmt.Errorf("Error with delete request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		// returning an error here anyway, no more information if we couldn't read the body
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "Failed to delete VSatellite", respBody)
+	}
+
+	return nil
+}
+
+func (c *Client) CreateVSatellitePairingCode(id string) (*PairingCode, error) {
+	path := c.Path(`%s/v1/edgeinstances/` + id + `/pairingcodes`)
+
+	resp, err := c.Post(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error posting request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var pc PairingCode
+	err = json.Unmarshal(respBody, &pc)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if pc.PairingCode == "" {
+		return nil, newAPIError(resp, "Didn't create a pairing code", respBody)
+	}
+
+	return &pc, nil
+}
+
+type VSatelliteWorker struct {
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name"`
+	VSatelliteID string `json:"edgeInstanceId,omitempty"`
+	Status       string `json:"status,omitempty"`
+	// LastSeenOn is when the worker last sent a heartbeat.
+	LastSeenOn string `json:"lastSeenOn,omitempty"`
+}
+
+type VSatelliteWorkers struct {
+	VSatelliteWorkers []VSatelliteWorker `json:"edgeWorkers"`
+}
+
+func (c *Client) CreateVSatelliteWorker(w VSatelliteWorker) (*VSatelliteWorker, error) {
+	path := c.Path(`%s/v1/edgeworkers`)
+
+	body, err := json.Marshal(w)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.postCreate(path, body)
+	if err != nil {
+		return nil, fmt.Errorf("Error posting request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var created VSatelliteWorker
+	err = json.Unmarshal(respBody, &created)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if created.ID == "" {
+		return nil, newAPIError(resp, "Didn't create a VSatellite Worker", respBody)
+	}
+
+	return &created, nil
+}
+
+// GetVSatelliteWorkers returns the workers belonging to a VSatellite.
+func (c *Client) GetVSatelliteWorkers(vsatelliteID string) ([]VSatelliteWorker, error) {
+	path := c.Path(`%s/v1/edgeworkers?edgeInstanceId=` + url.QueryEscape(vsatelliteID))
+
+	return getAllPages(c, path, "VSatellite Workers", func(body []byte) ([]VSatelliteWorker, error) {
+		var workers VSatelliteWorkers
+		err := json.Unmarshal(body, &workers)
+		return workers.VSatelliteWorkers, err
+	})
+}
+
+func (c *Client) GetVSatelliteWorker(id string) (*VSatelliteWorker, error) {
+	path := c.Path(`%s/v1/edgeworkers/` + id)
+
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting VSatellite Worker: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var got VSatelliteWorker
+	err = json.Unmarshal(respBody, &got)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if got.ID == "" {
+		return nil, newAPIError(resp, "Didn't find a VSatellite Worker", respBody)
+	}
+
+	return &got, nil
+}
+
+func (c *Client) UpdateVSatelliteWorker(w VSatelliteWorker) (*VSatelliteWorker, error) {
+	id := w.ID
+	if id == "" {
+		return nil, errors.New("Empty ID")
+	}
+	path := c.Path(`%s/v1/edgeworkers/` + id)
+
+	// Only the name of a worker may be changed
+	body, err := json.Marshal(VSatelliteWorker{Name: w.Name})
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.Patch(path, body)
+	if err != nil {
+		return nil, fmt.Errorf("Error patching request: %s", err)
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "Failed to update VSatellite Worker", respBody)
+	}
+
+	var updated VSatelliteWorker
+	err = json.Unmarshal(respBody, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+
+	return &updated, nil
+}
+
+func (c *Client) DeleteVSatelliteWorker(id string) error {
+	path := c.Path(`%s/v1/edgeworkers/` + id)
+
+	resp, err := c.Delete(path, nil)
+	if err != nil {
+		return fmt.Errorf("Error with delete request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		// returning an error here anyway, no more information if we couldn't read the body
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "Failed to delete VSatellite Worker", respBody)
+	}
+
+	return nil
+}
+
+func (c *Client) CreateVSatelliteWorkerPairingCode(id string) (*PairingCode, error) {
+	path := c.Path(`%s/v1/edgeworkers/` + id + `/pairingcodes`)
+
+	resp, err := c.Post(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error posting request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var pc PairingCode
+	err = json.Unmarshal(respBody, &pc)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if pc.PairingCode == "" {
+		return nil, newAPIError(resp, "Didn't create a pairing code", respBody)
+	}
+
+	return &pc, nil
+}
+
+type Tag struct {
+	ID     string   `json:"id,omitempty"`
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+type Tags struct {
+	Tags []Tag `json:"tags"`
+}
+
+func (c *Client) CreateTag(tag Tag) (*Tag, error) {
+	path := c.Path(`%s/v1/tags`)
+
+	body, err := json.Marshal(tag)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.postCreate(path, body)
+	if err != nil {
+		return nil, fmt.Errorf("Error posting request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var created Tag
+	err = json.Unmarshal(respBody, &created)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if created.ID == "" {
+		return nil, newAPIError(resp, "Didn't create a Tag", respBody)
+	}
+
+	return &created, nil
+}
+
+func (c *Client) GetTag(id string) (*Tag, error) {
+	path := c.Path(`%s/v1/tags/` + id)
+
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Tag: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var got Tag
+	err = json.Unmarshal(respBody, &got)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if got.ID == "" {
+		return nil, newAPIError(resp, "Didn't find a Tag", respBody)
+	}
+
+	return &got, nil
+}
+
+func (c *Client) GetTags() ([]Tag, error) {
+	path := c.Path(`%s/v1/tags`)
+
+	return getAllPages(c, path, "Tags", func(body []byte) ([]Tag, error) {
+		var tags Tags
+		err := json.Unmarshal(body, &tags)
+		return tags.Tags, err
+	})
+}
+
+func (c *Client) UpdateTag(tag Tag) (*Tag, error) {
+	id := tag.ID
+	if id == "" {
+		return nil, errors.New("Empty ID")
+	}
+	tag.ID = ""
+	path := c.Path(`%s/v1/tags/` + id)
+
+	body, err := json.Marshal(tag)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.Patch(path, body)
+	if err != nil {
+		return nil, fmt.Errorf("Error patching request: %s", err)
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "Failed to update Tag", respBody)
+	}
+
+	var updated Tag
+	err = json.Unmarshal(respBody, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+
+	return &updated, nil
+}
+
+func (c *Client) DeleteTag(id string) error {
+	path := c.Path(`%s/v1/tags/` + id)
+
+	resp, err := c.Delete(path, nil)
+	if err != nil {
+		return fmt.Errorf("Error with delete request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		// returning an error here anyway, no more information if we couldn't read the body
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "Failed to delete Tag", respBody)
+	}
+
+	return nil
+}
+
+type TagAssignment struct {
+	Action       string   `json:"action,omitempty"`
+	EntityIDs    []string `json:"entityIds,omitempty"`
+	EntityType   string   `json:"entityType,omitempty"`
+	TargetedTags []string `json:"targetedTags"`
+}
+
+type AssignedTags struct {
+	Tags []string `json:"tags"`
+}
+
+// Tags are assigned as "name:value" strings.
+func (c *Client) AssignTags(ta TagAssignment) error {
+	path := c.Path(`%s/v1/tagsassignment`)
+
+	body, err := json.Marshal(ta)
+	if err != nil {
+		return fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.Post(path, body)
+	if err != nil {
+		return fmt.Errorf("Error posting request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		// returning an error here anyway, no more information if we couldn't read the body
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "Failed to assign Tags", respBody)
+	}
+
+	return nil
+}
+
+func (c *Client) GetAssignedTags(entityType, entityID string) ([]string, error) {
+	path := c.Path(`%s/v1/tagsassignment`)
+
+	queryParams := url.Values{}
+	queryParams.Set("entityType", entityType)
+	queryParams.Set("entityId", entityID)
+	path = path + "?" + queryParams.Encode()
+
+	return getAllPages(c, path, "assigned Tags", func(body []byte) ([]string, error) {
+		var assigned AssignedTags
+		err := json.Unmarshal(body, &assigned)
+		return assigned.Tags, err
+	})
+}
+
+type Machine struct {
+	ID                string `json:"id,omitempty"`
+	Name              string `json:"name"`
+	PluginID          string `json:"pluginId,omitempty"`
+	VSatelliteID      string `json:"edgeInstanceId,omitempty"`
+	Owner             string `json:"owningTeamId,omitempty"`
+	ConnectionDetails any    `json:"connectionDetails,omitempty"`
+	MachineType       string `json:"machineType,omitempty"`
+	Status            string `json:"status,omitempty"`
+}
+
+type Machines struct {
+	Machines []Machine `json:"machines"`
+}
+
+func (c *Client) CreateMachine(m Machine) (*Machine, error) {
+	path := c.Path(`%s/v1/machines`)
+
+	body, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.postCreate(path, body)
+	if createUncertain(err) {
+		if existing, findErr := c.GetMachineByName(m.Name); findErr == nil {
+			return existing, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error posting request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var created Machine
+	err = json.Unmarshal(respBody, &created)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if created.ID == "" {
+		return nil, newAPIError(resp, "Didn't create a Machine", respBody)
+	}
+
+	return &created, nil
+}
+
+func (c *Client) GetMachine(id string) (*Machine, error) {
+	path := c.Path(`%s/v1/machines/` + id)
+
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Machine: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var got Machine
+	err = json.Unmarshal(respBody, &got)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if got.ID == "" {
+		return nil, newAPIError(resp, "Didn't find a Machine", respBody)
+	}
+
+	return &got, nil
+}
+
+func (c *Client) GetMachineByName(name string) (*Machine, error) {
+	path := c.Path(`%s/v1/machines`)
+
+	machines, err := getAllPages(c, path, "Machines", func(body []byte) ([]Machine, error) {
+		var machines Machines
+		err := json.Unmarshal(body, &machines)
+		return machines.Machines, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var byName []Machine
+	for _, m := range machines {
+		if m.Name == name {
+			byName = append(byName, m)
+		}
+	}
+	if len(byName) > 1 {
+		return nil, fmt.Errorf("Unexpected number of Machines returned (%d)", len(byName))
+	}
+	if len(byName) == 0 {
+		return nil, fmt.Errorf("Machine not found: %s", name)
+	}
+	return &byName[0], nil
+}
+
+func (c *Client) UpdateMachine(m Machine) (*Machine, error) {
+	id := m.ID
+	if id == "" {
+		return nil, errors.New("Empty ID")
+	}
+	path := c.Path(`%s/v1/machines/` + id)
+
+	// The plugin of a machine can't be changed
+	m.ID = ""
+	m.PluginID = ""
+	m.MachineType = ""
+	m.Status = ""
+	body, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.Patch(path, body)
+	if err != nil {
+		return nil, fmt.Errorf("Error patching request: %s", err)
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "Failed to update Machine", respBody)
+	}
+
+	var updated Machine
+	err = json.Unmarshal(respBody, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+
+	return &updated, nil
+}
+
+func (c *Client) DeleteMachine(id string) error {
+	path := c.Path(`%s/v1/machines/` + id)
+
+	resp, err := c.Delete(path, nil)
+	if err != nil {
+		return fmt.Errorf("Error with delete request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		// returning an error here anyway, no more information if we couldn't read the body
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "Failed to delete Machine", respBody)
+	}
+
+	return nil
+}
+
+// A MachineIdentity is the installation of a certificate on a machine.
+type MachineIdentity struct {
+	ID            string `json:"id"`
+	CertificateID string `json:"certificateId"`
+	MachineID     string `json:"machineId"`
+	Status        string `json:"status"`
+	StatusDetails string `json:"statusDetails"`
+}
+
+type MachineIdentities struct {
+	MachineIdentities []MachineIdentity `json:"machineIdentities"`
+}
+
+// GetMachineIdentities returns the installations of a certificate on a machine.
+func (c *Client) GetMachineIdentities(certificateID, machineID string) ([]MachineIdentity, error) {
+	path := c.Path(`%s/v1/machineidentities`)
+
+	queryParams := url.Values{}
+	queryParams.Set("certificateId", certificateID)
+	queryParams.Set("machineId", machineID)
+	path = path + "?" + queryParams.Encode()
+
+	return getAllPages(c, path, "Machine Identities", func(body []byte) ([]MachineIdentity, error) {
+		var identities MachineIdentities
+		err := json.Unmarshal(body, &identities)
+		return identities.MachineIdentities, err
+	})
+}
+
+type ActivityLogEntry struct {
+	ID          string `json:"id"`
+	Date        string `json:"activityDate"`
+	Type        string `json:"activityType"`
+	Name        string `json:"activityName"`
+	Criticality int64  `json:"criticality"`
+	Message     string `json:"message"`
+	Actor       string `json:"userId"`
+}
+
+// ActivityLogFilter selects activity log entries; empty fields match
+// everything. From and To are RFC3339 timestamps.
+type ActivityLogFilter struct {
+	Actor string
+	Type  string
+	From  string
+	To    string
+	// Limit is the maximum number of entries to return, newest first.
+	Limit int
+}
+
+// searchRequest is the body of the search endpoints.
+type searchRequest struct {
+	Expression *searchExpression `json:"expression,omitempty"`
+	Ordering   searchOrdering    `json:"ordering"`
+	Paging     searchPaging      `json:"paging"`
+}
+
+type searchExpression struct {
+	Operator string          `json:"operator"`
+	Operands []searchOperand `json:"operands"`
+}
+
+type searchOperand struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    any    `json:"value"`
+}
+
+type searchOrdering struct {
+	Orders []searchOrder `json:"orders"`
+}
+
+type searchOrder struct {
+	Field     string `json:"field"`
+	Direction string `json:"direction"`
+}
+
+type searchPaging struct {
+	PageNumber int `json:"pageNumber"`
+	PageSize   int `json:"pageSize"`
+}
+
+type activityLogEntries struct {
+	Entries []ActivityLogEntry `json:"activityLogEntries"`
+}
+
+func (c *Client) SearchActivityLog(filter ActivityLogFilter) ([]ActivityLogEntry, error) {
+	path := c.Path(`%s/v1/activitylogsearch`)
+
+	operands := []searchOperand{}
+	if filter.Actor != "" {
+		operands = append(operands, searchOperand{Field: "userId", Operator: "EQ", Value: filter.Actor})
+	}
+	if filter.Type != "" {
+		operands = append(operands, searchOperand{Field: "activityType", Operator: "EQ", Value: filter.Type})
+	}
+	if filter.From != "" {
+		operands = append(operands, searchOperand{Field: "activityDate", Operator: "GTE", Value: filter.From})
+	}
+	if filter.To != "" {
+		operands = append(operands, searchOperand{Field: "activityDate", Operator: "LTE", Value: filter.To})
+	}
+
+	search := searchRequest{
+		Ordering: searchOrdering{Orders: []searchOrder{{Field: "activityDate", Direction: "DESC"}}},
+	}
+	if len(operands) > 0 {
+		search.Expression = &searchExpression{Operator: "AND", Operands: operands}
+	}
+
+	return searchAllPages(c, path, "activity log", search, filter.Limit, func(body []byte) ([]ActivityLogEntry, error) {
+		var page activityLogEntries
+		err := json.Unmarshal(body, &page)
+		return page.Entries, err
+	})
+}
+
+type Certificate struct {
+	ID              string   `json:"id"`
+	CertificateName string   `json:"certificateName,omitempty"`
+	ApplicationIDs  []string `json:"applicationIds"`
+
+	// The following are only read, e.g. from certificate searches.
+	Status                 string   `json:"certificateStatus,omitempty"`
+	ValidityStart          string   `json:"validityStart,omitempty"`
+	ValidityEnd            string   `json:"validityEnd,omitempty"`
+	IssuerCN               []string `json:"issuerCN,omitempty"`
+	SignatureHashAlgorithm string   `json:"signatureHashAlgorithm,omitempty"`
+	KeyType                string   `json:"keyType,omitempty"`
+	KeyStrength            int      `json:"keyStrength,omitempty"`
+	KeyCurve               string   `json:"keyCurve,omitempty"`
+	SelfSigned             bool     `json:"selfSigned,omitempty"`
+}
+
+// CertificateFilter selects certificates in searches; empty fields match
+// everything.
+type CertificateFilter struct {
+	ApplicationID string
+	// Status is e.g. ACTIVE or RETIRED.
+	Status string
+}
+
+type certificateSearchResults struct {
+	Certificates []Certificate `json:"certificates"`
+}
+
+type certificateApplications struct {
+	CertificateIDs []string `json:"certificateIds"`
+	ApplicationIDs []string `json:"applicationIds"`
+}
+
+func (c *Client) GetCertificate(id string) (*Certificate, error) {
+	path := c.Path(`%s/outagedetection/v1/certificates/` + id)
+
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting certificate: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var got Certificate
+	err = json.Unmarshal(respBody, &got)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if got.ID == "" {
+		return nil, newAPIError(resp, "Didn't find a Certificate", respBody)
+	}
+
+	return &got, nil
+}
+
+// SearchCertificates returns the certificates matching filter, in order of
+// expiry.
+func (c *Client) SearchCertificates(filter CertificateFilter) ([]Certificate, error) {
+	path := c.Path(`%s/outagedetection/v1/certificatesearch`)
+
+	operands := []searchOperand{}
+	if filter.ApplicationID != "" {
+		operands = append(operands, searchOperand{Field: "applicationIds", Operator: "EQ", Value: filter.ApplicationID})
+	}
+	if filter.Status != "" {
+		operands = append(operands, searchOperand{Field: "certificateStatus", Operator: "EQ", Value: filter.Status})
+	}
+
+	search := searchRequest{
+		Ordering: searchOrdering{Orders: []searchOrder{{Field: "validityEnd", Direction: "ASC"}}},
+	}
+	if len(operands) > 0 {
+		search.Expression = &searchExpression{Operator: "AND", Operands: operands}
+	}
+
+	return searchAllPages(c, path, "certificates", search, 0, func(body []byte) ([]Certificate, error) {
+		var page certificateSearchResults
+		err := json.Unmarshal(body, &page)
+		return page.Certificates, err
+	})
+}
+
+// GetCertificateContents returns the PEM encoded certificate followed by its
+// chain, in order from the certificate to the root.
+func (c *Client) GetCertificateContents(id string) (string, error) {
+	if id == "" {
+		return "", errors.New("Empty ID")
+	}
+	path := c.Path(`%s/outagedetection/v1/certificates/` + id + `/contents?format=PEM&chainOrder=EE_FIRST`)
+
+	resp, err := c.Get(path)
+	if err != nil {
+		return "", fmt.Errorf("Error getting certificate contents: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("Error reading response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", newAPIError(resp, "Failed to get Certificate contents", respBody)
+	}
+	if block, _ := pem.Decode(respBody); block == nil || block.Type != "CERTIFICATE" {
+		return "", newAPIError(resp, "Didn't find a PEM encoded Certificate", respBody)
+	}
+
+	return string(respBody), nil
+}
+
+// UpdateCertificateApplications sets the applications of a certificate to
+// the result of update, which is passed the current applications. The API
+// replaces the whole list, so updates are serialised to avoid concurrent
+// updates to the same certificate losing each other's changes.
+func (c *Client) UpdateCertificateApplications(id string, update func([]string) []string) error {
+	if id == "" {
+		return errors.New("Empty ID")
+	}
+
+	c.certificateApplicationsMu.Lock()
+	defer c.certificateApplicationsMu.Unlock()
+
+	cert, err := c.GetCertificate(id)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(certificateApplications{
+		CertificateIDs: []string{id},
+		ApplicationIDs: update(cert.ApplicationIDs),
+	})
+	if err != nil {
+		return fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	path := c.Path(`%s/outagedetection/v1/certificates`)
+	resp, err := c.Patch(path, body)
+	if err != nil {
+		return fmt.Errorf("Error patching request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// returning an error here anyway, no more information if we couldn't read the body
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "Failed to update Certificate applications", respBody)
+	}
+
+	return nil
+}
+
+// CustomField is the value of a custom field, which may be required by the
+// tenant's policies, set on a certificate request.
+type CustomField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CertificateRequest struct {
+	ID             string        `json:"id,omitempty"`
+	CSR            string        `json:"certificateSigningRequest,omitempty"`
+	ApplicationID  string        `json:"applicationId"`
+	TemplateID     string        `json:"certificateIssuingTemplateId"`
+	ValidityPeriod string        `json:"validityPeriod,omitempty"`
+	CustomFields   []CustomField `json:"customFields,omitempty"`
+	Status         string        `json:"status,omitempty"`
+	CertificateIDs []string      `json:"certificateIds,omitempty"`
+	CreationDate   string        `json:"creationDate,omitempty"`
+	// ErrorInformation describes why the request failed, if it did.
+	ErrorInformation *CertificateRequestError `json:"errorInformation,omitempty"`
+}
+
+// CertificateRequestFilter selects certificate requests in searches; empty
+// fields match everything. From and To are RFC3339 timestamps.
+type CertificateRequestFilter struct {
+	ApplicationID string
+	TemplateID    string
+	// Statuses matches requests with any of the statuses, e.g. REJECTED.
+	Statuses []string
+	From     string
+	To       string
+	// Limit is the maximum number of requests to return, newest first.
+	Limit int
+}
+
+type CertificateRequestError struct {
+	Type    string `json:"type"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type certificateRequests struct {
+	CertificateRequests []CertificateRequest `json:"certificateRequests"`
+}
+
+func (c *Client) SearchCertificateRequests(filter CertificateRequestFilter) ([]CertificateRequest, error) {
+	path := c.Path(`%s/outagedetection/v1/certificaterequestssearch`)
+
+	operands := []searchOperand{}
+	if filter.ApplicationID != "" {
+		operands = append(operands, searchOperand{Field: "applicationId", Operator: "EQ", Value: filter.ApplicationID})
+	}
+	if filter.TemplateID != "" {
+		operands = append(operands, searchOperand{Field: "certificateIssuingTemplateId", Operator: "EQ", Value: filter.TemplateID})
+	}
+	if len(filter.Statuses) > 0 {
+		operands = append(operands, searchOperand{Field: "status", Operator: "IN", Value: filter.Statuses})
+	}
+	if filter.From != "" {
+		operands = append(operands, searchOperand{Field: "creationDate", Operator: "GTE", Value: filter.From})
+	}
+	if filter.To != "" {
+		operands = append(operands, searchOperand{Field: "creationDate", Operator: "LTE", Value: filter.To})
+	}
+
+	search := searchRequest{
+		Ordering: searchOrdering{Orders: []searchOrder{{Field: "creationDate", Direction: "DESC"}}},
+	}
+	if len(operands) > 0 {
+		search.Expression = &searchExpression{Operator: "AND", Operands: operands}
+	}
+
+	return searchAllPages(c, path, "certificate requests", search, filter.Limit, func(body []byte) ([]CertificateRequest, error) {
+		var page certificateRequests
+		err := json.Unmarshal(body, &page)
+		return page.CertificateRequests, err
+	})
+}
+
+func (c *Client) CreateCertificateRequest(cr CertificateRequest) (*CertificateRequest, error) {
+	path := c.Path(`%s/outagedetection/v1/certificaterequests`)
+
+	body, err := json.Marshal(cr)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.Post(path, body)
+	if err != nil {
+		return nil, fmt.Errorf("Error posting request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var created certificateRequests
+	err = json.Unmarshal(respBody, &created)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if len(created.CertificateRequests) != 1 || created.CertificateRequests[0].ID == "" {
+		return nil, newAPIError(resp, "Didn't create a Certificate Request", respBody)
+	}
+
+	return &created.CertificateRequests[0], nil
+}
+
+func (c *Client) GetCertificateRequest(id string) (*CertificateRequest, error) {
+	if id == "" {
+		return nil, errors.New("Empty ID")
+	}
+	path := c.Path(`%s/outagedetection/v1/certificaterequests/` + id)
+
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting certificate request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var got CertificateRequest
+	err = json.Unmarshal(respBody, &got)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if got.ID == "" {
+		return nil, newAPIError(resp, "Didn't find a Certificate Request", respBody)
+	}
+
+	return &got, nil
+}
+
+// RevocationReasons are the reasons a certificate may be revoked for.
+var RevocationReasons = []string{"UNSPECIFIED", "KEY_COMPROMISE", "AFFILIATION_CHANGED", "SUPERSEDED", "CESSATION_OF_OPERATION"}
+
+type certificateRevocation struct {
+	CertificateID string `json:"certificateId"`
+	Reason        string `json:"revocationReason"`
+	Comments      string `json:"revocationComments,omitempty"`
+}
+
+// RevokeCertificate asks the CA which issued a certificate to revoke it, for
+// one of RevocationReasons. Not all CAs support revocation.
+func (c *Client) RevokeCertificate(id, reason, comments string) error {
+	if id == "" {
+		return errors.New("Empty ID")
+	}
+	path := c.Path(`%s/outagedetection/v1/certificaterequests/revocation`)
+
+	body, err := json.Marshal(certificateRevocation{CertificateID: id, Reason: reason, Comments: comments})
+	if err != nil {
+		return fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.Post(path, body)
+	if err != nil {
+		return fmt.Errorf("Error posting request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		// returning an error here anyway, no more information if we couldn't read the body
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "Failed to revoke Certificate", respBody)
+	}
+
+	return nil
+}
+
+type certificateRetirement struct {
+	CertificateIDs []string `json:"certificateIds"`
+}
+
+// RetireCertificates retires certificates, removing them from the inventory
+// and stopping their renewal.
+func (c *Client) RetireCertificates(ids []string) error {
+	path := c.Path(`%s/outagedetection/v1/certificates/retirement`)
+
+	body, err := json.Marshal(certificateRetirement{CertificateIDs: ids})
+	if err != nil {
+		return fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.Post(path, body)
+	if err != nil {
+		return fmt.Errorf("Error posting request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		// returning an error here anyway, no more information if we couldn't read the body
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "Failed to retire Certificates", respBody)
+	}
+
+	return nil
+}
+
+// ApprovalWorkflow requires certificate requests for the given applications
+// and issuing templates to be approved before they are issued.
+type ApprovalWorkflow struct {
+	ID                     string         `json:"id,omitempty"`
+	Name                   string         `json:"name"`
+	ApplicationIDs         []string       `json:"applicationIds"`
+	CertificateTemplateIDs []string       `json:"certificateIssuingTemplateIds"`
+	Approvers              []OwnerAndType `json:"approvers"`
+	RequiredApprovals      int32          `json:"requiredApprovals"`
+}
+
+type ApprovalWorkflows struct {
+	ApprovalWorkflows []ApprovalWorkflow `json:"approvalWorkflows"`
+}
+
+func (c *Client) CreateApprovalWorkflow(w ApprovalWorkflow) (*ApprovalWorkflow, error) {
+	path := c.Path(`%s/v1/approvalworkflows`)
+
+	body, err := json.Marshal(w)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.postCreate(path, body)
+	if createUncertain(err) {
+		if existing, findErr := c.GetApprovalWorkflowByName(w.Name); findErr == nil {
+			return existing, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error posting request: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var created ApprovalWorkflow
+	err = json.Unmarshal(respBody, &created)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if created.ID == "" {
+		return nil, newAPIError(resp, "Didn't create an Approval Workflow", respBody)
+	}
+
+	return &created, nil
+}
+
+func (c *Client) GetApprovalWorkflow(id string) (*ApprovalWorkflow, error) {
+	path := c.Path(`%s/v1/approvalworkflows/` + id)
+
+	resp, err := c.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Approval Workflow: %s", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	var got ApprovalWorkflow
+	err = json.Unmarshal(respBody, &got)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+	if got.ID == "" {
+		return nil, newAPIError(resp, "Didn't find an Approval Workflow", respBody)
+	}
+
+	return &got, nil
+}
+
+func (c *Client) GetApprovalWorkflowByName(name string) (*ApprovalWorkflow, error) {
+	path := c.Path(`%s/v1/approvalworkflows`)
+
+	workflows, err := getAllPages(c, path, "Approval Workflows", func(body []byte) ([]ApprovalWorkflow, error) {
+		var workflows ApprovalWorkflows
+		err := json.Unmarshal(body, &workflows)
+		return workflows.ApprovalWorkflows, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var byName []ApprovalWorkflow
+	for _, w := range workflows {
+		if w.Name == name {
+			byName = append(byName, w)
+		}
+	}
+	if len(byName) > 1 {
+		return nil, fmt.Errorf("Unexpected number of Approval Workflows returned (%d)", len(byName))
+	}
+	if len(byName) == 0 {
+		return nil, fmt.Errorf("Approval Workflow not found: %s", name)
+	}
+	return &byName[0], nil
+}
+
+func (c *Client) UpdateApprovalWorkflow(w ApprovalWorkflow) (*ApprovalWorkflow, error) {
+	id := w.ID
+	if id == "" {
+		return nil, errors.New("Empty ID")
+	}
+	path := c.Path(`%s/v1/approvalworkflows/` + id)
+
+	w.ID = ""
+	body, err := json.Marshal(w)
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding request: %s", err)
+	}
+
+	resp, err := c.Put(path, body)
+	if err != nil {
+		return nil, fmt.Errorf("Error patching request: %s", err)
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading response body: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "Failed to update Approval Workflow", respBody)
+	}
+
+	var updated ApprovalWorkflow
+	err = json.Unmarshal(respBody, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding response: %s", string(respBody))
+	}
+
+	return &updated, nil
+}
+
+func (c *Client) DeleteApprovalWorkflow(id string) error {
+	path := c.Path(`%s/v1/approvalworkflows/` + id)
+
+	resp, err := c.Delete(path, nil)
+	if err != nil {
+		return fmt.Errorf("Error with delete request: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		// returning an error here anyway, no more information if we couldn't read the body
+		respBody, _ := io.ReadAll(resp.Body)
+		return newAPIError(resp, "Failed to delete Approval Workflow", respBody)
+	}
+
+	return nil
+}
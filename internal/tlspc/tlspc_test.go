@@ -0,0 +1,1351 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tlspc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	gql "github.com/Khan/genqlient/graphql"
+)
+
+const testID = "3f1b2a9c-6d4e-4f8a-9b7c-1e2d3c4b5a69"
+
+// idBody is the response for endpoints returning a single object.
+const idBody = `{"id":"` + testID + `","name":"test"}`
+
+func ok(body string) fixture {
+	return fixture{status: http.StatusOK, body: body}
+}
+
+func noContent() fixture {
+	return fixture{status: http.StatusNoContent}
+}
+
+func TestUsers(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "GetUser",
+			fixtures: map[string]fixture{
+				"GET /v1/users": ok(`{"users":[{"id":"` + testID + `","username":"user@example.com"}]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetUser("user@example.com", "")
+				return err
+			},
+		},
+		{
+			name: "GetUserByID",
+			fixtures: map[string]fixture{
+				"GET /v1/users/{id}": ok(`{"id":"` + testID + `","username":"user@example.com"}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetUserByID(testID)
+				return err
+			},
+		},
+		{
+			name: "GetUserAccounts",
+			fixtures: map[string]fixture{
+				"GET /v1/useraccounts": ok(`{"user":{"id":"` + testID + `"},"company":{"id":"` + testID + `"}}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetUserAccounts()
+				return err
+			},
+		},
+	})
+}
+
+func TestGetUserDisambiguation(t *testing.T) {
+	srv := newTestServer(t, map[string]fixture{
+		"GET /v1/users": ok(`{"users":[
+			{"id":"1","username":"user@example.com","userStatus":"ACTIVE"},
+			{"id":"2","username":"user@example.com.au","userStatus":"ACTIVE"},
+			{"id":"3","username":"User@Example.com","userStatus":"INACTIVE"}
+		]}`),
+	}, func(f fixture) fixture { return f })
+	c := newTestClient(t, srv)
+
+	cases := []struct {
+		email  string
+		status string
+		id     string
+	}{
+		{email: "user@example.com.au", id: "2"},
+		{email: "user@example.com", status: "ACTIVE", id: "1"},
+		{email: "user@example.com", status: "INACTIVE", id: "3"},
+		{email: "user@example.com"},
+		{email: "user@example.com", status: "PENDING_ACTIVATION"},
+		{email: "user@example"},
+	}
+	for _, tc := range cases {
+		user, err := c.GetUser(tc.email, tc.status)
+		if tc.id == "" {
+			if err == nil {
+				t.Errorf("GetUser(%q, %q): expected an error, got user %s", tc.email, tc.status, user.ID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("GetUser(%q, %q): unexpected error: %s", tc.email, tc.status, err)
+		} else if user.ID != tc.id {
+			t.Errorf("GetUser(%q, %q): expected user %s, got %s", tc.email, tc.status, tc.id, user.ID)
+		}
+	}
+}
+
+func TestTeams(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "GetTeamByName",
+			fixtures: map[string]fixture{
+				"GET /v1/teams": ok(`{"teams":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetTeamByName("test")
+				return err
+			},
+		},
+		{
+			name: "CreateTeam",
+			fixtures: map[string]fixture{
+				"POST /v1/teams": {status: http.StatusCreated, body: idBody},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateTeam(Team{Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "GetTeam",
+			fixtures: map[string]fixture{
+				"GET /v1/teams/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetTeam(testID)
+				return err
+			},
+		},
+		{
+			name: "UpdateTeam",
+			fixtures: map[string]fixture{
+				"PATCH /v1/teams/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateTeam(Team{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "AddTeamOwners",
+			fixtures: map[string]fixture{
+				"POST /v1/teams/{id}/owners": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.AddTeamOwners(testID, []string{testID})
+				return err
+			},
+		},
+		{
+			name: "RemoveTeamOwners",
+			fixtures: map[string]fixture{
+				"DELETE /v1/teams/{id}/owners": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.RemoveTeamOwners(testID, []string{testID})
+				return err
+			},
+		},
+		{
+			name: "DeleteTeam",
+			fixtures: map[string]fixture{
+				"DELETE /v1/teams/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.DeleteTeam(testID)
+			},
+			noBody: true,
+		},
+	})
+}
+
+func TestServiceAccounts(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "CreateServiceAccount",
+			fixtures: map[string]fixture{
+				"POST /v1/serviceaccounts":     {status: http.StatusCreated, body: idBody},
+				"GET /v1/serviceaccounts/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateServiceAccount(ServiceAccount{Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "GetServiceAccount",
+			fixtures: map[string]fixture{
+				"GET /v1/serviceaccounts/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetServiceAccount(testID)
+				return err
+			},
+		},
+		{
+			name: "UpdateServiceAccount",
+			fixtures: map[string]fixture{
+				"PATCH /v1/serviceaccounts/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.UpdateServiceAccount(ServiceAccount{ID: testID, Name: "test"})
+			},
+			noBody: true,
+		},
+		{
+			name: "RotateServiceAccountCredentials",
+			fixtures: map[string]fixture{
+				"POST /v1/serviceaccounts/{id}/credentials": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.RotateServiceAccountCredentials(testID)
+				return err
+			},
+		},
+		{
+			name: "DeleteServiceAccount",
+			fixtures: map[string]fixture{
+				"DELETE /v1/serviceaccounts/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.DeleteServiceAccount(testID)
+			},
+			noBody: true,
+		},
+		{
+			name: "GetServiceAccountScopes",
+			fixtures: map[string]fixture{
+				"GET /v1/serviceaccounts/scopes": ok(`{"scopes":[{"id":"certificate-issuance"}]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetServiceAccountScopes()
+				return err
+			},
+		},
+	})
+}
+
+func TestPlugins(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "CreatePlugin",
+			fixtures: map[string]fixture{
+				"POST /v1/plugins": {status: http.StatusCreated, body: `{"plugins":[` + idBody + `]}`},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreatePlugin(Plugin{Type: "CA"})
+				return err
+			},
+		},
+		{
+			name: "GetPlugin",
+			fixtures: map[string]fixture{
+				"GET /v1/plugins/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetPlugin(testID)
+				return err
+			},
+		},
+		{
+			name: "GetPlugins",
+			fixtures: map[string]fixture{
+				"GET /v1/plugins": ok(`{"plugins":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetPlugins("CA")
+				return err
+			},
+		},
+		{
+			name: "GetPluginByName",
+			fixtures: map[string]fixture{
+				"GET /v1/plugins": ok(`{"plugins":[{"id":"` + testID + `","pluginType":"CA","manifest":{"name":"digicert"}}]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetPluginByName("CA", "digicert")
+				return err
+			},
+		},
+		{
+			name: "UpdatePlugin",
+			fixtures: map[string]fixture{
+				"PATCH /v1/plugins/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				return c.UpdatePlugin(Plugin{ID: testID, Type: "CA"})
+			},
+			noBody: true,
+		},
+		{
+			name: "DeletePlugin",
+			fixtures: map[string]fixture{
+				"DELETE /v1/plugins/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.DeletePlugin(testID)
+			},
+			noBody: true,
+		},
+	})
+}
+
+func TestCAProductOptions(t *testing.T) {
+	accounts := `{"accounts":[{"account":{"id":"` + testID + `","key":"account"},"productOptions":[{"id":"` + testID + `","productName":"option"}]}]}`
+
+	runClientCases(t, []clientCase{
+		{
+			name: "GetCAProductOption",
+			fixtures: map[string]fixture{
+				"GET /v1/certificateauthorities/{kind}/accounts": ok(accounts),
+			},
+			call: func(c *Client) error {
+				_, _, err := c.GetCAProductOption("DIGICERT", "account", "option")
+				return err
+			},
+		},
+		{
+			name: "GetCAProductOptionByID",
+			fixtures: map[string]fixture{
+				"GET /v1/certificateauthorities/{kind}/accounts": ok(accounts),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetCAProductOptionByID("DIGICERT", testID)
+				return err
+			},
+		},
+	})
+}
+
+func TestCertificateTemplates(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "CreateCertificateTemplate",
+			fixtures: map[string]fixture{
+				"POST /v1/certificateissuingtemplates": {status: http.StatusCreated, body: `{"certificateIssuingTemplates":[` + idBody + `]}`},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateCertificateTemplate(CertificateTemplate{Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "GetCertificateTemplate",
+			fixtures: map[string]fixture{
+				"GET /v1/certificateissuingtemplates/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetCertificateTemplate(testID)
+				return err
+			},
+		},
+		{
+			name: "GetCertTemplates",
+			fixtures: map[string]fixture{
+				"GET /v1/certificateissuingtemplates/{$}": ok(`{"certificateIssuingTemplates":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetCertTemplates()
+				return err
+			},
+		},
+		{
+			name: "UpdateCertificateTemplate",
+			fixtures: map[string]fixture{
+				"PUT /v1/certificateissuingtemplates/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateCertificateTemplate(CertificateTemplate{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "DeleteCertificateTemplate",
+			fixtures: map[string]fixture{
+				"DELETE /v1/certificateissuingtemplates/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.DeleteCertificateTemplate(testID)
+			},
+			noBody: true,
+		},
+	})
+}
+
+func TestApplications(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "CreateApplication",
+			fixtures: map[string]fixture{
+				"POST /outagedetection/v1/applications": {status: http.StatusCreated, body: `{"applications":[` + idBody + `]}`},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateApplication(Application{Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "GetApplicationByName",
+			fixtures: map[string]fixture{
+				"GET /outagedetection/v1/applications": ok(`{"applications":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetApplicationByName("test")
+				return err
+			},
+		},
+		{
+			name: "GetApplication",
+			fixtures: map[string]fixture{
+				"GET /outagedetection/v1/applications/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetApplication(testID)
+				return err
+			},
+		},
+		{
+			name: "UpdateApplication",
+			fixtures: map[string]fixture{
+				"PUT /outagedetection/v1/applications/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateApplication(Application{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "ModifyApplication",
+			fixtures: map[string]fixture{
+				"GET /outagedetection/v1/applications/{id}": ok(idBody),
+				"PUT /outagedetection/v1/applications/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.ModifyApplication(testID, func(app *Application) {
+					app.Name = "test"
+				})
+				return err
+			},
+		},
+		{
+			name: "DeleteApplication",
+			fixtures: map[string]fixture{
+				"DELETE /outagedetection/v1/applications/{id}": ok(""),
+			},
+			call: func(c *Client) error {
+				return c.DeleteApplication(testID)
+			},
+			noBody: true,
+		},
+	})
+}
+
+func TestFirefly(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "CreateFireflyConfig",
+			fixtures: map[string]fixture{
+				"POST /v1/distributedissuers/configurations": {status: http.StatusCreated, body: idBody},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateFireflyConfig(FireflyConfig{Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "GetFireflyConfig",
+			fixtures: map[string]fixture{
+				"GET /v1/distributedissuers/configurations/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetFireflyConfig(testID)
+				return err
+			},
+		},
+		{
+			name: "UpdateFireflyConfig",
+			fixtures: map[string]fixture{
+				"PATCH /v1/distributedissuers/configurations/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateFireflyConfig(FireflyConfig{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "DeleteFireflyConfig",
+			fixtures: map[string]fixture{
+				"DELETE /v1/distributedissuers/configurations/{id}": ok(""),
+			},
+			call: func(c *Client) error {
+				return c.DeleteFireflyConfig(testID)
+			},
+			noBody: true,
+		},
+		{
+			name: "CreateFireflySubCAProvider",
+			fixtures: map[string]fixture{
+				"POST /v1/distributedissuers/subcaproviders": {status: http.StatusCreated, body: idBody},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateFireflySubCAProvider(FireflySubCAProvider{Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "GetFireflySubCAProvider",
+			fixtures: map[string]fixture{
+				"GET /v1/distributedissuers/subcaproviders/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetFireflySubCAProvider(testID)
+				return err
+			},
+		},
+		{
+			name: "UpdateFireflySubCAProvider",
+			fixtures: map[string]fixture{
+				"PATCH /v1/distributedissuers/subcaproviders/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateFireflySubCAProvider(FireflySubCAProvider{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "DeleteFireflySubCAProvider",
+			fixtures: map[string]fixture{
+				"DELETE /v1/distributedissuers/subcaproviders/{id}": ok(""),
+			},
+			call: func(c *Client) error {
+				return c.DeleteFireflySubCAProvider(testID)
+			},
+			noBody: true,
+		},
+		{
+			name: "CreateFireflyPolicy",
+			fixtures: map[string]fixture{
+				"POST /v1/distributedissuers/policies": {status: http.StatusCreated, body: idBody},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateFireflyPolicy(FireflyPolicy{Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "GetFireflyPolicy",
+			fixtures: map[string]fixture{
+				"GET /v1/distributedissuers/policies/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetFireflyPolicy(testID)
+				return err
+			},
+		},
+		{
+			name: "GetFireflyPolicyByName",
+			fixtures: map[string]fixture{
+				"GET /v1/distributedissuers/policies": ok(`{"policies":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetFireflyPolicyByName("test")
+				return err
+			},
+		},
+		{
+			name: "UpdateFireflyPolicy",
+			fixtures: map[string]fixture{
+				"PATCH /v1/distributedissuers/policies/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateFireflyPolicy(FireflyPolicy{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "DeleteFireflyPolicy",
+			fixtures: map[string]fixture{
+				"DELETE /v1/distributedissuers/policies/{id}": ok(""),
+			},
+			call: func(c *Client) error {
+				return c.DeleteFireflyPolicy(testID)
+			},
+			noBody: true,
+		},
+	})
+}
+
+func TestFireflyPolicyOmitsEmptyBlocks(t *testing.T) {
+	var sent map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decoding request: %s", err)
+		}
+		_, _ = w.Write([]byte(idBody))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := newTestClient(t, srv)
+	if _, err := c.CreateFireflyPolicy(FireflyPolicy{Name: "test", Subject: &FireflyPolicySubject{}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := sent["sans"]; ok {
+		t.Error("expected sans to be omitted")
+	}
+	if _, ok := sent["subject"]; !ok {
+		t.Error("expected subject to be sent")
+	}
+}
+
+func TestVSatellites(t *testing.T) {
+	pairingCode := `{"pairingCode":"ABCD-1234","expiryDate":"2030-01-01T00:00:00Z"}`
+
+	runClientCases(t, []clientCase{
+		{
+			name: "CreateVSatellite",
+			fixtures: map[string]fixture{
+				"POST /v1/edgeinstances": {status: http.StatusCreated, body: idBody},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateVSatellite(VSatellite{Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "GetVSatellite",
+			fixtures: map[string]fixture{
+				"GET /v1/edgeinstances/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetVSatellite(testID)
+				return err
+			},
+		},
+		{
+			name: "GetVSatelliteByName",
+			fixtures: map[string]fixture{
+				"GET /v1/edgeinstances": ok(`{"edgeInstances":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetVSatelliteByName("test")
+				return err
+			},
+		},
+		{
+			name: "UpdateVSatellite",
+			fixtures: map[string]fixture{
+				"PATCH /v1/edgeinstances/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateVSatellite(VSatellite{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "DeleteVSatellite",
+			fixtures: map[string]fixture{
+				"DELETE /v1/edgeinstances/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.DeleteVSatellite(testID)
+			},
+			noBody: true,
+		},
+		{
+			name: "CreateVSatellitePairingCode",
+			fixtures: map[string]fixture{
+				"POST /v1/edgeinstances/{id}/pairingcodes": {status: http.StatusCreated, body: pairingCode},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateVSatellitePairingCode(testID)
+				return err
+			},
+		},
+		{
+			name: "CreateVSatelliteWorker",
+			fixtures: map[string]fixture{
+				"POST /v1/edgeworkers": {status: http.StatusCreated, body: idBody},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateVSatelliteWorker(VSatelliteWorker{Name: "test", VSatelliteID: testID})
+				return err
+			},
+		},
+		{
+			name: "GetVSatelliteWorker",
+			fixtures: map[string]fixture{
+				"GET /v1/edgeworkers/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetVSatelliteWorker(testID)
+				return err
+			},
+		},
+		{
+			name: "GetVSatelliteWorkers",
+			fixtures: map[string]fixture{
+				"GET /v1/edgeworkers": ok(`{"edgeWorkers":[{"id":"` + testID + `","status":"ACTIVE"}]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetVSatelliteWorkers(testID)
+				return err
+			},
+		},
+		{
+			name: "UpdateVSatelliteWorker",
+			fixtures: map[string]fixture{
+				"PATCH /v1/edgeworkers/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateVSatelliteWorker(VSatelliteWorker{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "DeleteVSatelliteWorker",
+			fixtures: map[string]fixture{
+				"DELETE /v1/edgeworkers/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.DeleteVSatelliteWorker(testID)
+			},
+			noBody: true,
+		},
+		{
+			name: "CreateVSatelliteWorkerPairingCode",
+			fixtures: map[string]fixture{
+				"POST /v1/edgeworkers/{id}/pairingcodes": {status: http.StatusCreated, body: pairingCode},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateVSatelliteWorkerPairingCode(testID)
+				return err
+			},
+		},
+	})
+}
+
+func TestTags(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "CreateTag",
+			fixtures: map[string]fixture{
+				"POST /v1/tags": {status: http.StatusCreated, body: idBody},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateTag(Tag{Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "GetTag",
+			fixtures: map[string]fixture{
+				"GET /v1/tags/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetTag(testID)
+				return err
+			},
+		},
+		{
+			name: "GetTags",
+			fixtures: map[string]fixture{
+				"GET /v1/tags": ok(`{"tags":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetTags()
+				return err
+			},
+		},
+		{
+			name: "UpdateTag",
+			fixtures: map[string]fixture{
+				"PATCH /v1/tags/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateTag(Tag{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "DeleteTag",
+			fixtures: map[string]fixture{
+				"DELETE /v1/tags/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.DeleteTag(testID)
+			},
+			noBody: true,
+		},
+		{
+			name: "AssignTags",
+			fixtures: map[string]fixture{
+				"POST /v1/tagsassignment": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.AssignTags(TagAssignment{Action: "ADD", EntityIDs: []string{testID}, EntityType: "CERTIFICATE", TargetedTags: []string{"env:prod"}})
+			},
+			noBody: true,
+		},
+		{
+			name: "GetAssignedTags",
+			fixtures: map[string]fixture{
+				"GET /v1/tagsassignment": ok(`{"tags":["env:prod"]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetAssignedTags("CERTIFICATE", testID)
+				return err
+			},
+		},
+	})
+}
+
+func TestMachines(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "CreateMachine",
+			fixtures: map[string]fixture{
+				"POST /v1/machines": {status: http.StatusCreated, body: idBody},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateMachine(Machine{Name: "test", PluginID: testID, VSatelliteID: testID, Owner: testID})
+				return err
+			},
+		},
+		{
+			name: "GetMachine",
+			fixtures: map[string]fixture{
+				"GET /v1/machines/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetMachine(testID)
+				return err
+			},
+		},
+		{
+			name: "GetMachineByName",
+			fixtures: map[string]fixture{
+				"GET /v1/machines": ok(`{"machines":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetMachineByName("test")
+				return err
+			},
+		},
+		{
+			name: "GetMachineIdentities",
+			fixtures: map[string]fixture{
+				"GET /v1/machineidentities": ok(`{"machineIdentities":[{"id":"` + testID + `","status":"INSTALLED"}]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetMachineIdentities(testID, testID)
+				return err
+			},
+		},
+		{
+			name: "UpdateMachine",
+			fixtures: map[string]fixture{
+				"PATCH /v1/machines/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateMachine(Machine{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "DeleteMachine",
+			fixtures: map[string]fixture{
+				"DELETE /v1/machines/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.DeleteMachine(testID)
+			},
+			noBody: true,
+		},
+	})
+}
+
+// Update methods refuse to send a request without an ID.
+func TestUpdateRequiresID(t *testing.T) {
+	c, err := NewClient(testAPIKey, "http://127.0.0.1:0", "test")
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+
+	calls := map[string]func() error{
+		"UpdateTeam":                 func() error { _, err := c.UpdateTeam(Team{}); return err },
+		"UpdateServiceAccount":       func() error { return c.UpdateServiceAccount(ServiceAccount{}) },
+		"UpdatePlugin":               func() error { return c.UpdatePlugin(Plugin{}) },
+		"UpdateCertificateTemplate":  func() error { _, err := c.UpdateCertificateTemplate(CertificateTemplate{}); return err },
+		"UpdateApplication":          func() error { _, err := c.UpdateApplication(Application{}); return err },
+		"UpdateFireflyConfig":        func() error { _, err := c.UpdateFireflyConfig(FireflyConfig{}); return err },
+		"UpdateFireflySubCAProvider": func() error { _, err := c.UpdateFireflySubCAProvider(FireflySubCAProvider{}); return err },
+		"UpdateFireflyPolicy":        func() error { _, err := c.UpdateFireflyPolicy(FireflyPolicy{}); return err },
+		"UpdateVSatellite":           func() error { _, err := c.UpdateVSatellite(VSatellite{}); return err },
+		"UpdateVSatelliteWorker":     func() error { _, err := c.UpdateVSatelliteWorker(VSatelliteWorker{}); return err },
+		"UpdateTag":                  func() error { _, err := c.UpdateTag(Tag{}); return err },
+		"UpdateMachine":              func() error { _, err := c.UpdateMachine(Machine{}); return err },
+		"UpdateApprovalWorkflow":     func() error { _, err := c.UpdateApprovalWorkflow(ApprovalWorkflow{}); return err },
+	}
+	for name, call := range calls {
+		if err := call(); err == nil || err.Error() != "Empty ID" {
+			t.Errorf("%s: expected Empty ID error, got %v", name, err)
+		}
+	}
+}
+
+func TestActivityLog(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "SearchActivityLog",
+			fixtures: map[string]fixture{
+				"POST /v1/activitylogsearch": ok(`{"activityLogEntries":[{"id":"` + testID + `","activityType":"Authentication"}]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.SearchActivityLog(ActivityLogFilter{Type: "Authentication"})
+				return err
+			},
+		},
+	})
+}
+
+func TestSearchActivityLogFilter(t *testing.T) {
+	setListPageSize(t, 2)
+
+	var searches []searchRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var search searchRequest
+		if err := json.NewDecoder(r.Body).Decode(&search); err != nil {
+			t.Errorf("decoding request: %s", err)
+		}
+		searches = append(searches, search)
+		_, _ = w.Write([]byte(`{"activityLogEntries":[{"id":"1"},{"id":"2"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	entries, err := newTestClient(t, srv).SearchActivityLog(ActivityLogFilter{
+		Actor: testID,
+		From:  "2026-01-01T00:00:00Z",
+		Limit: 3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("expected 3 entries, got %d", len(entries))
+	}
+	if len(searches) != 2 {
+		t.Fatalf("expected 2 searches, got %d", len(searches))
+	}
+	if searches[1].Paging.PageNumber != 1 {
+		t.Errorf("expected the second page to be requested, got %d", searches[1].Paging.PageNumber)
+	}
+	if e := searches[0].Expression; e == nil || len(e.Operands) != 2 || e.Operands[0].Field != "userId" || e.Operands[1].Operator != "GTE" {
+		t.Errorf("unexpected search expression: %+v", e)
+	}
+}
+
+func TestUserAgentSuffix(t *testing.T) {
+	cases := map[string]string{
+		"":                "terraform-provider-tlspc/test",
+		"my-platform/2.3": "terraform-provider-tlspc/test my-platform/2.3",
+	}
+	for suffix, want := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("User-Agent"); got != want {
+				t.Errorf("%s: expected User-Agent %q, got %q", r.URL.Path, want, got)
+			}
+			if strings.HasSuffix(r.URL.Path, "/graphql") {
+				_, _ = w.Write([]byte(`{"data":{}}`))
+				return
+			}
+			_, _ = w.Write([]byte(idBody))
+		}))
+		t.Cleanup(srv.Close)
+
+		c := newTestClient(t, srv)
+		c.SetUserAgentSuffix(suffix)
+		if _, err := c.GetTeam(testID); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		req := &gql.Request{OpName: "Test", Query: "query Test { __typename }"}
+		if err := c.GetGraphQLClient().MakeRequest(context.Background(), req, &gql.Response{Data: &struct{}{}}); err != nil {
+			t.Fatalf("unexpected graphql error: %s", err)
+		}
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	cases := map[string][]string{
+		"":           nil,
+		"2024-06-01": {"2024-06-01"},
+	}
+	for version, want := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Values(APIVersionHeader); !slices.Equal(got, want) {
+				t.Errorf("expected %s %q, got %q", APIVersionHeader, want, got)
+			}
+			_, _ = w.Write([]byte(idBody))
+		}))
+		t.Cleanup(srv.Close)
+
+		c := newTestClient(t, srv)
+		c.SetAPIVersion(version)
+		if _, err := c.GetTeam(testID); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+func TestRegion(t *testing.T) {
+	cases := map[string]string{
+		"":                            "US",
+		"https://api.venafi.eu":       "EU",
+		"https://api.au.venafi.cloud": "AU",
+		"http://127.0.0.1:8080":       "",
+	}
+	for endpoint, want := range cases {
+		c, err := NewClient(testAPIKey, endpoint, "test")
+		if err != nil {
+			t.Fatalf("creating client: %s", err)
+		}
+		if got := c.Region(); got != want {
+			t.Errorf("Region() for %q = %q; want %q", endpoint, got, want)
+		}
+	}
+}
+
+func TestModifyApplicationKeepsOthers(t *testing.T) {
+	var sent Application
+	fixtures := map[string]fixture{
+		"GET /outagedetection/v1/applications/{id}": ok(`{"id":"` + testID + `","name":"app","fqdns":["www.example.com"],"ports":["443"]}`),
+	}
+	srv := newTestServer(t, fixtures, func(f fixture) fixture { return f })
+	mux := srv.Config.Handler.(*http.ServeMux)
+	mux.HandleFunc("PUT /outagedetection/v1/applications/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decoding request: %s", err)
+		}
+		_, _ = w.Write([]byte(idBody))
+	})
+
+	_, err := newTestClient(t, srv).ModifyApplication(testID, func(app *Application) {
+		app.Name = "renamed"
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if sent.Name != "renamed" {
+		t.Errorf("expected name renamed, got %s", sent.Name)
+	}
+	if len(sent.FQDNs) != 1 || sent.FQDNs[0] != "www.example.com" || len(sent.Ports) != 1 || sent.Ports[0] != "443" {
+		t.Errorf("expected fqdns and ports to be kept, got %v and %v", sent.FQDNs, sent.Ports)
+	}
+}
+
+func TestCertificates(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "GetCertificate",
+			fixtures: map[string]fixture{
+				"GET /outagedetection/v1/certificates/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetCertificate(testID)
+				return err
+			},
+		},
+		{
+			name: "GetCertificateContents",
+			fixtures: map[string]fixture{
+				"GET /outagedetection/v1/certificates/{id}/contents": ok("-----BEGIN CERTIFICATE-----\nMA==\n-----END CERTIFICATE-----\n"),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetCertificateContents(testID)
+				return err
+			},
+		},
+		{
+			name: "SearchCertificates",
+			fixtures: map[string]fixture{
+				"POST /outagedetection/v1/certificatesearch": ok(`{"certificates":[{"id":"` + testID + `","certificateStatus":"ACTIVE"}]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.SearchCertificates(CertificateFilter{Status: "ACTIVE"})
+				return err
+			},
+		},
+		{
+			name: "SearchCertificateRequests",
+			fixtures: map[string]fixture{
+				"POST /outagedetection/v1/certificaterequestssearch": ok(`{"certificateRequests":[{"id":"` + testID + `","status":"REJECTED"}]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.SearchCertificateRequests(CertificateRequestFilter{Statuses: []string{"REJECTED"}})
+				return err
+			},
+		},
+		{
+			name: "UpdateCertificateApplications",
+			fixtures: map[string]fixture{
+				"GET /outagedetection/v1/certificates/{id}": ok(idBody),
+				"PATCH /outagedetection/v1/certificates":    ok(`{"certificates":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				return c.UpdateCertificateApplications(testID, func(apps []string) []string {
+					return append(apps, testID)
+				})
+			},
+		},
+		{
+			name: "CreateCertificateRequest",
+			fixtures: map[string]fixture{
+				"POST /outagedetection/v1/certificaterequests": {status: http.StatusCreated, body: `{"certificateRequests":[` + idBody + `]}`},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateCertificateRequest(CertificateRequest{CSR: "csr", ApplicationID: testID, TemplateID: testID})
+				return err
+			},
+		},
+		{
+			name: "GetCertificateRequest",
+			fixtures: map[string]fixture{
+				"GET /outagedetection/v1/certificaterequests/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetCertificateRequest(testID)
+				return err
+			},
+		},
+		{
+			name: "RetireCertificates",
+			fixtures: map[string]fixture{
+				"POST /outagedetection/v1/certificates/retirement": ok(`{"certificates":[` + idBody + `]}`),
+			},
+			call: func(c *Client) error {
+				return c.RetireCertificates([]string{testID})
+			},
+			noBody: true,
+		},
+		{
+			name: "RevokeCertificate",
+			fixtures: map[string]fixture{
+				"POST /outagedetection/v1/certificaterequests/revocation": {status: http.StatusCreated, body: `{"certificateRequests":[` + idBody + `]}`},
+			},
+			call: func(c *Client) error {
+				return c.RevokeCertificate(testID, "KEY_COMPROMISE", "")
+			},
+			noBody: true,
+		},
+	})
+}
+
+func TestUpdateCertificateApplicationsKeepsOthers(t *testing.T) {
+	var sent certificateApplications
+	fixtures := map[string]fixture{
+		"GET /outagedetection/v1/certificates/{id}": ok(`{"id":"` + testID + `","applicationIds":["other"]}`),
+	}
+	srv := newTestServer(t, fixtures, func(f fixture) fixture { return f })
+	mux := srv.Config.Handler.(*http.ServeMux)
+	mux.HandleFunc("PATCH /outagedetection/v1/certificates", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decoding request: %s", err)
+		}
+	})
+
+	err := newTestClient(t, srv).UpdateCertificateApplications(testID, func(apps []string) []string {
+		return append(apps, "new")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(sent.ApplicationIDs) != 2 || sent.ApplicationIDs[0] != "other" || sent.ApplicationIDs[1] != "new" {
+		t.Errorf("expected applications [other new], got %v", sent.ApplicationIDs)
+	}
+	if len(sent.CertificateIDs) != 1 || sent.CertificateIDs[0] != testID {
+		t.Errorf("expected certificate %s, got %v", testID, sent.CertificateIDs)
+	}
+}
+
+func TestApprovalWorkflows(t *testing.T) {
+	runClientCases(t, []clientCase{
+		{
+			name: "CreateApprovalWorkflow",
+			fixtures: map[string]fixture{
+				"POST /v1/approvalworkflows": {status: http.StatusCreated, body: idBody},
+			},
+			call: func(c *Client) error {
+				_, err := c.CreateApprovalWorkflow(ApprovalWorkflow{Name: "test", RequiredApprovals: 1})
+				return err
+			},
+		},
+		{
+			name: "GetApprovalWorkflow",
+			fixtures: map[string]fixture{
+				"GET /v1/approvalworkflows/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetApprovalWorkflow(testID)
+				return err
+			},
+		},
+		{
+			name: "GetApprovalWorkflowByName",
+			fixtures: map[string]fixture{
+				"GET /v1/approvalworkflows": ok(`{"approvalWorkflows":[{"id":"` + testID + `","name":"test"}]}`),
+			},
+			call: func(c *Client) error {
+				_, err := c.GetApprovalWorkflowByName("test")
+				return err
+			},
+		},
+		{
+			name: "UpdateApprovalWorkflow",
+			fixtures: map[string]fixture{
+				"PUT /v1/approvalworkflows/{id}": ok(idBody),
+			},
+			call: func(c *Client) error {
+				_, err := c.UpdateApprovalWorkflow(ApprovalWorkflow{ID: testID, Name: "test"})
+				return err
+			},
+		},
+		{
+			name: "DeleteApprovalWorkflow",
+			fixtures: map[string]fixture{
+				"DELETE /v1/approvalworkflows/{id}": noContent(),
+			},
+			call: func(c *Client) error {
+				return c.DeleteApprovalWorkflow(testID)
+			},
+			noBody: true,
+		},
+	})
+}
+
+// teamOwnersServer serves a team whose owners are changed by the owners
+// endpoint, failing removals if failRemove is set.
+// teamOwnersServer serves a team with owners. If failRemove is set, removing
+// the owner "old" alone fails, and if extraOwner is set, it's included in the
+// owners returned after each change without being added.
+func teamOwnersServer(t *testing.T, owners []string, failRemove bool, extraOwner string) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	respond := func(w http.ResponseWriter) {
+		_ = json.NewEncoder(w).Encode(Team{ID: testID, Owners: owners})
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/teams/{id}", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		respond(w)
+	})
+	mux.HandleFunc("/v1/teams/{id}/owners", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var update updateTeamOwners
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		switch r.Method {
+		case http.MethodPost:
+			owners = append(owners, update.Owners...)
+		case http.MethodDelete:
+			if failRemove && len(update.Owners) == 1 && update.Owners[0] == "old" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			owners = slices.DeleteFunc(owners, func(o string) bool { return slices.Contains(update.Owners, o) })
+		}
+		if extraOwner != "" {
+			_ = json.NewEncoder(w).Encode(Team{ID: testID, Owners: append(slices.Clone(owners), extraOwner)})
+			return
+		}
+		respond(w)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv, &owners
+}
+
+func TestSetTeamOwners(t *testing.T) {
+	srv, owners := teamOwnersServer(t, []string{"old", "kept"}, false, "")
+
+	team, err := newTestClient(t, srv).SetTeamOwners(testID, []string{"kept", "new"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !sameSet(team.Owners, []string{"kept", "new"}) || !sameSet(*owners, team.Owners) {
+		t.Errorf("unexpected owners %v", *owners)
+	}
+}
+
+func TestSetTeamOwnersRollsBack(t *testing.T) {
+	cases := []struct {
+		name       string
+		owners     []string
+		failRemove bool
+		extraOwner string
+	}{
+		{name: "removal fails", owners: []string{"new"}, failRemove: true},
+		{name: "unexpected owners after replacing", owners: []string{"new"}, extraOwner: "stranger"},
+		{name: "unexpected owners after adding", owners: []string{"old", "new"}, extraOwner: "stranger"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv, owners := teamOwnersServer(t, []string{"old"}, tc.failRemove, tc.extraOwner)
+
+			_, err := newTestClient(t, srv).SetTeamOwners(testID, tc.owners)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !sameSet(*owners, []string{"old"}) {
+				t.Errorf("expected the owners to be rolled back, got %v", *owners)
+			}
+		})
+	}
+}
+
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+func TestGetTeamDependents(t *testing.T) {
+	srv := newTestServer(t, map[string]fixture{
+		"GET /v1/serviceaccounts": ok(`[{"id":"owned","owner":"` + testID + `"},{"id":"other","owner":"other"}]`),
+		"GET /outagedetection/v1/applications": ok(`{"applications":[` +
+			`{"id":"owned","ownerIdsAndTypes":[{"ownerId":"other","ownerType":"USER"},{"ownerId":"` + testID + `","ownerType":"TEAM"}]},` +
+			`{"id":"other","ownerIdsAndTypes":[{"ownerId":"other","ownerType":"TEAM"}]}]}`),
+	}, func(f fixture) fixture { return f })
+
+	deps, err := newTestClient(t, srv).GetTeamDependents(testID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(deps.ServiceAccounts) != 1 || deps.ServiceAccounts[0].ID != "owned" {
+		t.Errorf("unexpected service accounts %+v", deps.ServiceAccounts)
+	}
+	if len(deps.Applications) != 1 || deps.Applications[0].ID != "owned" {
+		t.Errorf("unexpected applications %+v", deps.Applications)
+	}
+}
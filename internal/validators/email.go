@@ -0,0 +1,73 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+func Email() emailValidator {
+	return emailValidator{}
+}
+
+type emailValidator struct {
+}
+
+// validateEmail checks that s is a bare email address, e.g. "jane@example.com"
+// rather than "Jane <jane@example.com>".
+func validateEmail(s string) error {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return err
+	}
+	if addr.Address != s {
+		return errors.New("must be a bare address without a display name or angle brackets")
+	}
+	return nil
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v emailValidator) Description(ctx context.Context) string {
+	return "string must be an email address"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v emailValidator) MarkdownDescription(ctx context.Context) string {
+	return "string must be an email address"
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v emailValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// If the value is unknown or null, there is nothing to validate.
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if err := validateEmail(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid email address",
+			"String must be an email address: "+err.Error(),
+		)
+	}
+}
+
+// ValidateParameterString performs the same validation for provider function parameters.
+func (v emailValidator) ValidateParameterString(ctx context.Context, req function.StringParameterValidatorRequest, resp *function.StringParameterValidatorResponse) {
+	if req.Value.IsUnknown() || req.Value.IsNull() {
+		return
+	}
+
+	if err := validateEmail(req.Value.ValueString()); err != nil {
+		resp.Error = function.NewArgumentFuncError(
+			req.ArgumentPosition,
+			"String must be an email address: "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// iso8601PeriodRegex matches a period given in weeks alone, or in any other
+// components, as weeks can't be combined with them.
+var iso8601PeriodRegex = regexp.MustCompile(`^P(\d+W|(\d+Y)?(\d+M)?(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?)$`)
+
+// IsISO8601Period reports whether s is an ISO8601 period such as P30D, P2W or
+// PT8H. At least one component must be present.
+func IsISO8601Period(s string) bool {
+	if !iso8601PeriodRegex.MatchString(s) {
+		return false
+	}
+	return s != "P" && !strings.HasSuffix(s, "T")
+}
+
+func ISO8601Period() iso8601PeriodValidator {
+	return iso8601PeriodValidator{}
+}
+
+type iso8601PeriodValidator struct {
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v iso8601PeriodValidator) Description(ctx context.Context) string {
+	return "string must be an ISO8601 period, e.g. P30D or PT8H"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a practitioner to understand its impact.
+func (v iso8601PeriodValidator) MarkdownDescription(ctx context.Context) string {
+	return "string must be an ISO8601 period, e.g. `P30D` or `PT8H`"
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and updating `resp` with diagnostics.
+func (v iso8601PeriodValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	// If the value is unknown or null, there is nothing to validate.
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if !IsISO8601Period(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid ISO8601 period",
+			fmt.Sprintf("String must be an ISO8601 period, e.g. P30D or PT8H, got: %s", req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) Venafi, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestISO8601Period(t *testing.T) {
+	cases := map[string]bool{
+		"P1W":            true,
+		"P30D":           true,
+		"PT8H":           true,
+		"P1Y2M3DT4H5M6S": true,
+		"P1W2D":          false,
+		"P1Y1W":          false,
+		"P1WT1H":         false,
+		"P":              false,
+		"PT":             false,
+		"P1DT":           false,
+		"30D":            false,
+		"P1.5D":          false,
+		"":               false,
+	}
+
+	for period, valid := range cases {
+		t.Run(period, func(t *testing.T) {
+			if got := IsISO8601Period(period); got != valid {
+				t.Errorf("IsISO8601Period(%q) = %t, expected %t", period, got, valid)
+			}
+
+			req := validator.StringRequest{Path: path.Root("validity_period"), ConfigValue: types.StringValue(period)}
+			var resp validator.StringResponse
+			ISO8601Period().ValidateString(context.Background(), req, &resp)
+			if got := !resp.Diagnostics.HasError(); got != valid {
+				t.Errorf("validating %q passed %t, expected %t", period, got, valid)
+			}
+		})
+	}
+}
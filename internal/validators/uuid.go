@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
 
@@ -46,3 +47,17 @@ func (v uuidValidator) ValidateString(ctx context.Context, req validator.StringR
 		return
 	}
 }
+
+// ValidateParameterString performs the same validation for provider function parameters.
+func (v uuidValidator) ValidateParameterString(ctx context.Context, req function.StringParameterValidatorRequest, resp *function.StringParameterValidatorResponse) {
+	if req.Value.IsUnknown() || req.Value.IsNull() {
+		return
+	}
+
+	if err := uuid.Validate(req.Value.ValueString()); err != nil {
+		resp.Error = function.NewArgumentFuncError(
+			req.ArgumentPosition,
+			fmt.Sprintf("String must be a uuid: %s", err),
+		)
+	}
+}